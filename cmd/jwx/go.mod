@@ -5,10 +5,10 @@ go 1.17
 require (
 	github.com/lestrrat-go/jwx/v2 v2.0.11
 	github.com/urfave/cli/v2 v2.24.4
-	golang.org/x/crypto v0.9.0
 )
 
 require (
+	github.com/cloudflare/circl v1.2.0 // indirect
 	github.com/cpuguy83/go-md2man/v2 v2.0.2 // indirect
 	github.com/decred/dcrd/dcrec/secp256k1/v4 v4.2.0 // indirect
 	github.com/goccy/go-json v0.10.2 // indirect
@@ -20,5 +20,8 @@ require (
 	github.com/russross/blackfriday/v2 v2.1.0 // indirect
 	github.com/segmentio/asm v1.2.0 // indirect
 	github.com/xrash/smetrics v0.0.0-20201216005158-039620a65673 // indirect
-	golang.org/x/sys v0.8.0 // indirect
+	golang.org/x/crypto v0.10.0 // indirect
+	golang.org/x/sys v0.9.0 // indirect
 )
+
+replace github.com/lestrrat-go/jwx/v2 v2.0.11 => ../..
@@ -2,19 +2,13 @@ package main
 
 import (
 	"bytes"
-	"crypto/ecdsa"
-	"crypto/elliptic"
-	"crypto/rand"
-	"crypto/rsa"
 	"encoding/json"
 	"fmt"
 	"io"
 
 	"github.com/lestrrat-go/jwx/v2/jwa"
 	"github.com/lestrrat-go/jwx/v2/jwk"
-	"github.com/lestrrat-go/jwx/v2/x25519"
 	"github.com/urfave/cli/v2"
-	"golang.org/x/crypto/ed25519"
 )
 
 func init() {
@@ -128,73 +122,28 @@ func makeJwkGenerateCmd() *cli.Command {
 	}
 
 	cmd.Action = func(c *cli.Context) error {
-		var rawkey interface{}
-		switch typ := jwa.KeyType(c.String("type")); typ {
-		case jwa.RSA:
-			v, err := rsa.GenerateKey(rand.Reader, c.Int("keysize"))
-			if err != nil {
-				return fmt.Errorf(`failed to generate rsa private key: %w`, err)
-			}
-			rawkey = v
-		case jwa.EC:
-			var crv elliptic.Curve
-
-			var crvalg jwa.EllipticCurveAlgorithm
-			if err := crvalg.Accept(c.String("curve")); err != nil {
-				return fmt.Errorf(`invalid elliptic curve name %s: %w`, c.String("curve"), err)
-			}
-
-			crv, ok := jwk.CurveForAlgorithm(crvalg)
-			if !ok {
-				return fmt.Errorf(`invalid elliptic curve for ECDSA: %s (expected %s)`, crvalg, crvnames.String())
-			}
-
-			v, err := ecdsa.GenerateKey(crv, rand.Reader)
-			if err != nil {
-				return fmt.Errorf(`failed to generate ECDSA private key: %w`, err)
-			}
-			rawkey = v
-		case jwa.OctetSeq:
-			octets := make([]byte, c.Int("keysize"))
-			rand.Reader.Read(octets)
+		typ := jwa.KeyType(c.String("type"))
 
-			rawkey = octets
-		case jwa.OKP:
+		options := []jwk.GenerateOption{jwk.WithKeySize(c.Int("keysize"))}
+		if crvname := c.String("curve"); crvname != "" {
 			var crvalg jwa.EllipticCurveAlgorithm
-			if err := crvalg.Accept(c.String("curve")); err != nil {
-				return fmt.Errorf(`invalid elliptic curve name: %w`, err)
+			if err := crvalg.Accept(crvname); err != nil {
+				return fmt.Errorf(`invalid elliptic curve name %s: %w`, crvname, err)
 			}
+			options = append(options, jwk.WithCurve(crvalg))
+		}
 
-			switch crvalg {
-			case jwa.Ed25519:
-				_, priv, err := ed25519.GenerateKey(rand.Reader)
-				if err != nil {
-					return fmt.Errorf(`failed to generate ed25519 private key: %w`, err)
-				}
-				rawkey = priv
-			case jwa.X25519:
-				_, priv, err := x25519.GenerateKey(rand.Reader)
-				if err != nil {
-					return fmt.Errorf(`failed to generate x25519 private key: %w`, err)
-				}
-				rawkey = priv
-			default:
-				return fmt.Errorf(`invalid elliptic curve for OKP: %s (expected %s/%s)`, crvalg, jwa.Ed25519, jwa.X25519)
-			}
-		default:
-			return fmt.Errorf(`invalid key type %s`, typ)
+		key, err := jwk.Generate(typ, options...)
+		if err != nil {
+			return fmt.Errorf(`failed to generate new JWK: %w`, err)
 		}
+
 		var attrs map[string]interface{}
 		if tmpl := c.String("template"); tmpl != "" {
 			if err := json.Unmarshal([]byte(tmpl), &attrs); err != nil {
 				return fmt.Errorf(`failed to unmarshal template: %w`, err)
 			}
 		}
-		key, err := jwk.FromRaw(rawkey)
-		if err != nil {
-			return fmt.Errorf(`failed to create new JWK from raw key: %w`, err)
-		}
-
 		for k, v := range attrs {
 			if err := key.Set(k, v); err != nil {
 				return fmt.Errorf(`failed to set field %s: %w`, k, err)
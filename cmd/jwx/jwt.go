@@ -0,0 +1,459 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/lestrrat-go/jwx/v2/jwa"
+	"github.com/lestrrat-go/jwx/v2/jwe"
+	"github.com/lestrrat-go/jwx/v2/jwk"
+	"github.com/lestrrat-go/jwx/v2/jws"
+	"github.com/lestrrat-go/jwx/v2/jwt"
+	"github.com/urfave/cli/v2"
+)
+
+func init() {
+	topLevelCommands = append(topLevelCommands, makeJwtCmd())
+}
+
+func makeJwtCmd() *cli.Command {
+	var cmd cli.Command
+	cmd.Name = "jwt"
+	cmd.Usage = "Work with JWT tokens"
+
+	cmd.Subcommands = []*cli.Command{
+		makeJwtSignCmd(),
+		makeJwtParseCmd(),
+		makeJwtValidateCmd(),
+	}
+	return &cmd
+}
+
+// jwtVerifyFlags returns the flags that are used to specify the
+// keys to verify (and optionally decrypt) a JWT with.
+func jwtVerifyFlags() []cli.Flag {
+	return []cli.Flag{
+		jwsAlgorithmFlag("verify"),
+		&cli.StringFlag{
+			Name:    "key",
+			Aliases: []string{"k"},
+			Usage:   "`FILE` containing the single key to verify with",
+		},
+		&cli.StringFlag{
+			Name:  "keyset",
+			Usage: "`FILE` containing the JWK set to verify with. Keys are matched using the key ID (kid)",
+		},
+		&cli.StringFlag{
+			Name:  "jku",
+			Usage: "`URL` to fetch the JWK set to verify with. Keys are matched using the key ID (kid)",
+		},
+		keyFormatFlag(),
+		&cli.BoolFlag{
+			Name:  "infer-algorithm",
+			Usage: "when verifying using --keyset or --jku, infer the algorithm from keys that do not specify \"alg\"",
+		},
+		&cli.StringFlag{
+			Name:  "decrypt-key",
+			Usage: "`FILE` containing the key to decrypt JWE enveloped tokens with",
+		},
+		&cli.BoolFlag{
+			Name:  "no-verify",
+			Usage: "do not verify the signature of the token",
+		},
+	}
+}
+
+// jwtParseOptions builds the list of jwt.ParseOption from the command line
+// flags given by jwtVerifyFlags
+func jwtParseOptions(c *cli.Context) ([]jwt.ParseOption, error) {
+	var options []jwt.ParseOption
+
+	if keyfile := c.String("decrypt-key"); keyfile != "" {
+		keyset, err := getKeyFile(keyfile, c.String("key-format"))
+		if err != nil {
+			return nil, err
+		}
+		if keyset.Len() != 1 {
+			return nil, fmt.Errorf(`jwk file must contain exactly one key`)
+		}
+		key, _ := keyset.Key(0)
+		options = append(options, jwt.WithKeyDecryptionProvider(jwe.KeyProviderFunc(func(_ context.Context, sink jwe.KeySink, r jwe.Recipient, _ *jwe.Message) error {
+			sink.Key(r.Headers().Algorithm(), key)
+			return nil
+		})))
+	}
+
+	if c.Bool("no-verify") {
+		return append(options, jwt.WithVerify(false)), nil
+	}
+
+	var verifiers int
+	if keyfile := c.String("key"); keyfile != "" {
+		verifiers++
+		keyset, err := getKeyFile(keyfile, c.String("key-format"))
+		if err != nil {
+			return nil, err
+		}
+		if keyset.Len() != 1 {
+			return nil, fmt.Errorf(`jwk file must contain exactly one key (use --keyset for JWK sets)`)
+		}
+		key, _ := keyset.Key(0)
+		pubkey, err := jwk.PublicKeyOf(key)
+		if err != nil {
+			return nil, fmt.Errorf(`failed to retrieve public key of %T: %w`, key, err)
+		}
+
+		var alg jwa.SignatureAlgorithm
+		givenalg := c.String("alg")
+		if givenalg == "" {
+			givenalg = key.Algorithm().String()
+		}
+		if givenalg == "" {
+			return nil, fmt.Errorf(`option --alg must be given when the key does not contain "alg"`)
+		}
+		if err := alg.Accept(givenalg); err != nil {
+			return nil, fmt.Errorf(`invalid alg %s`, givenalg)
+		}
+		options = append(options, jwt.WithKey(alg, pubkey))
+	}
+
+	var sets []jwk.Set
+	if keyfile := c.String("keyset"); keyfile != "" {
+		keyset, err := getKeyFile(keyfile, c.String("key-format"))
+		if err != nil {
+			return nil, err
+		}
+		sets = append(sets, keyset)
+	}
+
+	if u := c.String("jku"); u != "" {
+		keyset, err := jwk.Fetch(c.Context, u)
+		if err != nil {
+			return nil, fmt.Errorf(`failed to fetch JWK set from %s: %w`, u, err)
+		}
+		sets = append(sets, keyset)
+	}
+
+	for _, set := range sets {
+		verifiers++
+		pubset, err := jwk.PublicSetOf(set)
+		if err != nil {
+			return nil, fmt.Errorf(`failed to retrieve public keys: %w`, err)
+		}
+		options = append(options, jwt.WithKeySet(pubset, jws.WithInferAlgorithmFromKey(c.Bool("infer-algorithm"))))
+	}
+
+	if verifiers == 0 {
+		return nil, fmt.Errorf(`one of --key, --keyset, or --jku must be given (use --no-verify to skip verification)`)
+	}
+	return options, nil
+}
+
+func readJwtToken(c *cli.Context, options ...jwt.ParseOption) (jwt.Token, error) {
+	src, err := getSource(c.Args().Get(0))
+	if err != nil {
+		return nil, err
+	}
+	defer src.Close()
+
+	buf, err := io.ReadAll(src)
+	if err != nil {
+		return nil, fmt.Errorf(`failed to read data from source: %w`, err)
+	}
+
+	parseOptions, err := jwtParseOptions(c)
+	if err != nil {
+		return nil, err
+	}
+
+	tok, err := jwt.Parse(buf, append(parseOptions, options...)...)
+	if err != nil {
+		return nil, fmt.Errorf(`failed to parse token: %w`, err)
+	}
+	return tok, nil
+}
+
+func makeJwtSignCmd() *cli.Command {
+	var cmd cli.Command
+	cmd.Name = "sign"
+	cmd.Aliases = []string{"sig"}
+	cmd.Usage = "Sign (and optionally encrypt) a set of claims to generate a JWT"
+	cmd.UsageText = `jwx jwt sign [command options] FILE
+
+   Reads a JSON object containing the JWT claims in FILE, signs it,
+   and generates a JWT in compact format.
+   Use "-" as FILE to read from STDIN.
+
+   If --key-encryption is specified, the signed token is further
+   encrypted using the key specified by --encrypt-key, and a nested
+   JWT is generated.
+`
+	cmd.Flags = []cli.Flag{
+		jwsAlgorithmFlag("sign"),
+		keyFlag("sign"),
+		keyFormatFlag(),
+		&cli.StringFlag{
+			Name:  "header",
+			Usage: "header object to inject into JWS message protected header",
+		},
+		&cli.StringFlag{
+			Name:  "encrypt-key",
+			Usage: "`FILE` containing the key to encrypt the token with",
+		},
+		keyEncryptionFlag(false),
+		&cli.StringFlag{
+			Name:    "content-encryption",
+			Aliases: []string{"C"},
+			Usage:   "Content encryption algorithm name `NAME` (e.g. A128CBC-HS256, A192GCM, A256GCM, etc)",
+			Value:   jwa.A256GCM.String(),
+		},
+		outputFlag(),
+	}
+
+	cmd.Action = func(c *cli.Context) error {
+		keyset, err := getKeyFile(c.String("key"), c.String("key-format"))
+		if err != nil {
+			return err
+		}
+
+		if keyset.Len() != 1 {
+			return fmt.Errorf(`jwk file must contain exactly one key`)
+		}
+		key, _ := keyset.Key(0)
+
+		src, err := getSource(c.Args().Get(0))
+		if err != nil {
+			return err
+		}
+		defer src.Close()
+
+		buf, err := io.ReadAll(src)
+		if err != nil {
+			return fmt.Errorf(`failed to read data from source: %w`, err)
+		}
+
+		tok := jwt.New()
+		if err := json.Unmarshal(buf, tok); err != nil {
+			return fmt.Errorf(`failed to parse claims: %w`, err)
+		}
+
+		var alg jwa.SignatureAlgorithm
+		givenalg := c.String("alg")
+		if givenalg == "" {
+			return fmt.Errorf(`option --alg must be given`)
+		}
+
+		if err := alg.Accept(givenalg); err != nil {
+			return fmt.Errorf(`invalid alg %s`, givenalg)
+		}
+
+		var suboptions []jwt.Option
+		if hdrbuf := c.String("header"); hdrbuf != "" {
+			h := jws.NewHeaders()
+			if err := json.Unmarshal([]byte(hdrbuf), h); err != nil {
+				return fmt.Errorf(`failed to parse header: %w`, err)
+			}
+			suboptions = append(suboptions, jws.WithProtectedHeaders(h))
+		}
+
+		serializer := jwt.NewSerializer().
+			Sign(jwt.WithKey(alg, key, suboptions...))
+
+		if keyencalg := c.String("key-encryption"); keyencalg != "" {
+			var keyenc jwa.KeyEncryptionAlgorithm
+			if err := keyenc.Accept(keyencalg); err != nil {
+				return fmt.Errorf(`invalid key encryption algorithm: %w`, err)
+			}
+
+			var cntenc jwa.ContentEncryptionAlgorithm
+			if err := cntenc.Accept(c.String("content-encryption")); err != nil {
+				return fmt.Errorf(`invalid content encryption algorithm: %w`, err)
+			}
+
+			enckeyfile := c.String("encrypt-key")
+			if enckeyfile == "" {
+				return fmt.Errorf(`option --encrypt-key must be given when --key-encryption is specified`)
+			}
+			enckeyset, err := getKeyFile(enckeyfile, c.String("key-format"))
+			if err != nil {
+				return err
+			}
+			if enckeyset.Len() != 1 {
+				return fmt.Errorf(`jwk file must contain exactly one key`)
+			}
+			enckey, _ := enckeyset.Key(0)
+
+			pubkey, err := jwk.PublicKeyOf(enckey)
+			if err != nil {
+				return fmt.Errorf(`failed to retrieve public key of %T: %w`, enckey, err)
+			}
+
+			serializer = serializer.Encrypt(
+				jwt.WithKey(keyenc, pubkey),
+				jwt.WithEncryptOption(jwe.WithContentEncryption(cntenc)),
+			)
+		}
+
+		signed, err := serializer.Serialize(tok)
+		if err != nil {
+			return fmt.Errorf(`failed to sign token: %w`, err)
+		}
+
+		output, err := getOutput(c.String("output"))
+		if err != nil {
+			return err
+		}
+		defer output.Close()
+
+		fmt.Fprintf(output, "%s", signed)
+		return nil
+	}
+	return &cmd
+}
+
+func makeJwtParseCmd() *cli.Command {
+	var cmd cli.Command
+	cmd.Name = "parse"
+	cmd.Usage = "Parse and verify a JWT"
+	cmd.UsageText = `jwx jwt parse [command options] FILE
+
+   Parses the JWT in FILE, verifies its signature, and displays
+   the claims in the token. Use "-" as FILE to read from STDIN.
+
+   The keys to verify the token can be specified using one of
+   --key (a single key), --keyset (a JWK set), or --jku (a URL
+   pointing to a JWK set). When using JWK sets, the token must
+   specify a key ID ("kid"), and the matching key must contain
+   the "alg" field, unless --infer-algorithm is specified.
+
+   The validity of the claims (e.g. "exp") are NOT checked by
+   this command. Use "jwx jwt validate" for that purpose.
+`
+	cmd.Flags = append(jwtVerifyFlags(), outputFlag())
+
+	cmd.Action = func(c *cli.Context) error {
+		tok, err := readJwtToken(c, jwt.WithValidate(false))
+		if err != nil {
+			return err
+		}
+
+		output, err := getOutput(c.String("output"))
+		if err != nil {
+			return err
+		}
+		defer output.Close()
+
+		if err := dumpJSON(output, tok); err != nil {
+			return err
+		}
+		fmt.Fprintf(output, "\n")
+		return nil
+	}
+	return &cmd
+}
+
+// jwtValidationCheck is a single, named validation step performed
+// by "jwx jwt validate"
+type jwtValidationCheck struct {
+	name      string
+	validator jwt.Validator
+}
+
+func makeJwtValidateCmd() *cli.Command {
+	var cmd cli.Command
+	cmd.Name = "validate"
+	cmd.Usage = "Validate the claims in a JWT"
+	cmd.UsageText = `jwx jwt validate [command options] FILE
+
+   Parses the JWT in FILE, and validates the claims in the token.
+   Use "-" as FILE to read from STDIN.
+
+   The "exp", "iat", and "nbf" claims are always checked, if present.
+   Other claims are checked depending on the options given.
+
+   Results of each validation step are displayed, and the command
+   exits with a non-zero status if any of them fail.
+
+   The signature of the token is verified using the same options as
+   "jwx jwt parse". Use --no-verify to skip signature verification.
+`
+	cmd.Flags = append(jwtVerifyFlags(),
+		&cli.StringFlag{
+			Name:  "issuer",
+			Usage: "expected value `ISSUER` of the \"iss\" claim",
+		},
+		&cli.StringFlag{
+			Name:  "audience",
+			Usage: "value `AUDIENCE` that must be included in the \"aud\" claim",
+		},
+		&cli.StringFlag{
+			Name:  "subject",
+			Usage: "expected value `SUBJECT` of the \"sub\" claim",
+		},
+		&cli.DurationFlag{
+			Name:  "acceptable-skew",
+			Usage: "acceptable clock skew `DURATION` when checking time based claims",
+		},
+		&cli.StringSliceFlag{
+			Name:  "required-claim",
+			Usage: "name of the `CLAIM` that must be present in the token. May be specified multiple times",
+		},
+		outputFlag(),
+	)
+
+	cmd.Action = func(c *cli.Context) error {
+		tok, err := readJwtToken(c, jwt.WithValidate(false))
+		if err != nil {
+			return err
+		}
+
+		checks := []jwtValidationCheck{
+			{name: `exp`, validator: jwt.IsExpirationValid()},
+			{name: `iat`, validator: jwt.IsIssuedAtValid()},
+			{name: `nbf`, validator: jwt.IsNbfValid()},
+		}
+		if v := c.String("issuer"); v != "" {
+			checks = append(checks, jwtValidationCheck{name: `iss`, validator: jwt.ClaimValueIs(jwt.IssuerKey, v)})
+		}
+		if v := c.String("audience"); v != "" {
+			checks = append(checks, jwtValidationCheck{name: `aud`, validator: jwt.ClaimContainsString(jwt.AudienceKey, v)})
+		}
+		if v := c.String("subject"); v != "" {
+			checks = append(checks, jwtValidationCheck{name: `sub`, validator: jwt.ClaimValueIs(jwt.SubjectKey, v)})
+		}
+		for _, name := range c.StringSlice("required-claim") {
+			checks = append(checks, jwtValidationCheck{name: fmt.Sprintf(`required claim %q`, name), validator: jwt.IsRequired(name)})
+		}
+
+		ctx := context.Background()
+		ctx = jwt.SetValidationCtxSkew(ctx, c.Duration("acceptable-skew"))
+		ctx = jwt.SetValidationCtxClock(ctx, jwt.ClockFunc(time.Now))
+		ctx = jwt.SetValidationCtxTruncation(ctx, time.Second)
+
+		output, err := getOutput(c.String("output"))
+		if err != nil {
+			return err
+		}
+		defer output.Close()
+
+		var failed int
+		for _, check := range checks {
+			if err := check.validator.Validate(ctx, tok); err != nil {
+				failed++
+				fmt.Fprintf(output, "FAIL  %s: %s\n", check.name, err)
+				continue
+			}
+			fmt.Fprintf(output, "OK    %s\n", check.name)
+		}
+
+		if failed > 0 {
+			return errors.New(`token failed validation`)
+		}
+		return nil
+	}
+	return &cmd
+}
@@ -1,6 +1,13 @@
 load("@bazel_gazelle//:deps.bzl", "go_repository")
 
 def go_dependencies():
+    go_repository(
+        name = "com_github_cloudflare_circl",
+        build_file_proto_mode = "disable_global",
+        importpath = "github.com/cloudflare/circl",
+        sum = "h1:NheeISPSUcYftKlfrLuOo4T62FkmD4t4jviLfFFYaec=",
+        version = "v1.2.0",
+    )
     go_repository(
         name = "com_github_davecgh_go_spew",
         build_file_proto_mode = "disable_global",
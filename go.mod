@@ -3,6 +3,7 @@ module github.com/lestrrat-go/jwx/v2
 go 1.16
 
 require (
+	github.com/cloudflare/circl v1.2.0
 	github.com/decred/dcrd/dcrec/secp256k1/v4 v4.2.0
 	github.com/goccy/go-json v0.10.2
 	github.com/lestrrat-go/blackmagic v1.0.1
@@ -0,0 +1,187 @@
+// Package brainpool implements the Brainpool elliptic curves
+// brainpoolP256r1, brainpoolP384r1, and brainpoolP512r1, as
+// described in RFC 5639.
+//
+// The standard library's generic curve implementation only handles
+// curves of the form y² = x³ - 3x + b, which is not the case for the
+// Brainpool "r1" curves. However, each "r1" curve is isomorphic to a
+// "t1" curve with a = -3, so the arithmetic is performed on the "t1"
+// curve, and the points are mapped back and forth using the
+// isomorphism (x, y) -> (x * z², y * z³) described in the RFC.
+//
+// WARNING: the arithmetic is performed using elliptic.CurveParams and
+// math/big, neither of which run in constant time. Operations involving
+// private keys, such as signing and ECDH key agreement, may leak the
+// private key through timing side channels. For this reason these curves
+// are only registered when compiling with the jwx_brainpool build tag,
+// and should only be used where interoperability demands it, and where
+// attackers can not precisely time repeated private key operations.
+package brainpool
+
+import (
+	"crypto/elliptic"
+	"math/big"
+	"sync"
+)
+
+var initonce sync.Once
+var p256r1, p384r1, p512r1 *rcurve
+
+// rcurve is a Brainpool "r1" curve, whose arithmetic is delegated to
+// the isomorphic "t1" curve.
+type rcurve struct {
+	twisted *elliptic.CurveParams
+	params  *elliptic.CurveParams
+	z2      *big.Int // z²
+	z3      *big.Int // z³
+	zinv2   *big.Int // z⁻²
+	zinv3   *big.Int // z⁻³
+}
+
+func fromHex(s string) *big.Int {
+	v, ok := new(big.Int).SetString(s, 16)
+	if !ok {
+		panic("brainpool: invalid hex constant " + s)
+	}
+	return v
+}
+
+// newRCurve creates a new "r1" curve given its domain parameters and the
+// isomorphism constant z. The parameters for the "t1" curve are derived
+// from them.
+func newRCurve(name string, bitSize int, p, a, b, gx, gy, n, z string) *rcurve {
+	params := &elliptic.CurveParams{
+		Name:    name,
+		P:       fromHex(p),
+		N:       fromHex(n),
+		B:       fromHex(b),
+		Gx:      fromHex(gx),
+		Gy:      fromHex(gy),
+		BitSize: bitSize,
+	}
+	zz := fromHex(z)
+
+	c := &rcurve{params: params}
+	c.z2 = new(big.Int).Exp(zz, big.NewInt(2), params.P)
+	c.z3 = new(big.Int).Exp(zz, big.NewInt(3), params.P)
+	zinv := new(big.Int).ModInverse(zz, params.P)
+	c.zinv2 = new(big.Int).Exp(zinv, big.NewInt(2), params.P)
+	c.zinv3 = new(big.Int).Exp(zinv, big.NewInt(3), params.P)
+
+	// a * z⁴ must be -3 for the "t1" curve to be usable with
+	// elliptic.CurveParams
+	check := new(big.Int).Mul(fromHex(a), new(big.Int).Mul(c.z2, c.z2))
+	check.Add(check, big.NewInt(3))
+	if check.Mod(check, params.P).Sign() != 0 {
+		panic("brainpool: invalid isomorphism constant for " + name)
+	}
+
+	// b' = b * z⁶
+	bt := new(big.Int).Mul(params.B, new(big.Int).Mul(c.z3, c.z3))
+	bt.Mod(bt, params.P)
+	gxt, gyt := c.toTwisted(params.Gx, params.Gy)
+	c.twisted = &elliptic.CurveParams{
+		Name:    name,
+		P:       params.P,
+		N:       params.N,
+		B:       bt,
+		Gx:      gxt,
+		Gy:      gyt,
+		BitSize: bitSize,
+	}
+	return c
+}
+
+func initAll() {
+	p256r1 = newRCurve("brainpoolP256r1", 256,
+		"A9FB57DBA1EEA9BC3E660A909D838D726E3BF623D52620282013481D1F6E5377",
+		"7D5A0975FC2C3057EEF67530417AFFE7FB8055C126DC5C6CE94A4B44F330B5D9",
+		"26DC5C6CE94A4B44F330B5D9BBD77CBF958416295CF7E1CE6BCCDC18FF8C07B6",
+		"8BD2AEB9CB7E57CB2C4B482FFC81B7AFB9DE27E1E3BD23C23A4453BD9ACE3262",
+		"547EF835C3DAC4FD97F8461A14611DC9C27745132DED8E545C1D54C72F046997",
+		"A9FB57DBA1EEA9BC3E660A909D838D718C397AA3B561A6F7901E0E82974856A7",
+		"3E2D4BD9597B58639AE7AA669CAB9837CF5CF20A2C852D10F655668DFC150EF0",
+	)
+	p384r1 = newRCurve("brainpoolP384r1", 384,
+		"8CB91E82A3386D280F5D6F7E50E641DF152F7109ED5456B412B1DA197FB71123ACD3A729901D1A71874700133107EC53",
+		"7BC382C63D8C150C3C72080ACE05AFA0C2BEA28E4FB22787139165EFBA91F90F8AA5814A503AD4EB04A8C7DD22CE2826",
+		"04A8C7DD22CE28268B39B55416F0447C2FB77DE107DCD2A62E880EA53EEB62D57CB4390295DBC9943AB78696FA504C11",
+		"1D1C64F068CF45FFA2A63A81B7C13F6B8847A3E77EF14FE3DB7FCAFE0CBD10E8E826E03436D646AAEF87B2E247D4AF1E",
+		"8ABE1D7520F9C2A45CB1EB8E95CFD55262B70B29FEEC5864E19C054FF99129280E4646217791811142820341263C5315",
+		"8CB91E82A3386D280F5D6F7E50E641DF152F7109ED5456B31F166E6CAC0425A7CF3AB6AF6B7FC3103B883202E9046565",
+		"41DFE8DD399331F7166A66076734A89CD0D2BCDB7D068E44E1F378F41ECBAE97D2D63DBC87BCCDDCCC5DA39E8589291C",
+	)
+	p512r1 = newRCurve("brainpoolP512r1", 512,
+		"AADD9DB8DBE9C48B3FD4E6AE33C9FC07CB308DB3B3C9D20ED6639CCA703308717D4D9B009BC66842AECDA12AE6A380E62881FF2F2D82C68528AA6056583A48F3",
+		"7830A3318B603B89E2327145AC234CC594CBDD8D3DF91610A83441CAEA9863BC2DED5D5AA8253AA10A2EF1C98B9AC8B57F1117A72BF2C7B9E7C1AC4D77FC94CA",
+		"3DF91610A83441CAEA9863BC2DED5D5AA8253AA10A2EF1C98B9AC8B57F1117A72BF2C7B9E7C1AC4D77FC94CADC083E67984050B75EBAE5DD2809BD638016F723",
+		"81AEE4BDD82ED9645A21322E9C4C6A9385ED9F70B5D916C1B43B62EEF4D0098EFF3B1F78E2D0D48D50D1687B93B97D5F7C6D5047406A5E688B352209BCB9F822",
+		"7DDE385D566332ECC0EABFA9CF7822FDF209F70024A57B1AA000C55B881F8111B2DCDE494A5F485E5BCA4BD88A2763AED1CA2B2FA8F0540678CD1E0F3AD80892",
+		"AADD9DB8DBE9C48B3FD4E6AE33C9FC07CB308DB3B3C9D20ED6639CCA70330870553E5C414CA92619418661197FAC10471DB1D381085DDADDB58796829CA90069",
+		"12EE58E6764838B69782136F0F2D3BA06E27695716054092E60A80BEDB212B64E585D90BCE13761F85C3F1D2A64E3BE8FEA2220F01EBA5EEB0F35DBD29D922AB",
+	)
+}
+
+// P256r1 returns a Curve which implements brainpoolP256r1
+func P256r1() elliptic.Curve {
+	initonce.Do(initAll)
+	return p256r1
+}
+
+// P384r1 returns a Curve which implements brainpoolP384r1
+func P384r1() elliptic.Curve {
+	initonce.Do(initAll)
+	return p384r1
+}
+
+// P512r1 returns a Curve which implements brainpoolP512r1
+func P512r1() elliptic.Curve {
+	initonce.Do(initAll)
+	return p512r1
+}
+
+func (c *rcurve) toTwisted(x, y *big.Int) (*big.Int, *big.Int) {
+	tx := new(big.Int).Mul(x, c.z2)
+	tx.Mod(tx, c.params.P)
+	ty := new(big.Int).Mul(y, c.z3)
+	ty.Mod(ty, c.params.P)
+	return tx, ty
+}
+
+func (c *rcurve) fromTwisted(tx, ty *big.Int) (*big.Int, *big.Int) {
+	x := new(big.Int).Mul(tx, c.zinv2)
+	x.Mod(x, c.params.P)
+	y := new(big.Int).Mul(ty, c.zinv3)
+	y.Mod(y, c.params.P)
+	return x, y
+}
+
+func (c *rcurve) Params() *elliptic.CurveParams {
+	return c.params
+}
+
+func (c *rcurve) IsOnCurve(x, y *big.Int) bool {
+	if x.Sign() < 0 || x.Cmp(c.params.P) >= 0 || y.Sign() < 0 || y.Cmp(c.params.P) >= 0 {
+		return false
+	}
+	return c.twisted.IsOnCurve(c.toTwisted(x, y))
+}
+
+func (c *rcurve) Add(x1, y1, x2, y2 *big.Int) (*big.Int, *big.Int) {
+	tx1, ty1 := c.toTwisted(x1, y1)
+	tx2, ty2 := c.toTwisted(x2, y2)
+	return c.fromTwisted(c.twisted.Add(tx1, ty1, tx2, ty2))
+}
+
+func (c *rcurve) Double(x1, y1 *big.Int) (*big.Int, *big.Int) {
+	return c.fromTwisted(c.twisted.Double(c.toTwisted(x1, y1)))
+}
+
+func (c *rcurve) ScalarMult(x1, y1 *big.Int, k []byte) (*big.Int, *big.Int) {
+	tx1, ty1 := c.toTwisted(x1, y1)
+	return c.fromTwisted(c.twisted.ScalarMult(tx1, ty1, k))
+}
+
+func (c *rcurve) ScalarBaseMult(k []byte) (*big.Int, *big.Int) {
+	return c.fromTwisted(c.twisted.ScalarBaseMult(k))
+}
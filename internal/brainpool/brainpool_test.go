@@ -0,0 +1,53 @@
+package brainpool_test
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"testing"
+
+	"github.com/lestrrat-go/jwx/v2/internal/brainpool"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBrainpool(t *testing.T) {
+	curves := []elliptic.Curve{
+		brainpool.P256r1(),
+		brainpool.P384r1(),
+		brainpool.P512r1(),
+	}
+	for _, crv := range curves {
+		crv := crv
+		t.Run(crv.Params().Name, func(t *testing.T) {
+			params := crv.Params()
+			require.True(t, crv.IsOnCurve(params.Gx, params.Gy), `generator should be on curve`)
+
+			// n * G must be the point at infinity
+			x, y := crv.ScalarBaseMult(params.N.Bytes())
+			require.Zero(t, x.Sign(), `n * G should be the point at infinity`)
+			require.Zero(t, y.Sign(), `n * G should be the point at infinity`)
+
+			// 2 * G computed in different ways must match
+			dx, dy := crv.Double(params.Gx, params.Gy)
+			ax, ay := crv.Add(params.Gx, params.Gy, params.Gx, params.Gy)
+			sx, sy := crv.ScalarMult(params.Gx, params.Gy, []byte{2})
+			require.True(t, crv.IsOnCurve(dx, dy), `2 * G should be on curve`)
+			require.Equal(t, dx, ax, `Double and Add should match`)
+			require.Equal(t, dy, ay, `Double and Add should match`)
+			require.Equal(t, dx, sx, `Double and ScalarMult should match`)
+			require.Equal(t, dy, sy, `Double and ScalarMult should match`)
+
+			key, err := ecdsa.GenerateKey(crv, rand.Reader)
+			require.NoError(t, err, `ecdsa.GenerateKey should succeed`)
+
+			digest := sha256.Sum256([]byte("Lorem ipsum"))
+			r, s, err := ecdsa.Sign(rand.Reader, key, digest[:])
+			require.NoError(t, err, `ecdsa.Sign should succeed`)
+			require.True(t, ecdsa.Verify(&key.PublicKey, digest[:], r, s), `ecdsa.Verify should succeed`)
+
+			digest[0] ^= 0xff
+			require.False(t, ecdsa.Verify(&key.PublicKey, digest[:], r, s), `ecdsa.Verify should fail for a different digest`)
+		})
+	}
+}
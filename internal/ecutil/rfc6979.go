@@ -0,0 +1,154 @@
+package ecutil
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/hmac"
+	"crypto/rand"
+	"fmt"
+	"math/big"
+)
+
+// SignDeterministic computes an ECDSA signature over the given digest,
+// using a nonce that is derived from the private key and the digest as
+// described in RFC 6979. The hash function is the one that was used
+// to create the digest, and is also used to derive the nonce.
+//
+// Signing the same digest with the same key always produces the same
+// signature, and no random source is required.
+func SignDeterministic(priv *ecdsa.PrivateKey, hash crypto.Hash, digest []byte) (*big.Int, *big.Int, error) {
+	if !hash.Available() {
+		return nil, nil, fmt.Errorf(`hash function %s is not available`, hash)
+	}
+	if priv.D == nil || priv.D.Sign() <= 0 {
+		return nil, nil, fmt.Errorf(`invalid private key`)
+	}
+
+	params := priv.Curve.Params()
+	n := params.N
+	if n.Sign() <= 0 {
+		return nil, nil, fmt.Errorf(`invalid curve order`)
+	}
+	qlen := n.BitLen()
+	rlen := (qlen + 7) / 8
+
+	// bits2int, as defined in RFC 6979 section 2.3.2
+	bits2int := func(b []byte) *big.Int {
+		v := new(big.Int).SetBytes(b)
+		if excess := len(b)*8 - qlen; excess > 0 {
+			v.Rsh(v, uint(excess))
+		}
+		return v
+	}
+
+	// int2octets, as defined in RFC 6979 section 2.3.3
+	int2octets := func(v *big.Int) []byte {
+		out := make([]byte, rlen)
+		return v.FillBytes(out)
+	}
+
+	e := bits2int(digest)
+
+	// bits2octets, as defined in RFC 6979 section 2.3.4
+	h1 := new(big.Int).Set(e)
+	if h1.Cmp(n) >= 0 {
+		h1.Sub(h1, n)
+	}
+
+	x := int2octets(priv.D)
+	h1octets := int2octets(h1)
+
+	// Steps b. through g. of RFC 6979 section 3.2
+	hlen := hash.Size()
+	v := make([]byte, hlen)
+	for i := range v {
+		v[i] = 0x01
+	}
+	k := make([]byte, hlen)
+
+	mac := func(key []byte, data ...[]byte) []byte {
+		h := hmac.New(hash.New, key)
+		for _, d := range data {
+			h.Write(d)
+		}
+		return h.Sum(nil)
+	}
+
+	k = mac(k, v, []byte{0x00}, x, h1octets)
+	v = mac(k, v)
+	k = mac(k, v, []byte{0x01}, x, h1octets)
+	v = mac(k, v)
+
+	one := big.NewInt(1)
+	for {
+		// Step h. of RFC 6979 section 3.2
+		var t []byte
+		for len(t)*8 < qlen {
+			v = mac(k, v)
+			t = append(t, v...)
+		}
+
+		nonce := bits2int(t)
+		if nonce.Cmp(one) >= 0 && nonce.Cmp(n) < 0 {
+			r, s, err := signWithNonce(priv, n, e, nonce)
+			if err != nil {
+				return nil, nil, err
+			}
+			if r != nil {
+				return r, s, nil
+			}
+		}
+
+		k = mac(k, v, []byte{0x00})
+		v = mac(k, v)
+	}
+}
+
+// signWithNonce computes the ECDSA signature using the given nonce.
+// nil values are returned if the nonce can not be used, in which case
+// a new nonce must be generated
+//
+// math/big does not operate in constant time, and because the nonce is
+// deterministic, an attacker who can time repeated signatures over the
+// same message observes the exact same computation every time. To avoid
+// leaking the nonce or the private key through timing, the scalar
+// arithmetic is blinded using a random value b:
+//
+//	s = (b*e + r*(b*d)) * (b*k)^-1 mod n
+//
+// which is equal to (e + r*d) * k^-1 mod n, so the resulting signature
+// is still deterministic.
+func signWithNonce(priv *ecdsa.PrivateKey, n, e, nonce *big.Int) (*big.Int, *big.Int, error) {
+	// Pass the nonce as a fixed length scalar so that its length does not
+	// depend on its value
+	scalar := make([]byte, (n.BitLen()+7)/8)
+	x, _ := priv.Curve.ScalarBaseMult(nonce.FillBytes(scalar))
+	r := new(big.Int).Mod(x, n)
+	if r.Sign() == 0 {
+		return nil, nil, nil
+	}
+
+	// b is chosen from [1, n-1]
+	b, err := rand.Int(rand.Reader, new(big.Int).Sub(n, big.NewInt(1)))
+	if err != nil {
+		return nil, nil, fmt.Errorf(`failed to generate blinding value: %w`, err)
+	}
+	b.Add(b, big.NewInt(1))
+
+	bk := new(big.Int).Mul(b, nonce)
+	bk.Mod(bk, n)
+	bkinv := new(big.Int).ModInverse(bk, n)
+
+	bd := new(big.Int).Mul(b, priv.D)
+	bd.Mod(bd, n)
+
+	s := new(big.Int).Mul(r, bd)
+	s.Add(s, new(big.Int).Mul(b, e))
+	s.Mod(s, n)
+	s.Mul(s, bkinv)
+	s.Mod(s, n)
+	if s.Sign() == 0 {
+		return nil, nil, nil
+	}
+	return r, s, nil
+}
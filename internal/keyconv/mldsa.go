@@ -0,0 +1,78 @@
+//go:build go1.27
+// +build go1.27
+
+package keyconv
+
+import (
+	"crypto"
+	"crypto/mldsa"
+	"fmt"
+
+	"github.com/lestrrat-go/jwx/v2/jwk"
+)
+
+// MLDSAPrivateKey assigns src to dst.
+// `dst` should be a pointer to a *mldsa.PrivateKey.
+// `src` may be *mldsa.PrivateKey or a jwk.Key
+func MLDSAPrivateKey(dst, src interface{}) error {
+	if jwkKey, ok := src.(jwk.Key); ok {
+		var raw *mldsa.PrivateKey
+		if err := jwkKey.Raw(&raw); err != nil {
+			return fmt.Errorf(`failed to produce *mldsa.PrivateKey from %T: %w`, src, err)
+		}
+		src = raw
+	}
+
+	ptr, ok := src.(*mldsa.PrivateKey)
+	if !ok {
+		return fmt.Errorf(`expected *mldsa.PrivateKey, got %T`, src)
+	}
+
+	// *mldsa.PrivateKey should not be copied by value, so we can't use
+	// blackmagic.AssignIfCompatible here
+	pdst, ok := dst.(**mldsa.PrivateKey)
+	if !ok {
+		return fmt.Errorf(`expected **mldsa.PrivateKey as destination, got %T`, dst)
+	}
+	*pdst = ptr
+	return nil
+}
+
+// MLDSAPublicKey assigns src to dst.
+// `dst` should be a pointer to a *mldsa.PublicKey.
+// `src` may be *mldsa.PublicKey, a crypto.Signer whose public key is
+// *mldsa.PublicKey (e.g. *mldsa.PrivateKey), or a jwk.Key
+func MLDSAPublicKey(dst, src interface{}) error {
+	if jwkKey, ok := src.(jwk.Key); ok {
+		pubKey, err := jwk.PublicKeyOf(jwkKey)
+		if err != nil {
+			return fmt.Errorf(`failed to produce public key from %T: %w`, src, err)
+		}
+		var raw *mldsa.PublicKey
+		if err := pubKey.Raw(&raw); err != nil {
+			return fmt.Errorf(`failed to produce *mldsa.PublicKey from %T: %w`, src, err)
+		}
+		src = raw
+	}
+
+	var ptr *mldsa.PublicKey
+	switch src := src.(type) {
+	case *mldsa.PublicKey:
+		ptr = src
+	case crypto.Signer:
+		tmp, ok := src.Public().(*mldsa.PublicKey)
+		if !ok {
+			return fmt.Errorf(`failed to retrieve *mldsa.PublicKey out of %T`, src)
+		}
+		ptr = tmp
+	default:
+		return fmt.Errorf(`expected *mldsa.PublicKey, got %T`, src)
+	}
+
+	pdst, ok := dst.(**mldsa.PublicKey)
+	if !ok {
+		return fmt.Errorf(`expected **mldsa.PublicKey as destination, got %T`, dst)
+	}
+	*pdst = ptr
+	return nil
+}
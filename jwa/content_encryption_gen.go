@@ -19,6 +19,8 @@ const (
 	A192GCM       ContentEncryptionAlgorithm = "A192GCM"       // AES-GCM (192)
 	A256CBC_HS512 ContentEncryptionAlgorithm = "A256CBC-HS512" // AES-CBC + HMAC-SHA512 (256)
 	A256GCM       ContentEncryptionAlgorithm = "A256GCM"       // AES-GCM (256)
+	C20P          ContentEncryptionAlgorithm = "C20P"          // ChaCha20-Poly1305
+	XC20P         ContentEncryptionAlgorithm = "XC20P"         // XChaCha20-Poly1305
 )
 
 var muContentEncryptionAlgorithms sync.RWMutex
@@ -35,6 +37,8 @@ func init() {
 	allContentEncryptionAlgorithms[A192GCM] = struct{}{}
 	allContentEncryptionAlgorithms[A256CBC_HS512] = struct{}{}
 	allContentEncryptionAlgorithms[A256GCM] = struct{}{}
+	allContentEncryptionAlgorithms[C20P] = struct{}{}
+	allContentEncryptionAlgorithms[XC20P] = struct{}{}
 	rebuildContentEncryptionAlgorithm()
 }
 
@@ -227,6 +227,78 @@ func TestContentEncryptionAlgorithm(t *testing.T) {
 			return
 		}
 	})
+	t.Run(`accept jwa constant C20P`, func(t *testing.T) {
+		t.Parallel()
+		var dst jwa.ContentEncryptionAlgorithm
+		if !assert.NoError(t, dst.Accept(jwa.C20P), `accept is successful`) {
+			return
+		}
+		if !assert.Equal(t, jwa.C20P, dst, `accepted value should be equal to constant`) {
+			return
+		}
+	})
+	t.Run(`accept the string C20P`, func(t *testing.T) {
+		t.Parallel()
+		var dst jwa.ContentEncryptionAlgorithm
+		if !assert.NoError(t, dst.Accept("C20P"), `accept is successful`) {
+			return
+		}
+		if !assert.Equal(t, jwa.C20P, dst, `accepted value should be equal to constant`) {
+			return
+		}
+	})
+	t.Run(`accept fmt.Stringer for C20P`, func(t *testing.T) {
+		t.Parallel()
+		var dst jwa.ContentEncryptionAlgorithm
+		if !assert.NoError(t, dst.Accept(stringer{src: "C20P"}), `accept is successful`) {
+			return
+		}
+		if !assert.Equal(t, jwa.C20P, dst, `accepted value should be equal to constant`) {
+			return
+		}
+	})
+	t.Run(`stringification for C20P`, func(t *testing.T) {
+		t.Parallel()
+		if !assert.Equal(t, "C20P", jwa.C20P.String(), `stringified value matches`) {
+			return
+		}
+	})
+	t.Run(`accept jwa constant XC20P`, func(t *testing.T) {
+		t.Parallel()
+		var dst jwa.ContentEncryptionAlgorithm
+		if !assert.NoError(t, dst.Accept(jwa.XC20P), `accept is successful`) {
+			return
+		}
+		if !assert.Equal(t, jwa.XC20P, dst, `accepted value should be equal to constant`) {
+			return
+		}
+	})
+	t.Run(`accept the string XC20P`, func(t *testing.T) {
+		t.Parallel()
+		var dst jwa.ContentEncryptionAlgorithm
+		if !assert.NoError(t, dst.Accept("XC20P"), `accept is successful`) {
+			return
+		}
+		if !assert.Equal(t, jwa.XC20P, dst, `accepted value should be equal to constant`) {
+			return
+		}
+	})
+	t.Run(`accept fmt.Stringer for XC20P`, func(t *testing.T) {
+		t.Parallel()
+		var dst jwa.ContentEncryptionAlgorithm
+		if !assert.NoError(t, dst.Accept(stringer{src: "XC20P"}), `accept is successful`) {
+			return
+		}
+		if !assert.Equal(t, jwa.XC20P, dst, `accepted value should be equal to constant`) {
+			return
+		}
+	})
+	t.Run(`stringification for XC20P`, func(t *testing.T) {
+		t.Parallel()
+		if !assert.Equal(t, "XC20P", jwa.XC20P.String(), `stringified value matches`) {
+			return
+		}
+	})
 	t.Run(`bail out on random integer value`, func(t *testing.T) {
 		t.Parallel()
 		var dst jwa.ContentEncryptionAlgorithm
@@ -250,6 +322,8 @@ func TestContentEncryptionAlgorithm(t *testing.T) {
 			jwa.A192GCM:       {},
 			jwa.A256CBC_HS512: {},
 			jwa.A256GCM:       {},
+			jwa.C20P:          {},
+			jwa.XC20P:         {},
 		}
 		for _, v := range jwa.ContentEncryptionAlgorithms() {
 			if _, ok := expected[v]; !assert.True(t, ok, `%s should be in the expected list`, v) {
@@ -13,6 +13,9 @@ type EllipticCurveAlgorithm string
 
 // Supported values for EllipticCurveAlgorithm
 const (
+	BrainpoolP256r1      EllipticCurveAlgorithm = "brainpoolP256r1"
+	BrainpoolP384r1      EllipticCurveAlgorithm = "brainpoolP384r1"
+	BrainpoolP512r1      EllipticCurveAlgorithm = "brainpoolP512r1"
 	Ed25519              EllipticCurveAlgorithm = "Ed25519"
 	Ed448                EllipticCurveAlgorithm = "Ed448"
 	InvalidEllipticCurve EllipticCurveAlgorithm = "P-invalid"
@@ -31,6 +34,9 @@ func init() {
 	muEllipticCurveAlgorithms.Lock()
 	defer muEllipticCurveAlgorithms.Unlock()
 	allEllipticCurveAlgorithms = make(map[EllipticCurveAlgorithm]struct{})
+	allEllipticCurveAlgorithms[BrainpoolP256r1] = struct{}{}
+	allEllipticCurveAlgorithms[BrainpoolP384r1] = struct{}{}
+	allEllipticCurveAlgorithms[BrainpoolP512r1] = struct{}{}
 	allEllipticCurveAlgorithms[Ed25519] = struct{}{}
 	allEllipticCurveAlgorithms[Ed448] = struct{}{}
 	allEllipticCurveAlgorithms[P256] = struct{}{}
@@ -11,6 +11,114 @@ import (
 
 func TestEllipticCurveAlgorithm(t *testing.T) {
 	t.Parallel()
+	t.Run(`accept jwa constant BrainpoolP256r1`, func(t *testing.T) {
+		t.Parallel()
+		var dst jwa.EllipticCurveAlgorithm
+		if !assert.NoError(t, dst.Accept(jwa.BrainpoolP256r1), `accept is successful`) {
+			return
+		}
+		if !assert.Equal(t, jwa.BrainpoolP256r1, dst, `accepted value should be equal to constant`) {
+			return
+		}
+	})
+	t.Run(`accept the string brainpoolP256r1`, func(t *testing.T) {
+		t.Parallel()
+		var dst jwa.EllipticCurveAlgorithm
+		if !assert.NoError(t, dst.Accept("brainpoolP256r1"), `accept is successful`) {
+			return
+		}
+		if !assert.Equal(t, jwa.BrainpoolP256r1, dst, `accepted value should be equal to constant`) {
+			return
+		}
+	})
+	t.Run(`accept fmt.Stringer for brainpoolP256r1`, func(t *testing.T) {
+		t.Parallel()
+		var dst jwa.EllipticCurveAlgorithm
+		if !assert.NoError(t, dst.Accept(stringer{src: "brainpoolP256r1"}), `accept is successful`) {
+			return
+		}
+		if !assert.Equal(t, jwa.BrainpoolP256r1, dst, `accepted value should be equal to constant`) {
+			return
+		}
+	})
+	t.Run(`stringification for brainpoolP256r1`, func(t *testing.T) {
+		t.Parallel()
+		if !assert.Equal(t, "brainpoolP256r1", jwa.BrainpoolP256r1.String(), `stringified value matches`) {
+			return
+		}
+	})
+	t.Run(`accept jwa constant BrainpoolP384r1`, func(t *testing.T) {
+		t.Parallel()
+		var dst jwa.EllipticCurveAlgorithm
+		if !assert.NoError(t, dst.Accept(jwa.BrainpoolP384r1), `accept is successful`) {
+			return
+		}
+		if !assert.Equal(t, jwa.BrainpoolP384r1, dst, `accepted value should be equal to constant`) {
+			return
+		}
+	})
+	t.Run(`accept the string brainpoolP384r1`, func(t *testing.T) {
+		t.Parallel()
+		var dst jwa.EllipticCurveAlgorithm
+		if !assert.NoError(t, dst.Accept("brainpoolP384r1"), `accept is successful`) {
+			return
+		}
+		if !assert.Equal(t, jwa.BrainpoolP384r1, dst, `accepted value should be equal to constant`) {
+			return
+		}
+	})
+	t.Run(`accept fmt.Stringer for brainpoolP384r1`, func(t *testing.T) {
+		t.Parallel()
+		var dst jwa.EllipticCurveAlgorithm
+		if !assert.NoError(t, dst.Accept(stringer{src: "brainpoolP384r1"}), `accept is successful`) {
+			return
+		}
+		if !assert.Equal(t, jwa.BrainpoolP384r1, dst, `accepted value should be equal to constant`) {
+			return
+		}
+	})
+	t.Run(`stringification for brainpoolP384r1`, func(t *testing.T) {
+		t.Parallel()
+		if !assert.Equal(t, "brainpoolP384r1", jwa.BrainpoolP384r1.String(), `stringified value matches`) {
+			return
+		}
+	})
+	t.Run(`accept jwa constant BrainpoolP512r1`, func(t *testing.T) {
+		t.Parallel()
+		var dst jwa.EllipticCurveAlgorithm
+		if !assert.NoError(t, dst.Accept(jwa.BrainpoolP512r1), `accept is successful`) {
+			return
+		}
+		if !assert.Equal(t, jwa.BrainpoolP512r1, dst, `accepted value should be equal to constant`) {
+			return
+		}
+	})
+	t.Run(`accept the string brainpoolP512r1`, func(t *testing.T) {
+		t.Parallel()
+		var dst jwa.EllipticCurveAlgorithm
+		if !assert.NoError(t, dst.Accept("brainpoolP512r1"), `accept is successful`) {
+			return
+		}
+		if !assert.Equal(t, jwa.BrainpoolP512r1, dst, `accepted value should be equal to constant`) {
+			return
+		}
+	})
+	t.Run(`accept fmt.Stringer for brainpoolP512r1`, func(t *testing.T) {
+		t.Parallel()
+		var dst jwa.EllipticCurveAlgorithm
+		if !assert.NoError(t, dst.Accept(stringer{src: "brainpoolP512r1"}), `accept is successful`) {
+			return
+		}
+		if !assert.Equal(t, jwa.BrainpoolP512r1, dst, `accepted value should be equal to constant`) {
+			return
+		}
+	})
+	t.Run(`stringification for brainpoolP512r1`, func(t *testing.T) {
+		t.Parallel()
+		if !assert.Equal(t, "brainpoolP512r1", jwa.BrainpoolP512r1.String(), `stringified value matches`) {
+			return
+		}
+	})
 	t.Run(`accept jwa constant Ed25519`, func(t *testing.T) {
 		t.Parallel()
 		var dst jwa.EllipticCurveAlgorithm
@@ -287,13 +395,16 @@ func TestEllipticCurveAlgorithm(t *testing.T) {
 	t.Run(`check list of elements`, func(t *testing.T) {
 		t.Parallel()
 		var expected = map[jwa.EllipticCurveAlgorithm]struct{}{
-			jwa.Ed25519: {},
-			jwa.Ed448:   {},
-			jwa.P256:    {},
-			jwa.P384:    {},
-			jwa.P521:    {},
-			jwa.X25519:  {},
-			jwa.X448:    {},
+			jwa.BrainpoolP256r1: {},
+			jwa.BrainpoolP384r1: {},
+			jwa.BrainpoolP512r1: {},
+			jwa.Ed25519:         {},
+			jwa.Ed448:           {},
+			jwa.P256:            {},
+			jwa.P384:            {},
+			jwa.P521:            {},
+			jwa.X25519:          {},
+			jwa.X448:            {},
 		}
 		for _, v := range jwa.EllipticCurveAlgorithms() {
 			// There is no good way to detect from a test if es256k (secp256k1)
@@ -19,17 +19,35 @@ const (
 	A192KW             KeyEncryptionAlgorithm = "A192KW"             // AES key wrap (192)
 	A256GCMKW          KeyEncryptionAlgorithm = "A256GCMKW"          // AES-GCM key wrap (256)
 	A256KW             KeyEncryptionAlgorithm = "A256KW"             // AES key wrap (256)
+	C20PKW             KeyEncryptionAlgorithm = "C20PKW"             // ChaCha20-Poly1305 key wrap
 	DIRECT             KeyEncryptionAlgorithm = "dir"                // Direct encryption
+	ECDH_1PU           KeyEncryptionAlgorithm = "ECDH-1PU"           // ECDH-1PU
+	ECDH_1PU_A128KW    KeyEncryptionAlgorithm = "ECDH-1PU+A128KW"    // ECDH-1PU + AES key wrap (128)
+	ECDH_1PU_A192KW    KeyEncryptionAlgorithm = "ECDH-1PU+A192KW"    // ECDH-1PU + AES key wrap (192)
+	ECDH_1PU_A256KW    KeyEncryptionAlgorithm = "ECDH-1PU+A256KW"    // ECDH-1PU + AES key wrap (256)
 	ECDH_ES            KeyEncryptionAlgorithm = "ECDH-ES"            // ECDH-ES
 	ECDH_ES_A128KW     KeyEncryptionAlgorithm = "ECDH-ES+A128KW"     // ECDH-ES + AES key wrap (128)
 	ECDH_ES_A192KW     KeyEncryptionAlgorithm = "ECDH-ES+A192KW"     // ECDH-ES + AES key wrap (192)
 	ECDH_ES_A256KW     KeyEncryptionAlgorithm = "ECDH-ES+A256KW"     // ECDH-ES + AES key wrap (256)
+	HPKE_0             KeyEncryptionAlgorithm = "HPKE-0"             // HPKE using DHKEM(P-256, HKDF-SHA256), HKDF-SHA256 and AES-128-GCM (integrated encryption)
+	HPKE_0_KE          KeyEncryptionAlgorithm = "HPKE-0-KE"          // HPKE using DHKEM(P-256, HKDF-SHA256), HKDF-SHA256 and AES-128-GCM (key encryption)
+	HPKE_3             KeyEncryptionAlgorithm = "HPKE-3"             // HPKE using DHKEM(X25519, HKDF-SHA256), HKDF-SHA256 and AES-128-GCM (integrated encryption)
+	HPKE_3_KE          KeyEncryptionAlgorithm = "HPKE-3-KE"          // HPKE using DHKEM(X25519, HKDF-SHA256), HKDF-SHA256 and AES-128-GCM (key encryption)
+	HPKE_4             KeyEncryptionAlgorithm = "HPKE-4"             // HPKE using DHKEM(X25519, HKDF-SHA256), HKDF-SHA256 and ChaCha20Poly1305 (integrated encryption)
+	HPKE_4_KE          KeyEncryptionAlgorithm = "HPKE-4-KE"          // HPKE using DHKEM(X25519, HKDF-SHA256), HKDF-SHA256 and ChaCha20Poly1305 (key encryption)
+	MLKEM1024          KeyEncryptionAlgorithm = "MLKEM1024"          // ML-KEM-1024 (direct key agreement, experimental)
+	MLKEM1024_A256KW   KeyEncryptionAlgorithm = "MLKEM1024+A256KW"   // ML-KEM-1024 + AES key wrap (256, experimental)
+	MLKEM768           KeyEncryptionAlgorithm = "MLKEM768"           // ML-KEM-768 (direct key agreement, experimental)
+	MLKEM768_A192KW    KeyEncryptionAlgorithm = "MLKEM768+A192KW"    // ML-KEM-768 + AES key wrap (192, experimental)
 	PBES2_HS256_A128KW KeyEncryptionAlgorithm = "PBES2-HS256+A128KW" // PBES2 + HMAC-SHA256 + AES key wrap (128)
 	PBES2_HS384_A192KW KeyEncryptionAlgorithm = "PBES2-HS384+A192KW" // PBES2 + HMAC-SHA384 + AES key wrap (192)
 	PBES2_HS512_A256KW KeyEncryptionAlgorithm = "PBES2-HS512+A256KW" // PBES2 + HMAC-SHA512 + AES key wrap (256)
 	RSA1_5             KeyEncryptionAlgorithm = "RSA1_5"             // RSA-PKCS1v1.5
 	RSA_OAEP           KeyEncryptionAlgorithm = "RSA-OAEP"           // RSA-OAEP-SHA1
 	RSA_OAEP_256       KeyEncryptionAlgorithm = "RSA-OAEP-256"       // RSA-OAEP-SHA256
+	RSA_OAEP_384       KeyEncryptionAlgorithm = "RSA-OAEP-384"       // RSA-OAEP-SHA384
+	RSA_OAEP_512       KeyEncryptionAlgorithm = "RSA-OAEP-512"       // RSA-OAEP-SHA512
+	XC20PKW            KeyEncryptionAlgorithm = "XC20PKW"            // XChaCha20-Poly1305 key wrap
 )
 
 var muKeyEncryptionAlgorithms sync.RWMutex
@@ -46,17 +64,35 @@ func init() {
 	allKeyEncryptionAlgorithms[A192KW] = struct{}{}
 	allKeyEncryptionAlgorithms[A256GCMKW] = struct{}{}
 	allKeyEncryptionAlgorithms[A256KW] = struct{}{}
+	allKeyEncryptionAlgorithms[C20PKW] = struct{}{}
 	allKeyEncryptionAlgorithms[DIRECT] = struct{}{}
+	allKeyEncryptionAlgorithms[ECDH_1PU] = struct{}{}
+	allKeyEncryptionAlgorithms[ECDH_1PU_A128KW] = struct{}{}
+	allKeyEncryptionAlgorithms[ECDH_1PU_A192KW] = struct{}{}
+	allKeyEncryptionAlgorithms[ECDH_1PU_A256KW] = struct{}{}
 	allKeyEncryptionAlgorithms[ECDH_ES] = struct{}{}
 	allKeyEncryptionAlgorithms[ECDH_ES_A128KW] = struct{}{}
 	allKeyEncryptionAlgorithms[ECDH_ES_A192KW] = struct{}{}
 	allKeyEncryptionAlgorithms[ECDH_ES_A256KW] = struct{}{}
+	allKeyEncryptionAlgorithms[HPKE_0] = struct{}{}
+	allKeyEncryptionAlgorithms[HPKE_0_KE] = struct{}{}
+	allKeyEncryptionAlgorithms[HPKE_3] = struct{}{}
+	allKeyEncryptionAlgorithms[HPKE_3_KE] = struct{}{}
+	allKeyEncryptionAlgorithms[HPKE_4] = struct{}{}
+	allKeyEncryptionAlgorithms[HPKE_4_KE] = struct{}{}
+	allKeyEncryptionAlgorithms[MLKEM1024] = struct{}{}
+	allKeyEncryptionAlgorithms[MLKEM1024_A256KW] = struct{}{}
+	allKeyEncryptionAlgorithms[MLKEM768] = struct{}{}
+	allKeyEncryptionAlgorithms[MLKEM768_A192KW] = struct{}{}
 	allKeyEncryptionAlgorithms[PBES2_HS256_A128KW] = struct{}{}
 	allKeyEncryptionAlgorithms[PBES2_HS384_A192KW] = struct{}{}
 	allKeyEncryptionAlgorithms[PBES2_HS512_A256KW] = struct{}{}
 	allKeyEncryptionAlgorithms[RSA1_5] = struct{}{}
 	allKeyEncryptionAlgorithms[RSA_OAEP] = struct{}{}
 	allKeyEncryptionAlgorithms[RSA_OAEP_256] = struct{}{}
+	allKeyEncryptionAlgorithms[RSA_OAEP_384] = struct{}{}
+	allKeyEncryptionAlgorithms[RSA_OAEP_512] = struct{}{}
+	allKeyEncryptionAlgorithms[XC20PKW] = struct{}{}
 	rebuildKeyEncryptionAlgorithm()
 }
 
@@ -133,7 +169,7 @@ func (v KeyEncryptionAlgorithm) String() string {
 // IsSymmetric returns true if the algorithm is a symmetric type
 func (v KeyEncryptionAlgorithm) IsSymmetric() bool {
 	switch v {
-	case A128GCMKW, A128KW, A192GCMKW, A192KW, A256GCMKW, A256KW, DIRECT, PBES2_HS256_A128KW, PBES2_HS384_A192KW, PBES2_HS512_A256KW:
+	case A128GCMKW, A128KW, A192GCMKW, A192KW, A256GCMKW, A256KW, C20PKW, DIRECT, PBES2_HS256_A128KW, PBES2_HS384_A192KW, PBES2_HS512_A256KW, XC20PKW:
 		return true
 	}
 	return false
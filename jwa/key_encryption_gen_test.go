@@ -227,6 +227,42 @@ func TestKeyEncryptionAlgorithm(t *testing.T) {
 			return
 		}
 	})
+	t.Run(`accept jwa constant C20PKW`, func(t *testing.T) {
+		t.Parallel()
+		var dst jwa.KeyEncryptionAlgorithm
+		if !assert.NoError(t, dst.Accept(jwa.C20PKW), `accept is successful`) {
+			return
+		}
+		if !assert.Equal(t, jwa.C20PKW, dst, `accepted value should be equal to constant`) {
+			return
+		}
+	})
+	t.Run(`accept the string C20PKW`, func(t *testing.T) {
+		t.Parallel()
+		var dst jwa.KeyEncryptionAlgorithm
+		if !assert.NoError(t, dst.Accept("C20PKW"), `accept is successful`) {
+			return
+		}
+		if !assert.Equal(t, jwa.C20PKW, dst, `accepted value should be equal to constant`) {
+			return
+		}
+	})
+	t.Run(`accept fmt.Stringer for C20PKW`, func(t *testing.T) {
+		t.Parallel()
+		var dst jwa.KeyEncryptionAlgorithm
+		if !assert.NoError(t, dst.Accept(stringer{src: "C20PKW"}), `accept is successful`) {
+			return
+		}
+		if !assert.Equal(t, jwa.C20PKW, dst, `accepted value should be equal to constant`) {
+			return
+		}
+	})
+	t.Run(`stringification for C20PKW`, func(t *testing.T) {
+		t.Parallel()
+		if !assert.Equal(t, "C20PKW", jwa.C20PKW.String(), `stringified value matches`) {
+			return
+		}
+	})
 	t.Run(`accept jwa constant DIRECT`, func(t *testing.T) {
 		t.Parallel()
 		var dst jwa.KeyEncryptionAlgorithm
@@ -263,6 +299,150 @@ func TestKeyEncryptionAlgorithm(t *testing.T) {
 			return
 		}
 	})
+	t.Run(`accept jwa constant ECDH_1PU`, func(t *testing.T) {
+		t.Parallel()
+		var dst jwa.KeyEncryptionAlgorithm
+		if !assert.NoError(t, dst.Accept(jwa.ECDH_1PU), `accept is successful`) {
+			return
+		}
+		if !assert.Equal(t, jwa.ECDH_1PU, dst, `accepted value should be equal to constant`) {
+			return
+		}
+	})
+	t.Run(`accept the string ECDH-1PU`, func(t *testing.T) {
+		t.Parallel()
+		var dst jwa.KeyEncryptionAlgorithm
+		if !assert.NoError(t, dst.Accept("ECDH-1PU"), `accept is successful`) {
+			return
+		}
+		if !assert.Equal(t, jwa.ECDH_1PU, dst, `accepted value should be equal to constant`) {
+			return
+		}
+	})
+	t.Run(`accept fmt.Stringer for ECDH-1PU`, func(t *testing.T) {
+		t.Parallel()
+		var dst jwa.KeyEncryptionAlgorithm
+		if !assert.NoError(t, dst.Accept(stringer{src: "ECDH-1PU"}), `accept is successful`) {
+			return
+		}
+		if !assert.Equal(t, jwa.ECDH_1PU, dst, `accepted value should be equal to constant`) {
+			return
+		}
+	})
+	t.Run(`stringification for ECDH-1PU`, func(t *testing.T) {
+		t.Parallel()
+		if !assert.Equal(t, "ECDH-1PU", jwa.ECDH_1PU.String(), `stringified value matches`) {
+			return
+		}
+	})
+	t.Run(`accept jwa constant ECDH_1PU_A128KW`, func(t *testing.T) {
+		t.Parallel()
+		var dst jwa.KeyEncryptionAlgorithm
+		if !assert.NoError(t, dst.Accept(jwa.ECDH_1PU_A128KW), `accept is successful`) {
+			return
+		}
+		if !assert.Equal(t, jwa.ECDH_1PU_A128KW, dst, `accepted value should be equal to constant`) {
+			return
+		}
+	})
+	t.Run(`accept the string ECDH-1PU+A128KW`, func(t *testing.T) {
+		t.Parallel()
+		var dst jwa.KeyEncryptionAlgorithm
+		if !assert.NoError(t, dst.Accept("ECDH-1PU+A128KW"), `accept is successful`) {
+			return
+		}
+		if !assert.Equal(t, jwa.ECDH_1PU_A128KW, dst, `accepted value should be equal to constant`) {
+			return
+		}
+	})
+	t.Run(`accept fmt.Stringer for ECDH-1PU+A128KW`, func(t *testing.T) {
+		t.Parallel()
+		var dst jwa.KeyEncryptionAlgorithm
+		if !assert.NoError(t, dst.Accept(stringer{src: "ECDH-1PU+A128KW"}), `accept is successful`) {
+			return
+		}
+		if !assert.Equal(t, jwa.ECDH_1PU_A128KW, dst, `accepted value should be equal to constant`) {
+			return
+		}
+	})
+	t.Run(`stringification for ECDH-1PU+A128KW`, func(t *testing.T) {
+		t.Parallel()
+		if !assert.Equal(t, "ECDH-1PU+A128KW", jwa.ECDH_1PU_A128KW.String(), `stringified value matches`) {
+			return
+		}
+	})
+	t.Run(`accept jwa constant ECDH_1PU_A192KW`, func(t *testing.T) {
+		t.Parallel()
+		var dst jwa.KeyEncryptionAlgorithm
+		if !assert.NoError(t, dst.Accept(jwa.ECDH_1PU_A192KW), `accept is successful`) {
+			return
+		}
+		if !assert.Equal(t, jwa.ECDH_1PU_A192KW, dst, `accepted value should be equal to constant`) {
+			return
+		}
+	})
+	t.Run(`accept the string ECDH-1PU+A192KW`, func(t *testing.T) {
+		t.Parallel()
+		var dst jwa.KeyEncryptionAlgorithm
+		if !assert.NoError(t, dst.Accept("ECDH-1PU+A192KW"), `accept is successful`) {
+			return
+		}
+		if !assert.Equal(t, jwa.ECDH_1PU_A192KW, dst, `accepted value should be equal to constant`) {
+			return
+		}
+	})
+	t.Run(`accept fmt.Stringer for ECDH-1PU+A192KW`, func(t *testing.T) {
+		t.Parallel()
+		var dst jwa.KeyEncryptionAlgorithm
+		if !assert.NoError(t, dst.Accept(stringer{src: "ECDH-1PU+A192KW"}), `accept is successful`) {
+			return
+		}
+		if !assert.Equal(t, jwa.ECDH_1PU_A192KW, dst, `accepted value should be equal to constant`) {
+			return
+		}
+	})
+	t.Run(`stringification for ECDH-1PU+A192KW`, func(t *testing.T) {
+		t.Parallel()
+		if !assert.Equal(t, "ECDH-1PU+A192KW", jwa.ECDH_1PU_A192KW.String(), `stringified value matches`) {
+			return
+		}
+	})
+	t.Run(`accept jwa constant ECDH_1PU_A256KW`, func(t *testing.T) {
+		t.Parallel()
+		var dst jwa.KeyEncryptionAlgorithm
+		if !assert.NoError(t, dst.Accept(jwa.ECDH_1PU_A256KW), `accept is successful`) {
+			return
+		}
+		if !assert.Equal(t, jwa.ECDH_1PU_A256KW, dst, `accepted value should be equal to constant`) {
+			return
+		}
+	})
+	t.Run(`accept the string ECDH-1PU+A256KW`, func(t *testing.T) {
+		t.Parallel()
+		var dst jwa.KeyEncryptionAlgorithm
+		if !assert.NoError(t, dst.Accept("ECDH-1PU+A256KW"), `accept is successful`) {
+			return
+		}
+		if !assert.Equal(t, jwa.ECDH_1PU_A256KW, dst, `accepted value should be equal to constant`) {
+			return
+		}
+	})
+	t.Run(`accept fmt.Stringer for ECDH-1PU+A256KW`, func(t *testing.T) {
+		t.Parallel()
+		var dst jwa.KeyEncryptionAlgorithm
+		if !assert.NoError(t, dst.Accept(stringer{src: "ECDH-1PU+A256KW"}), `accept is successful`) {
+			return
+		}
+		if !assert.Equal(t, jwa.ECDH_1PU_A256KW, dst, `accepted value should be equal to constant`) {
+			return
+		}
+	})
+	t.Run(`stringification for ECDH-1PU+A256KW`, func(t *testing.T) {
+		t.Parallel()
+		if !assert.Equal(t, "ECDH-1PU+A256KW", jwa.ECDH_1PU_A256KW.String(), `stringified value matches`) {
+			return
+		}
+	})
 	t.Run(`accept jwa constant ECDH_ES`, func(t *testing.T) {
 		t.Parallel()
 		var dst jwa.KeyEncryptionAlgorithm
@@ -407,6 +587,366 @@ func TestKeyEncryptionAlgorithm(t *testing.T) {
 			return
 		}
 	})
+	t.Run(`accept jwa constant HPKE_0`, func(t *testing.T) {
+		t.Parallel()
+		var dst jwa.KeyEncryptionAlgorithm
+		if !assert.NoError(t, dst.Accept(jwa.HPKE_0), `accept is successful`) {
+			return
+		}
+		if !assert.Equal(t, jwa.HPKE_0, dst, `accepted value should be equal to constant`) {
+			return
+		}
+	})
+	t.Run(`accept the string HPKE-0`, func(t *testing.T) {
+		t.Parallel()
+		var dst jwa.KeyEncryptionAlgorithm
+		if !assert.NoError(t, dst.Accept("HPKE-0"), `accept is successful`) {
+			return
+		}
+		if !assert.Equal(t, jwa.HPKE_0, dst, `accepted value should be equal to constant`) {
+			return
+		}
+	})
+	t.Run(`accept fmt.Stringer for HPKE-0`, func(t *testing.T) {
+		t.Parallel()
+		var dst jwa.KeyEncryptionAlgorithm
+		if !assert.NoError(t, dst.Accept(stringer{src: "HPKE-0"}), `accept is successful`) {
+			return
+		}
+		if !assert.Equal(t, jwa.HPKE_0, dst, `accepted value should be equal to constant`) {
+			return
+		}
+	})
+	t.Run(`stringification for HPKE-0`, func(t *testing.T) {
+		t.Parallel()
+		if !assert.Equal(t, "HPKE-0", jwa.HPKE_0.String(), `stringified value matches`) {
+			return
+		}
+	})
+	t.Run(`accept jwa constant HPKE_0_KE`, func(t *testing.T) {
+		t.Parallel()
+		var dst jwa.KeyEncryptionAlgorithm
+		if !assert.NoError(t, dst.Accept(jwa.HPKE_0_KE), `accept is successful`) {
+			return
+		}
+		if !assert.Equal(t, jwa.HPKE_0_KE, dst, `accepted value should be equal to constant`) {
+			return
+		}
+	})
+	t.Run(`accept the string HPKE-0-KE`, func(t *testing.T) {
+		t.Parallel()
+		var dst jwa.KeyEncryptionAlgorithm
+		if !assert.NoError(t, dst.Accept("HPKE-0-KE"), `accept is successful`) {
+			return
+		}
+		if !assert.Equal(t, jwa.HPKE_0_KE, dst, `accepted value should be equal to constant`) {
+			return
+		}
+	})
+	t.Run(`accept fmt.Stringer for HPKE-0-KE`, func(t *testing.T) {
+		t.Parallel()
+		var dst jwa.KeyEncryptionAlgorithm
+		if !assert.NoError(t, dst.Accept(stringer{src: "HPKE-0-KE"}), `accept is successful`) {
+			return
+		}
+		if !assert.Equal(t, jwa.HPKE_0_KE, dst, `accepted value should be equal to constant`) {
+			return
+		}
+	})
+	t.Run(`stringification for HPKE-0-KE`, func(t *testing.T) {
+		t.Parallel()
+		if !assert.Equal(t, "HPKE-0-KE", jwa.HPKE_0_KE.String(), `stringified value matches`) {
+			return
+		}
+	})
+	t.Run(`accept jwa constant HPKE_3`, func(t *testing.T) {
+		t.Parallel()
+		var dst jwa.KeyEncryptionAlgorithm
+		if !assert.NoError(t, dst.Accept(jwa.HPKE_3), `accept is successful`) {
+			return
+		}
+		if !assert.Equal(t, jwa.HPKE_3, dst, `accepted value should be equal to constant`) {
+			return
+		}
+	})
+	t.Run(`accept the string HPKE-3`, func(t *testing.T) {
+		t.Parallel()
+		var dst jwa.KeyEncryptionAlgorithm
+		if !assert.NoError(t, dst.Accept("HPKE-3"), `accept is successful`) {
+			return
+		}
+		if !assert.Equal(t, jwa.HPKE_3, dst, `accepted value should be equal to constant`) {
+			return
+		}
+	})
+	t.Run(`accept fmt.Stringer for HPKE-3`, func(t *testing.T) {
+		t.Parallel()
+		var dst jwa.KeyEncryptionAlgorithm
+		if !assert.NoError(t, dst.Accept(stringer{src: "HPKE-3"}), `accept is successful`) {
+			return
+		}
+		if !assert.Equal(t, jwa.HPKE_3, dst, `accepted value should be equal to constant`) {
+			return
+		}
+	})
+	t.Run(`stringification for HPKE-3`, func(t *testing.T) {
+		t.Parallel()
+		if !assert.Equal(t, "HPKE-3", jwa.HPKE_3.String(), `stringified value matches`) {
+			return
+		}
+	})
+	t.Run(`accept jwa constant HPKE_3_KE`, func(t *testing.T) {
+		t.Parallel()
+		var dst jwa.KeyEncryptionAlgorithm
+		if !assert.NoError(t, dst.Accept(jwa.HPKE_3_KE), `accept is successful`) {
+			return
+		}
+		if !assert.Equal(t, jwa.HPKE_3_KE, dst, `accepted value should be equal to constant`) {
+			return
+		}
+	})
+	t.Run(`accept the string HPKE-3-KE`, func(t *testing.T) {
+		t.Parallel()
+		var dst jwa.KeyEncryptionAlgorithm
+		if !assert.NoError(t, dst.Accept("HPKE-3-KE"), `accept is successful`) {
+			return
+		}
+		if !assert.Equal(t, jwa.HPKE_3_KE, dst, `accepted value should be equal to constant`) {
+			return
+		}
+	})
+	t.Run(`accept fmt.Stringer for HPKE-3-KE`, func(t *testing.T) {
+		t.Parallel()
+		var dst jwa.KeyEncryptionAlgorithm
+		if !assert.NoError(t, dst.Accept(stringer{src: "HPKE-3-KE"}), `accept is successful`) {
+			return
+		}
+		if !assert.Equal(t, jwa.HPKE_3_KE, dst, `accepted value should be equal to constant`) {
+			return
+		}
+	})
+	t.Run(`stringification for HPKE-3-KE`, func(t *testing.T) {
+		t.Parallel()
+		if !assert.Equal(t, "HPKE-3-KE", jwa.HPKE_3_KE.String(), `stringified value matches`) {
+			return
+		}
+	})
+	t.Run(`accept jwa constant HPKE_4`, func(t *testing.T) {
+		t.Parallel()
+		var dst jwa.KeyEncryptionAlgorithm
+		if !assert.NoError(t, dst.Accept(jwa.HPKE_4), `accept is successful`) {
+			return
+		}
+		if !assert.Equal(t, jwa.HPKE_4, dst, `accepted value should be equal to constant`) {
+			return
+		}
+	})
+	t.Run(`accept the string HPKE-4`, func(t *testing.T) {
+		t.Parallel()
+		var dst jwa.KeyEncryptionAlgorithm
+		if !assert.NoError(t, dst.Accept("HPKE-4"), `accept is successful`) {
+			return
+		}
+		if !assert.Equal(t, jwa.HPKE_4, dst, `accepted value should be equal to constant`) {
+			return
+		}
+	})
+	t.Run(`accept fmt.Stringer for HPKE-4`, func(t *testing.T) {
+		t.Parallel()
+		var dst jwa.KeyEncryptionAlgorithm
+		if !assert.NoError(t, dst.Accept(stringer{src: "HPKE-4"}), `accept is successful`) {
+			return
+		}
+		if !assert.Equal(t, jwa.HPKE_4, dst, `accepted value should be equal to constant`) {
+			return
+		}
+	})
+	t.Run(`stringification for HPKE-4`, func(t *testing.T) {
+		t.Parallel()
+		if !assert.Equal(t, "HPKE-4", jwa.HPKE_4.String(), `stringified value matches`) {
+			return
+		}
+	})
+	t.Run(`accept jwa constant HPKE_4_KE`, func(t *testing.T) {
+		t.Parallel()
+		var dst jwa.KeyEncryptionAlgorithm
+		if !assert.NoError(t, dst.Accept(jwa.HPKE_4_KE), `accept is successful`) {
+			return
+		}
+		if !assert.Equal(t, jwa.HPKE_4_KE, dst, `accepted value should be equal to constant`) {
+			return
+		}
+	})
+	t.Run(`accept the string HPKE-4-KE`, func(t *testing.T) {
+		t.Parallel()
+		var dst jwa.KeyEncryptionAlgorithm
+		if !assert.NoError(t, dst.Accept("HPKE-4-KE"), `accept is successful`) {
+			return
+		}
+		if !assert.Equal(t, jwa.HPKE_4_KE, dst, `accepted value should be equal to constant`) {
+			return
+		}
+	})
+	t.Run(`accept fmt.Stringer for HPKE-4-KE`, func(t *testing.T) {
+		t.Parallel()
+		var dst jwa.KeyEncryptionAlgorithm
+		if !assert.NoError(t, dst.Accept(stringer{src: "HPKE-4-KE"}), `accept is successful`) {
+			return
+		}
+		if !assert.Equal(t, jwa.HPKE_4_KE, dst, `accepted value should be equal to constant`) {
+			return
+		}
+	})
+	t.Run(`stringification for HPKE-4-KE`, func(t *testing.T) {
+		t.Parallel()
+		if !assert.Equal(t, "HPKE-4-KE", jwa.HPKE_4_KE.String(), `stringified value matches`) {
+			return
+		}
+	})
+	t.Run(`accept jwa constant MLKEM1024`, func(t *testing.T) {
+		t.Parallel()
+		var dst jwa.KeyEncryptionAlgorithm
+		if !assert.NoError(t, dst.Accept(jwa.MLKEM1024), `accept is successful`) {
+			return
+		}
+		if !assert.Equal(t, jwa.MLKEM1024, dst, `accepted value should be equal to constant`) {
+			return
+		}
+	})
+	t.Run(`accept the string MLKEM1024`, func(t *testing.T) {
+		t.Parallel()
+		var dst jwa.KeyEncryptionAlgorithm
+		if !assert.NoError(t, dst.Accept("MLKEM1024"), `accept is successful`) {
+			return
+		}
+		if !assert.Equal(t, jwa.MLKEM1024, dst, `accepted value should be equal to constant`) {
+			return
+		}
+	})
+	t.Run(`accept fmt.Stringer for MLKEM1024`, func(t *testing.T) {
+		t.Parallel()
+		var dst jwa.KeyEncryptionAlgorithm
+		if !assert.NoError(t, dst.Accept(stringer{src: "MLKEM1024"}), `accept is successful`) {
+			return
+		}
+		if !assert.Equal(t, jwa.MLKEM1024, dst, `accepted value should be equal to constant`) {
+			return
+		}
+	})
+	t.Run(`stringification for MLKEM1024`, func(t *testing.T) {
+		t.Parallel()
+		if !assert.Equal(t, "MLKEM1024", jwa.MLKEM1024.String(), `stringified value matches`) {
+			return
+		}
+	})
+	t.Run(`accept jwa constant MLKEM1024_A256KW`, func(t *testing.T) {
+		t.Parallel()
+		var dst jwa.KeyEncryptionAlgorithm
+		if !assert.NoError(t, dst.Accept(jwa.MLKEM1024_A256KW), `accept is successful`) {
+			return
+		}
+		if !assert.Equal(t, jwa.MLKEM1024_A256KW, dst, `accepted value should be equal to constant`) {
+			return
+		}
+	})
+	t.Run(`accept the string MLKEM1024+A256KW`, func(t *testing.T) {
+		t.Parallel()
+		var dst jwa.KeyEncryptionAlgorithm
+		if !assert.NoError(t, dst.Accept("MLKEM1024+A256KW"), `accept is successful`) {
+			return
+		}
+		if !assert.Equal(t, jwa.MLKEM1024_A256KW, dst, `accepted value should be equal to constant`) {
+			return
+		}
+	})
+	t.Run(`accept fmt.Stringer for MLKEM1024+A256KW`, func(t *testing.T) {
+		t.Parallel()
+		var dst jwa.KeyEncryptionAlgorithm
+		if !assert.NoError(t, dst.Accept(stringer{src: "MLKEM1024+A256KW"}), `accept is successful`) {
+			return
+		}
+		if !assert.Equal(t, jwa.MLKEM1024_A256KW, dst, `accepted value should be equal to constant`) {
+			return
+		}
+	})
+	t.Run(`stringification for MLKEM1024+A256KW`, func(t *testing.T) {
+		t.Parallel()
+		if !assert.Equal(t, "MLKEM1024+A256KW", jwa.MLKEM1024_A256KW.String(), `stringified value matches`) {
+			return
+		}
+	})
+	t.Run(`accept jwa constant MLKEM768`, func(t *testing.T) {
+		t.Parallel()
+		var dst jwa.KeyEncryptionAlgorithm
+		if !assert.NoError(t, dst.Accept(jwa.MLKEM768), `accept is successful`) {
+			return
+		}
+		if !assert.Equal(t, jwa.MLKEM768, dst, `accepted value should be equal to constant`) {
+			return
+		}
+	})
+	t.Run(`accept the string MLKEM768`, func(t *testing.T) {
+		t.Parallel()
+		var dst jwa.KeyEncryptionAlgorithm
+		if !assert.NoError(t, dst.Accept("MLKEM768"), `accept is successful`) {
+			return
+		}
+		if !assert.Equal(t, jwa.MLKEM768, dst, `accepted value should be equal to constant`) {
+			return
+		}
+	})
+	t.Run(`accept fmt.Stringer for MLKEM768`, func(t *testing.T) {
+		t.Parallel()
+		var dst jwa.KeyEncryptionAlgorithm
+		if !assert.NoError(t, dst.Accept(stringer{src: "MLKEM768"}), `accept is successful`) {
+			return
+		}
+		if !assert.Equal(t, jwa.MLKEM768, dst, `accepted value should be equal to constant`) {
+			return
+		}
+	})
+	t.Run(`stringification for MLKEM768`, func(t *testing.T) {
+		t.Parallel()
+		if !assert.Equal(t, "MLKEM768", jwa.MLKEM768.String(), `stringified value matches`) {
+			return
+		}
+	})
+	t.Run(`accept jwa constant MLKEM768_A192KW`, func(t *testing.T) {
+		t.Parallel()
+		var dst jwa.KeyEncryptionAlgorithm
+		if !assert.NoError(t, dst.Accept(jwa.MLKEM768_A192KW), `accept is successful`) {
+			return
+		}
+		if !assert.Equal(t, jwa.MLKEM768_A192KW, dst, `accepted value should be equal to constant`) {
+			return
+		}
+	})
+	t.Run(`accept the string MLKEM768+A192KW`, func(t *testing.T) {
+		t.Parallel()
+		var dst jwa.KeyEncryptionAlgorithm
+		if !assert.NoError(t, dst.Accept("MLKEM768+A192KW"), `accept is successful`) {
+			return
+		}
+		if !assert.Equal(t, jwa.MLKEM768_A192KW, dst, `accepted value should be equal to constant`) {
+			return
+		}
+	})
+	t.Run(`accept fmt.Stringer for MLKEM768+A192KW`, func(t *testing.T) {
+		t.Parallel()
+		var dst jwa.KeyEncryptionAlgorithm
+		if !assert.NoError(t, dst.Accept(stringer{src: "MLKEM768+A192KW"}), `accept is successful`) {
+			return
+		}
+		if !assert.Equal(t, jwa.MLKEM768_A192KW, dst, `accepted value should be equal to constant`) {
+			return
+		}
+	})
+	t.Run(`stringification for MLKEM768+A192KW`, func(t *testing.T) {
+		t.Parallel()
+		if !assert.Equal(t, "MLKEM768+A192KW", jwa.MLKEM768_A192KW.String(), `stringified value matches`) {
+			return
+		}
+	})
 	t.Run(`accept jwa constant PBES2_HS256_A128KW`, func(t *testing.T) {
 		t.Parallel()
 		var dst jwa.KeyEncryptionAlgorithm
@@ -623,6 +1163,114 @@ func TestKeyEncryptionAlgorithm(t *testing.T) {
 			return
 		}
 	})
+	t.Run(`accept jwa constant RSA_OAEP_384`, func(t *testing.T) {
+		t.Parallel()
+		var dst jwa.KeyEncryptionAlgorithm
+		if !assert.NoError(t, dst.Accept(jwa.RSA_OAEP_384), `accept is successful`) {
+			return
+		}
+		if !assert.Equal(t, jwa.RSA_OAEP_384, dst, `accepted value should be equal to constant`) {
+			return
+		}
+	})
+	t.Run(`accept the string RSA-OAEP-384`, func(t *testing.T) {
+		t.Parallel()
+		var dst jwa.KeyEncryptionAlgorithm
+		if !assert.NoError(t, dst.Accept("RSA-OAEP-384"), `accept is successful`) {
+			return
+		}
+		if !assert.Equal(t, jwa.RSA_OAEP_384, dst, `accepted value should be equal to constant`) {
+			return
+		}
+	})
+	t.Run(`accept fmt.Stringer for RSA-OAEP-384`, func(t *testing.T) {
+		t.Parallel()
+		var dst jwa.KeyEncryptionAlgorithm
+		if !assert.NoError(t, dst.Accept(stringer{src: "RSA-OAEP-384"}), `accept is successful`) {
+			return
+		}
+		if !assert.Equal(t, jwa.RSA_OAEP_384, dst, `accepted value should be equal to constant`) {
+			return
+		}
+	})
+	t.Run(`stringification for RSA-OAEP-384`, func(t *testing.T) {
+		t.Parallel()
+		if !assert.Equal(t, "RSA-OAEP-384", jwa.RSA_OAEP_384.String(), `stringified value matches`) {
+			return
+		}
+	})
+	t.Run(`accept jwa constant RSA_OAEP_512`, func(t *testing.T) {
+		t.Parallel()
+		var dst jwa.KeyEncryptionAlgorithm
+		if !assert.NoError(t, dst.Accept(jwa.RSA_OAEP_512), `accept is successful`) {
+			return
+		}
+		if !assert.Equal(t, jwa.RSA_OAEP_512, dst, `accepted value should be equal to constant`) {
+			return
+		}
+	})
+	t.Run(`accept the string RSA-OAEP-512`, func(t *testing.T) {
+		t.Parallel()
+		var dst jwa.KeyEncryptionAlgorithm
+		if !assert.NoError(t, dst.Accept("RSA-OAEP-512"), `accept is successful`) {
+			return
+		}
+		if !assert.Equal(t, jwa.RSA_OAEP_512, dst, `accepted value should be equal to constant`) {
+			return
+		}
+	})
+	t.Run(`accept fmt.Stringer for RSA-OAEP-512`, func(t *testing.T) {
+		t.Parallel()
+		var dst jwa.KeyEncryptionAlgorithm
+		if !assert.NoError(t, dst.Accept(stringer{src: "RSA-OAEP-512"}), `accept is successful`) {
+			return
+		}
+		if !assert.Equal(t, jwa.RSA_OAEP_512, dst, `accepted value should be equal to constant`) {
+			return
+		}
+	})
+	t.Run(`stringification for RSA-OAEP-512`, func(t *testing.T) {
+		t.Parallel()
+		if !assert.Equal(t, "RSA-OAEP-512", jwa.RSA_OAEP_512.String(), `stringified value matches`) {
+			return
+		}
+	})
+	t.Run(`accept jwa constant XC20PKW`, func(t *testing.T) {
+		t.Parallel()
+		var dst jwa.KeyEncryptionAlgorithm
+		if !assert.NoError(t, dst.Accept(jwa.XC20PKW), `accept is successful`) {
+			return
+		}
+		if !assert.Equal(t, jwa.XC20PKW, dst, `accepted value should be equal to constant`) {
+			return
+		}
+	})
+	t.Run(`accept the string XC20PKW`, func(t *testing.T) {
+		t.Parallel()
+		var dst jwa.KeyEncryptionAlgorithm
+		if !assert.NoError(t, dst.Accept("XC20PKW"), `accept is successful`) {
+			return
+		}
+		if !assert.Equal(t, jwa.XC20PKW, dst, `accepted value should be equal to constant`) {
+			return
+		}
+	})
+	t.Run(`accept fmt.Stringer for XC20PKW`, func(t *testing.T) {
+		t.Parallel()
+		var dst jwa.KeyEncryptionAlgorithm
+		if !assert.NoError(t, dst.Accept(stringer{src: "XC20PKW"}), `accept is successful`) {
+			return
+		}
+		if !assert.Equal(t, jwa.XC20PKW, dst, `accepted value should be equal to constant`) {
+			return
+		}
+	})
+	t.Run(`stringification for XC20PKW`, func(t *testing.T) {
+		t.Parallel()
+		if !assert.Equal(t, "XC20PKW", jwa.XC20PKW.String(), `stringified value matches`) {
+			return
+		}
+	})
 	t.Run(`bail out on random integer value`, func(t *testing.T) {
 		t.Parallel()
 		var dst jwa.KeyEncryptionAlgorithm
@@ -657,9 +1305,24 @@ func TestKeyEncryptionAlgorithm(t *testing.T) {
 		t.Run(`A256KW`, func(t *testing.T) {
 			assert.True(t, jwa.A256KW.IsSymmetric(), `jwa.A256KW should be symmetric`)
 		})
+		t.Run(`C20PKW`, func(t *testing.T) {
+			assert.True(t, jwa.C20PKW.IsSymmetric(), `jwa.C20PKW should be symmetric`)
+		})
 		t.Run(`DIRECT`, func(t *testing.T) {
 			assert.True(t, jwa.DIRECT.IsSymmetric(), `jwa.DIRECT should be symmetric`)
 		})
+		t.Run(`ECDH_1PU`, func(t *testing.T) {
+			assert.False(t, jwa.ECDH_1PU.IsSymmetric(), `jwa.ECDH_1PU should NOT be symmetric`)
+		})
+		t.Run(`ECDH_1PU_A128KW`, func(t *testing.T) {
+			assert.False(t, jwa.ECDH_1PU_A128KW.IsSymmetric(), `jwa.ECDH_1PU_A128KW should NOT be symmetric`)
+		})
+		t.Run(`ECDH_1PU_A192KW`, func(t *testing.T) {
+			assert.False(t, jwa.ECDH_1PU_A192KW.IsSymmetric(), `jwa.ECDH_1PU_A192KW should NOT be symmetric`)
+		})
+		t.Run(`ECDH_1PU_A256KW`, func(t *testing.T) {
+			assert.False(t, jwa.ECDH_1PU_A256KW.IsSymmetric(), `jwa.ECDH_1PU_A256KW should NOT be symmetric`)
+		})
 		t.Run(`ECDH_ES`, func(t *testing.T) {
 			assert.False(t, jwa.ECDH_ES.IsSymmetric(), `jwa.ECDH_ES should NOT be symmetric`)
 		})
@@ -672,6 +1335,36 @@ func TestKeyEncryptionAlgorithm(t *testing.T) {
 		t.Run(`ECDH_ES_A256KW`, func(t *testing.T) {
 			assert.False(t, jwa.ECDH_ES_A256KW.IsSymmetric(), `jwa.ECDH_ES_A256KW should NOT be symmetric`)
 		})
+		t.Run(`HPKE_0`, func(t *testing.T) {
+			assert.False(t, jwa.HPKE_0.IsSymmetric(), `jwa.HPKE_0 should NOT be symmetric`)
+		})
+		t.Run(`HPKE_0_KE`, func(t *testing.T) {
+			assert.False(t, jwa.HPKE_0_KE.IsSymmetric(), `jwa.HPKE_0_KE should NOT be symmetric`)
+		})
+		t.Run(`HPKE_3`, func(t *testing.T) {
+			assert.False(t, jwa.HPKE_3.IsSymmetric(), `jwa.HPKE_3 should NOT be symmetric`)
+		})
+		t.Run(`HPKE_3_KE`, func(t *testing.T) {
+			assert.False(t, jwa.HPKE_3_KE.IsSymmetric(), `jwa.HPKE_3_KE should NOT be symmetric`)
+		})
+		t.Run(`HPKE_4`, func(t *testing.T) {
+			assert.False(t, jwa.HPKE_4.IsSymmetric(), `jwa.HPKE_4 should NOT be symmetric`)
+		})
+		t.Run(`HPKE_4_KE`, func(t *testing.T) {
+			assert.False(t, jwa.HPKE_4_KE.IsSymmetric(), `jwa.HPKE_4_KE should NOT be symmetric`)
+		})
+		t.Run(`MLKEM1024`, func(t *testing.T) {
+			assert.False(t, jwa.MLKEM1024.IsSymmetric(), `jwa.MLKEM1024 should NOT be symmetric`)
+		})
+		t.Run(`MLKEM1024_A256KW`, func(t *testing.T) {
+			assert.False(t, jwa.MLKEM1024_A256KW.IsSymmetric(), `jwa.MLKEM1024_A256KW should NOT be symmetric`)
+		})
+		t.Run(`MLKEM768`, func(t *testing.T) {
+			assert.False(t, jwa.MLKEM768.IsSymmetric(), `jwa.MLKEM768 should NOT be symmetric`)
+		})
+		t.Run(`MLKEM768_A192KW`, func(t *testing.T) {
+			assert.False(t, jwa.MLKEM768_A192KW.IsSymmetric(), `jwa.MLKEM768_A192KW should NOT be symmetric`)
+		})
 		t.Run(`PBES2_HS256_A128KW`, func(t *testing.T) {
 			assert.True(t, jwa.PBES2_HS256_A128KW.IsSymmetric(), `jwa.PBES2_HS256_A128KW should be symmetric`)
 		})
@@ -690,6 +1383,15 @@ func TestKeyEncryptionAlgorithm(t *testing.T) {
 		t.Run(`RSA_OAEP_256`, func(t *testing.T) {
 			assert.False(t, jwa.RSA_OAEP_256.IsSymmetric(), `jwa.RSA_OAEP_256 should NOT be symmetric`)
 		})
+		t.Run(`RSA_OAEP_384`, func(t *testing.T) {
+			assert.False(t, jwa.RSA_OAEP_384.IsSymmetric(), `jwa.RSA_OAEP_384 should NOT be symmetric`)
+		})
+		t.Run(`RSA_OAEP_512`, func(t *testing.T) {
+			assert.False(t, jwa.RSA_OAEP_512.IsSymmetric(), `jwa.RSA_OAEP_512 should NOT be symmetric`)
+		})
+		t.Run(`XC20PKW`, func(t *testing.T) {
+			assert.True(t, jwa.XC20PKW.IsSymmetric(), `jwa.XC20PKW should be symmetric`)
+		})
 	})
 	t.Run(`check list of elements`, func(t *testing.T) {
 		t.Parallel()
@@ -700,17 +1402,35 @@ func TestKeyEncryptionAlgorithm(t *testing.T) {
 			jwa.A192KW:             {},
 			jwa.A256GCMKW:          {},
 			jwa.A256KW:             {},
+			jwa.C20PKW:             {},
 			jwa.DIRECT:             {},
+			jwa.ECDH_1PU:           {},
+			jwa.ECDH_1PU_A128KW:    {},
+			jwa.ECDH_1PU_A192KW:    {},
+			jwa.ECDH_1PU_A256KW:    {},
 			jwa.ECDH_ES:            {},
 			jwa.ECDH_ES_A128KW:     {},
 			jwa.ECDH_ES_A192KW:     {},
 			jwa.ECDH_ES_A256KW:     {},
+			jwa.HPKE_0:             {},
+			jwa.HPKE_0_KE:          {},
+			jwa.HPKE_3:             {},
+			jwa.HPKE_3_KE:          {},
+			jwa.HPKE_4:             {},
+			jwa.HPKE_4_KE:          {},
+			jwa.MLKEM1024:          {},
+			jwa.MLKEM1024_A256KW:   {},
+			jwa.MLKEM768:           {},
+			jwa.MLKEM768_A192KW:    {},
 			jwa.PBES2_HS256_A128KW: {},
 			jwa.PBES2_HS384_A192KW: {},
 			jwa.PBES2_HS512_A256KW: {},
 			jwa.RSA1_5:             {},
 			jwa.RSA_OAEP:           {},
 			jwa.RSA_OAEP_256:       {},
+			jwa.RSA_OAEP_384:       {},
+			jwa.RSA_OAEP_512:       {},
+			jwa.XC20PKW:            {},
 		}
 		for _, v := range jwa.KeyEncryptionAlgorithms() {
 			if _, ok := expected[v]; !assert.True(t, ok, `%s should be in the expected list`, v) {
@@ -13,6 +13,7 @@ type KeyType string
 
 // Supported values for KeyType
 const (
+	AKP            KeyType = "AKP" // Algorithm key pairs
 	EC             KeyType = "EC"  // Elliptic Curve
 	InvalidKeyType KeyType = ""    // Invalid KeyType
 	OKP            KeyType = "OKP" // Octet string key pairs
@@ -28,6 +29,7 @@ func init() {
 	muKeyTypes.Lock()
 	defer muKeyTypes.Unlock()
 	allKeyTypes = make(map[KeyType]struct{})
+	allKeyTypes[AKP] = struct{}{}
 	allKeyTypes[EC] = struct{}{}
 	allKeyTypes[OKP] = struct{}{}
 	allKeyTypes[OctetSeq] = struct{}{}
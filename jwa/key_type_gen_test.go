@@ -11,6 +11,42 @@ import (
 
 func TestKeyType(t *testing.T) {
 	t.Parallel()
+	t.Run(`accept jwa constant AKP`, func(t *testing.T) {
+		t.Parallel()
+		var dst jwa.KeyType
+		if !assert.NoError(t, dst.Accept(jwa.AKP), `accept is successful`) {
+			return
+		}
+		if !assert.Equal(t, jwa.AKP, dst, `accepted value should be equal to constant`) {
+			return
+		}
+	})
+	t.Run(`accept the string AKP`, func(t *testing.T) {
+		t.Parallel()
+		var dst jwa.KeyType
+		if !assert.NoError(t, dst.Accept("AKP"), `accept is successful`) {
+			return
+		}
+		if !assert.Equal(t, jwa.AKP, dst, `accepted value should be equal to constant`) {
+			return
+		}
+	})
+	t.Run(`accept fmt.Stringer for AKP`, func(t *testing.T) {
+		t.Parallel()
+		var dst jwa.KeyType
+		if !assert.NoError(t, dst.Accept(stringer{src: "AKP"}), `accept is successful`) {
+			return
+		}
+		if !assert.Equal(t, jwa.AKP, dst, `accepted value should be equal to constant`) {
+			return
+		}
+	})
+	t.Run(`stringification for AKP`, func(t *testing.T) {
+		t.Parallel()
+		if !assert.Equal(t, "AKP", jwa.AKP.String(), `stringified value matches`) {
+			return
+		}
+	})
 	t.Run(`accept jwa constant EC`, func(t *testing.T) {
 		t.Parallel()
 		var dst jwa.KeyType
@@ -179,6 +215,7 @@ func TestKeyType(t *testing.T) {
 	t.Run(`check list of elements`, func(t *testing.T) {
 		t.Parallel()
 		var expected = map[jwa.KeyType]struct{}{
+			jwa.AKP:      {},
 			jwa.EC:       {},
 			jwa.OKP:      {},
 			jwa.OctetSeq: {},
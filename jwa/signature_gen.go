@@ -13,14 +13,20 @@ type SignatureAlgorithm string
 
 // Supported values for SignatureAlgorithm
 const (
-	ES256       SignatureAlgorithm = "ES256"  // ECDSA using P-256 and SHA-256
-	ES256K      SignatureAlgorithm = "ES256K" // ECDSA using secp256k1 and SHA-256
-	ES384       SignatureAlgorithm = "ES384"  // ECDSA using P-384 and SHA-384
-	ES512       SignatureAlgorithm = "ES512"  // ECDSA using P-521 and SHA-512
-	EdDSA       SignatureAlgorithm = "EdDSA"  // EdDSA signature algorithms
-	HS256       SignatureAlgorithm = "HS256"  // HMAC using SHA-256
-	HS384       SignatureAlgorithm = "HS384"  // HMAC using SHA-384
-	HS512       SignatureAlgorithm = "HS512"  // HMAC using SHA-512
+	ES256       SignatureAlgorithm = "ES256"     // ECDSA using P-256 and SHA-256
+	ES256K      SignatureAlgorithm = "ES256K"    // ECDSA using secp256k1 and SHA-256
+	ES384       SignatureAlgorithm = "ES384"     // ECDSA using P-384 and SHA-384
+	ES512       SignatureAlgorithm = "ES512"     // ECDSA using P-521 and SHA-512
+	ESB256      SignatureAlgorithm = "ESB256"    // ECDSA using brainpoolP256r1 and SHA-256
+	ESB384      SignatureAlgorithm = "ESB384"    // ECDSA using brainpoolP384r1 and SHA-384
+	ESB512      SignatureAlgorithm = "ESB512"    // ECDSA using brainpoolP512r1 and SHA-512
+	EdDSA       SignatureAlgorithm = "EdDSA"     // EdDSA signature algorithms
+	HS256       SignatureAlgorithm = "HS256"     // HMAC using SHA-256
+	HS384       SignatureAlgorithm = "HS384"     // HMAC using SHA-384
+	HS512       SignatureAlgorithm = "HS512"     // HMAC using SHA-512
+	MLDSA44     SignatureAlgorithm = "ML-DSA-44" // ML-DSA using parameter set ML-DSA-44
+	MLDSA65     SignatureAlgorithm = "ML-DSA-65" // ML-DSA using parameter set ML-DSA-65
+	MLDSA87     SignatureAlgorithm = "ML-DSA-87" // ML-DSA using parameter set ML-DSA-87
 	NoSignature SignatureAlgorithm = "none"
 	PS256       SignatureAlgorithm = "PS256" // RSASSA-PSS using SHA256 and MGF1-SHA256
 	PS384       SignatureAlgorithm = "PS384" // RSASSA-PSS using SHA384 and MGF1-SHA384
@@ -42,10 +48,16 @@ func init() {
 	allSignatureAlgorithms[ES256K] = struct{}{}
 	allSignatureAlgorithms[ES384] = struct{}{}
 	allSignatureAlgorithms[ES512] = struct{}{}
+	allSignatureAlgorithms[ESB256] = struct{}{}
+	allSignatureAlgorithms[ESB384] = struct{}{}
+	allSignatureAlgorithms[ESB512] = struct{}{}
 	allSignatureAlgorithms[EdDSA] = struct{}{}
 	allSignatureAlgorithms[HS256] = struct{}{}
 	allSignatureAlgorithms[HS384] = struct{}{}
 	allSignatureAlgorithms[HS512] = struct{}{}
+	allSignatureAlgorithms[MLDSA44] = struct{}{}
+	allSignatureAlgorithms[MLDSA65] = struct{}{}
+	allSignatureAlgorithms[MLDSA87] = struct{}{}
 	allSignatureAlgorithms[NoSignature] = struct{}{}
 	allSignatureAlgorithms[PS256] = struct{}{}
 	allSignatureAlgorithms[PS384] = struct{}{}
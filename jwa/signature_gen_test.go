@@ -155,6 +155,114 @@ func TestSignatureAlgorithm(t *testing.T) {
 			return
 		}
 	})
+	t.Run(`accept jwa constant ESB256`, func(t *testing.T) {
+		t.Parallel()
+		var dst jwa.SignatureAlgorithm
+		if !assert.NoError(t, dst.Accept(jwa.ESB256), `accept is successful`) {
+			return
+		}
+		if !assert.Equal(t, jwa.ESB256, dst, `accepted value should be equal to constant`) {
+			return
+		}
+	})
+	t.Run(`accept the string ESB256`, func(t *testing.T) {
+		t.Parallel()
+		var dst jwa.SignatureAlgorithm
+		if !assert.NoError(t, dst.Accept("ESB256"), `accept is successful`) {
+			return
+		}
+		if !assert.Equal(t, jwa.ESB256, dst, `accepted value should be equal to constant`) {
+			return
+		}
+	})
+	t.Run(`accept fmt.Stringer for ESB256`, func(t *testing.T) {
+		t.Parallel()
+		var dst jwa.SignatureAlgorithm
+		if !assert.NoError(t, dst.Accept(stringer{src: "ESB256"}), `accept is successful`) {
+			return
+		}
+		if !assert.Equal(t, jwa.ESB256, dst, `accepted value should be equal to constant`) {
+			return
+		}
+	})
+	t.Run(`stringification for ESB256`, func(t *testing.T) {
+		t.Parallel()
+		if !assert.Equal(t, "ESB256", jwa.ESB256.String(), `stringified value matches`) {
+			return
+		}
+	})
+	t.Run(`accept jwa constant ESB384`, func(t *testing.T) {
+		t.Parallel()
+		var dst jwa.SignatureAlgorithm
+		if !assert.NoError(t, dst.Accept(jwa.ESB384), `accept is successful`) {
+			return
+		}
+		if !assert.Equal(t, jwa.ESB384, dst, `accepted value should be equal to constant`) {
+			return
+		}
+	})
+	t.Run(`accept the string ESB384`, func(t *testing.T) {
+		t.Parallel()
+		var dst jwa.SignatureAlgorithm
+		if !assert.NoError(t, dst.Accept("ESB384"), `accept is successful`) {
+			return
+		}
+		if !assert.Equal(t, jwa.ESB384, dst, `accepted value should be equal to constant`) {
+			return
+		}
+	})
+	t.Run(`accept fmt.Stringer for ESB384`, func(t *testing.T) {
+		t.Parallel()
+		var dst jwa.SignatureAlgorithm
+		if !assert.NoError(t, dst.Accept(stringer{src: "ESB384"}), `accept is successful`) {
+			return
+		}
+		if !assert.Equal(t, jwa.ESB384, dst, `accepted value should be equal to constant`) {
+			return
+		}
+	})
+	t.Run(`stringification for ESB384`, func(t *testing.T) {
+		t.Parallel()
+		if !assert.Equal(t, "ESB384", jwa.ESB384.String(), `stringified value matches`) {
+			return
+		}
+	})
+	t.Run(`accept jwa constant ESB512`, func(t *testing.T) {
+		t.Parallel()
+		var dst jwa.SignatureAlgorithm
+		if !assert.NoError(t, dst.Accept(jwa.ESB512), `accept is successful`) {
+			return
+		}
+		if !assert.Equal(t, jwa.ESB512, dst, `accepted value should be equal to constant`) {
+			return
+		}
+	})
+	t.Run(`accept the string ESB512`, func(t *testing.T) {
+		t.Parallel()
+		var dst jwa.SignatureAlgorithm
+		if !assert.NoError(t, dst.Accept("ESB512"), `accept is successful`) {
+			return
+		}
+		if !assert.Equal(t, jwa.ESB512, dst, `accepted value should be equal to constant`) {
+			return
+		}
+	})
+	t.Run(`accept fmt.Stringer for ESB512`, func(t *testing.T) {
+		t.Parallel()
+		var dst jwa.SignatureAlgorithm
+		if !assert.NoError(t, dst.Accept(stringer{src: "ESB512"}), `accept is successful`) {
+			return
+		}
+		if !assert.Equal(t, jwa.ESB512, dst, `accepted value should be equal to constant`) {
+			return
+		}
+	})
+	t.Run(`stringification for ESB512`, func(t *testing.T) {
+		t.Parallel()
+		if !assert.Equal(t, "ESB512", jwa.ESB512.String(), `stringified value matches`) {
+			return
+		}
+	})
 	t.Run(`accept jwa constant EdDSA`, func(t *testing.T) {
 		t.Parallel()
 		var dst jwa.SignatureAlgorithm
@@ -299,6 +407,114 @@ func TestSignatureAlgorithm(t *testing.T) {
 			return
 		}
 	})
+	t.Run(`accept jwa constant MLDSA44`, func(t *testing.T) {
+		t.Parallel()
+		var dst jwa.SignatureAlgorithm
+		if !assert.NoError(t, dst.Accept(jwa.MLDSA44), `accept is successful`) {
+			return
+		}
+		if !assert.Equal(t, jwa.MLDSA44, dst, `accepted value should be equal to constant`) {
+			return
+		}
+	})
+	t.Run(`accept the string ML-DSA-44`, func(t *testing.T) {
+		t.Parallel()
+		var dst jwa.SignatureAlgorithm
+		if !assert.NoError(t, dst.Accept("ML-DSA-44"), `accept is successful`) {
+			return
+		}
+		if !assert.Equal(t, jwa.MLDSA44, dst, `accepted value should be equal to constant`) {
+			return
+		}
+	})
+	t.Run(`accept fmt.Stringer for ML-DSA-44`, func(t *testing.T) {
+		t.Parallel()
+		var dst jwa.SignatureAlgorithm
+		if !assert.NoError(t, dst.Accept(stringer{src: "ML-DSA-44"}), `accept is successful`) {
+			return
+		}
+		if !assert.Equal(t, jwa.MLDSA44, dst, `accepted value should be equal to constant`) {
+			return
+		}
+	})
+	t.Run(`stringification for ML-DSA-44`, func(t *testing.T) {
+		t.Parallel()
+		if !assert.Equal(t, "ML-DSA-44", jwa.MLDSA44.String(), `stringified value matches`) {
+			return
+		}
+	})
+	t.Run(`accept jwa constant MLDSA65`, func(t *testing.T) {
+		t.Parallel()
+		var dst jwa.SignatureAlgorithm
+		if !assert.NoError(t, dst.Accept(jwa.MLDSA65), `accept is successful`) {
+			return
+		}
+		if !assert.Equal(t, jwa.MLDSA65, dst, `accepted value should be equal to constant`) {
+			return
+		}
+	})
+	t.Run(`accept the string ML-DSA-65`, func(t *testing.T) {
+		t.Parallel()
+		var dst jwa.SignatureAlgorithm
+		if !assert.NoError(t, dst.Accept("ML-DSA-65"), `accept is successful`) {
+			return
+		}
+		if !assert.Equal(t, jwa.MLDSA65, dst, `accepted value should be equal to constant`) {
+			return
+		}
+	})
+	t.Run(`accept fmt.Stringer for ML-DSA-65`, func(t *testing.T) {
+		t.Parallel()
+		var dst jwa.SignatureAlgorithm
+		if !assert.NoError(t, dst.Accept(stringer{src: "ML-DSA-65"}), `accept is successful`) {
+			return
+		}
+		if !assert.Equal(t, jwa.MLDSA65, dst, `accepted value should be equal to constant`) {
+			return
+		}
+	})
+	t.Run(`stringification for ML-DSA-65`, func(t *testing.T) {
+		t.Parallel()
+		if !assert.Equal(t, "ML-DSA-65", jwa.MLDSA65.String(), `stringified value matches`) {
+			return
+		}
+	})
+	t.Run(`accept jwa constant MLDSA87`, func(t *testing.T) {
+		t.Parallel()
+		var dst jwa.SignatureAlgorithm
+		if !assert.NoError(t, dst.Accept(jwa.MLDSA87), `accept is successful`) {
+			return
+		}
+		if !assert.Equal(t, jwa.MLDSA87, dst, `accepted value should be equal to constant`) {
+			return
+		}
+	})
+	t.Run(`accept the string ML-DSA-87`, func(t *testing.T) {
+		t.Parallel()
+		var dst jwa.SignatureAlgorithm
+		if !assert.NoError(t, dst.Accept("ML-DSA-87"), `accept is successful`) {
+			return
+		}
+		if !assert.Equal(t, jwa.MLDSA87, dst, `accepted value should be equal to constant`) {
+			return
+		}
+	})
+	t.Run(`accept fmt.Stringer for ML-DSA-87`, func(t *testing.T) {
+		t.Parallel()
+		var dst jwa.SignatureAlgorithm
+		if !assert.NoError(t, dst.Accept(stringer{src: "ML-DSA-87"}), `accept is successful`) {
+			return
+		}
+		if !assert.Equal(t, jwa.MLDSA87, dst, `accepted value should be equal to constant`) {
+			return
+		}
+	})
+	t.Run(`stringification for ML-DSA-87`, func(t *testing.T) {
+		t.Parallel()
+		if !assert.Equal(t, "ML-DSA-87", jwa.MLDSA87.String(), `stringified value matches`) {
+			return
+		}
+	})
 	t.Run(`accept jwa constant NoSignature`, func(t *testing.T) {
 		t.Parallel()
 		var dst jwa.SignatureAlgorithm
@@ -572,10 +788,16 @@ func TestSignatureAlgorithm(t *testing.T) {
 			jwa.ES256K:      {},
 			jwa.ES384:       {},
 			jwa.ES512:       {},
+			jwa.ESB256:      {},
+			jwa.ESB384:      {},
+			jwa.ESB512:      {},
 			jwa.EdDSA:       {},
 			jwa.HS256:       {},
 			jwa.HS384:       {},
 			jwa.HS512:       {},
+			jwa.MLDSA44:     {},
+			jwa.MLDSA65:     {},
+			jwa.MLDSA87:     {},
 			jwa.NoSignature: {},
 			jwa.PS256:       {},
 			jwa.PS384:       {},
@@ -9,8 +9,25 @@ import (
 	"github.com/lestrrat-go/jwx/v2/internal/pool"
 )
 
-func uncompress(plaintext []byte) ([]byte, error) {
-	return io.ReadAll(flate.NewReader(bytes.NewReader(plaintext)))
+// uncompress inflates the given payload. If maxSize is greater than 0,
+// an error is returned as soon as the inflated payload becomes larger
+// than maxSize bytes
+func uncompress(plaintext []byte, maxSize int64) ([]byte, error) {
+	var rdr io.Reader = flate.NewReader(bytes.NewReader(plaintext))
+	if maxSize <= 0 {
+		return io.ReadAll(rdr)
+	}
+
+	// Read one more byte than allowed, so that we can tell if the
+	// payload has been truncated
+	buf, err := io.ReadAll(io.LimitReader(rdr, maxSize+1))
+	if err != nil {
+		return nil, err
+	}
+	if int64(len(buf)) > maxSize {
+		return nil, fmt.Errorf(`%w (%d bytes)`, errMaxDecompressedSizeExceeded, maxSize)
+	}
+	return buf, nil
 }
 
 func compress(plaintext []byte) ([]byte, error) {
@@ -0,0 +1,63 @@
+package jwe
+
+import (
+	"github.com/lestrrat-go/jwx/v2/jwa"
+	"github.com/lestrrat-go/jwx/v2/jwe/internal/cipher"
+)
+
+// ContentCipher is an interface for objects that can encrypt and decrypt
+// the payload of a JWE message using a content encryption key.
+//
+// Encrypt must return the initialization vector, the ciphertext, and the
+// authentication tag, all of which will be stored in the JWE message.
+// Decrypt receives the same values, and must return the original plaintext.
+// In both cases, aad is the additional authenticated data that must be
+// integrity protected along with the payload.
+//
+// This API is experimental and may change without notice, even
+// in minor releases.
+type ContentCipher interface {
+	// KeySize returns the size of the content encryption key in bytes
+	KeySize() int
+	Encrypt(cek, plaintext, aad []byte) (iv, ciphertext, tag []byte, err error)
+	Decrypt(cek, iv, ciphertext, tag, aad []byte) ([]byte, error)
+}
+
+type ContentCipherFactory interface {
+	Create() (ContentCipher, error)
+}
+type ContentCipherFactoryFn func() (ContentCipher, error)
+
+func (fn ContentCipherFactoryFn) Create() (ContentCipher, error) {
+	return fn()
+}
+
+// RegisterContentCipher is used to register a factory object that creates
+// ContentCipher objects based on the given algorithm.
+//
+// For example, if you would like to provide a vendor specific AEAD for
+// content encryption, use this function to register a `ContentCipherFactory`
+// (probably in your `init()`). Ciphers registered via this function take
+// precedence over the built-in ciphers.
+//
+// Unlike the `UnregisterContentCipher` function, this function automatically
+// calls `jwa.RegisterContentEncryptionAlgorithm` to register the algorithm
+// in the known algorithms database.
+func RegisterContentCipher(alg jwa.ContentEncryptionAlgorithm, f ContentCipherFactory) {
+	jwa.RegisterContentEncryptionAlgorithm(alg)
+	cipher.Register(alg, func() (cipher.ContentCipher, error) {
+		return f.Create()
+	})
+}
+
+// UnregisterContentCipher removes the content cipher factory associated
+// with the given algorithm. If the algorithm is one of the built-in
+// algorithms, the built-in cipher will be used from then on.
+//
+// Note that when you call this function, the algorithm itself is
+// not automatically unregistered from the known algorithms database.
+// Therefore, in order to completely remove the algorithm, you must
+// call `jwa.UnregisterContentEncryptionAlgorithm` yourself.
+func UnregisterContentCipher(alg jwa.ContentEncryptionAlgorithm) {
+	cipher.Unregister(alg)
+}
@@ -0,0 +1,79 @@
+package jwe
+
+import (
+	"fmt"
+	"sync"
+)
+
+var muCriticalHeaders sync.RWMutex
+var criticalHeaders = map[string]struct{}{
+	EncapsulatedKeyKey: {},
+	SenderKeyIDKey:     {},
+}
+
+// RegisterCriticalHeader registers the name of a header parameter
+// that is understood and processed by the application. This function
+// has a global effect.
+//
+// As required by RFC 7516 Section 4.1.13, `jwe.Decrypt()` refuses to
+// decrypt messages whose "crit" header lists a name that is not
+// understood. By default only the extension header parameters that
+// are processed by this library ("ek" and "skid") are understood.
+// Names may also be specified per call using `jwe.WithCriticalHeaders()`.
+//
+// Note that registering a name only declares that the application
+// will take care of processing the header parameter: the library does
+// not do anything else with it.
+func RegisterCriticalHeader(name string) {
+	muCriticalHeaders.Lock()
+	defer muCriticalHeaders.Unlock()
+	criticalHeaders[name] = struct{}{}
+}
+
+func isCriticalHeaderUnderstood(name string, extra []string) bool {
+	for _, v := range extra {
+		if v == name {
+			return true
+		}
+	}
+
+	muCriticalHeaders.RLock()
+	defer muCriticalHeaders.RUnlock()
+	_, ok := criticalHeaders[name]
+	return ok
+}
+
+// checkCritical validates the "crit" header parameter of the message
+// as described in RFC 7516 Section 4.1.13. extra contains the names
+// that were specified via `jwe.WithCriticalHeaders()`
+func (m *Message) checkCritical(extra []string) error {
+	if hdrs := m.unprotectedHeaders; hdrs != nil {
+		if _, ok := hdrs.Get(CriticalKey); ok {
+			return fmt.Errorf(`"crit" must be included in the protected header`)
+		}
+	}
+
+	protected := m.protectedHeaders
+	if protected == nil {
+		return nil
+	}
+
+	if _, ok := protected.Get(CriticalKey); !ok {
+		return nil
+	}
+
+	crit := protected.Critical()
+	if len(crit) == 0 {
+		return fmt.Errorf(`"crit" must not be empty`)
+	}
+
+	for _, name := range crit {
+		if _, ok := protected.Get(name); !ok {
+			return fmt.Errorf(`critical header %q is not present in the protected header`, name)
+		}
+		if !isCriticalHeaderUnderstood(name, extra) {
+			return fmt.Errorf(`critical header %q is not understood`, name)
+		}
+	}
+	return nil
+}
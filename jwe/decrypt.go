@@ -18,6 +18,7 @@ import (
 	"github.com/lestrrat-go/jwx/v2/jwe/internal/content_crypt"
 	"github.com/lestrrat-go/jwx/v2/jwe/internal/keyenc"
 	"github.com/lestrrat-go/jwx/v2/x25519"
+	"github.com/lestrrat-go/jwx/v2/x448"
 )
 
 // decrypter is responsible for taking various components to decrypt a message.
@@ -29,6 +30,7 @@ type decrypter struct {
 	apu         []byte
 	apv         []byte
 	computedAad []byte
+	ek          []byte
 	iv          []byte
 	keyiv       []byte
 	keysalt     []byte
@@ -36,6 +38,7 @@ type decrypter struct {
 	tag         []byte
 	privkey     interface{}
 	pubkey      interface{}
+	senderkey   interface{}
 	ctalg       jwa.ContentEncryptionAlgorithm
 	keyalg      jwa.KeyEncryptionAlgorithm
 	cipher      content_crypt.Cipher
@@ -83,6 +86,13 @@ func (d *decrypter) ContentEncryptionAlgorithm(ctalg jwa.ContentEncryptionAlgori
 	return d
 }
 
+// EncapsulatedKey sets the KEM ciphertext ('ek' header) to be used in
+// decoding ML-KEM and HPKE based encryptions.
+func (d *decrypter) EncapsulatedKey(ek []byte) *decrypter {
+	d.ek = ek
+	return d
+}
+
 func (d *decrypter) InitializationVector(iv []byte) *decrypter {
 	d.iv = iv
 	return d
@@ -115,6 +125,13 @@ func (d *decrypter) PublicKey(pubkey interface{}) *decrypter {
 	return d
 }
 
+// SenderPublicKey sets the sender's static public key to be used in
+// decoding ECDH-1PU based encryptions. The key must be in its "raw" format.
+func (d *decrypter) SenderPublicKey(senderkey interface{}) *decrypter {
+	d.senderkey = senderkey
+	return d
+}
+
 func (d *decrypter) Tag(tag []byte) *decrypter {
 	d.tag = tag
 	return d
@@ -122,16 +139,11 @@ func (d *decrypter) Tag(tag []byte) *decrypter {
 
 func (d *decrypter) ContentCipher() (content_crypt.Cipher, error) {
 	if d.cipher == nil {
-		switch d.ctalg {
-		case jwa.A128GCM, jwa.A192GCM, jwa.A256GCM, jwa.A128CBC_HS256, jwa.A192CBC_HS384, jwa.A256CBC_HS512:
-			cipher, err := cipher.NewAES(d.ctalg)
-			if err != nil {
-				return nil, fmt.Errorf(`failed to build content cipher for %s: %w`, d.ctalg, err)
-			}
-			d.cipher = cipher
-		default:
-			return nil, fmt.Errorf(`invalid content cipher algorithm (%s)`, d.ctalg)
+		cipher, err := cipher.New(d.ctalg)
+		if err != nil {
+			return nil, fmt.Errorf(`failed to build content cipher for %s: %w`, d.ctalg, err)
 		}
+		d.cipher = cipher
 	}
 
 	return d.cipher, nil
@@ -221,6 +233,25 @@ func (d *decrypter) decryptSymmetricKey(recipientKey, cek []byte) ([]byte, error
 			return nil, fmt.Errorf(`failed to decode key: %w`, err)
 		}
 		return jek, nil
+	case jwa.C20PKW, jwa.XC20PKW:
+		aead, err := keyenc.NewChaCha20Poly1305AEAD(d.keyalg, cek)
+		if err != nil {
+			return nil, fmt.Errorf(`failed to create new %s cipher: %w`, d.keyalg, err)
+		}
+		if len(d.keyiv) != aead.NonceSize() {
+			return nil, fmt.Errorf("%s requires %d-bit iv, got %d", d.keyalg, aead.NonceSize()*8, len(d.keyiv)*8)
+		}
+		if len(d.keytag) != aead.Overhead() {
+			return nil, fmt.Errorf("%s requires %d-bit tag, got %d", d.keyalg, aead.Overhead()*8, len(d.keytag)*8)
+		}
+		ciphertext := make([]byte, 0, len(recipientKey)+len(d.keytag))
+		ciphertext = append(ciphertext, recipientKey...)
+		ciphertext = append(ciphertext, d.keytag...)
+		jek, err := aead.Open(nil, d.keyiv, ciphertext, nil)
+		if err != nil {
+			return nil, fmt.Errorf(`failed to decode key: %w`, err)
+		}
+		return jek, nil
 	default:
 		return nil, fmt.Errorf("decrypt key: unsupported algorithm %s", d.keyalg)
 	}
@@ -269,7 +300,7 @@ func (d *decrypter) BuildKeyDecrypter() (keyenc.Decrypter, error) {
 		}
 
 		return keyenc.NewRSAPKCS15Decrypt(alg, &privkey, cipher.KeySize()/2), nil
-	case jwa.RSA_OAEP, jwa.RSA_OAEP_256:
+	case jwa.RSA_OAEP, jwa.RSA_OAEP_256, jwa.RSA_OAEP_384, jwa.RSA_OAEP_512:
 		var privkey rsa.PrivateKey
 		if err := keyconv.RSAPrivateKey(&privkey, d.privkey); err != nil {
 			return nil, fmt.Errorf(`*rsa.PrivateKey is required as the key to build %s key decrypter: %w`, alg, err)
@@ -285,7 +316,7 @@ func (d *decrypter) BuildKeyDecrypter() (keyenc.Decrypter, error) {
 		return keyenc.NewAES(alg, sharedkey)
 	case jwa.ECDH_ES, jwa.ECDH_ES_A128KW, jwa.ECDH_ES_A192KW, jwa.ECDH_ES_A256KW:
 		switch d.pubkey.(type) {
-		case x25519.PublicKey:
+		case x25519.PublicKey, x448.PublicKey:
 			return keyenc.NewECDHESDecrypt(alg, d.ctalg, d.pubkey, d.apu, d.apv, d.privkey), nil
 		default:
 			var pubkey ecdsa.PublicKey
@@ -300,6 +331,32 @@ func (d *decrypter) BuildKeyDecrypter() (keyenc.Decrypter, error) {
 
 			return keyenc.NewECDHESDecrypt(alg, d.ctalg, &pubkey, d.apu, d.apv, &privkey), nil
 		}
+	case jwa.ECDH_1PU, jwa.ECDH_1PU_A128KW, jwa.ECDH_1PU_A192KW, jwa.ECDH_1PU_A256KW:
+		switch d.pubkey.(type) {
+		case x25519.PublicKey, x448.PublicKey:
+			return keyenc.NewECDH1PUDecrypt(alg, d.ctalg, d.pubkey, d.senderkey, d.apu, d.apv, d.tag, d.privkey), nil
+		default:
+			var pubkey ecdsa.PublicKey
+			if err := keyconv.ECDSAPublicKey(&pubkey, d.pubkey); err != nil {
+				return nil, fmt.Errorf(`*ecdsa.PublicKey is required as the key to build %s key decrypter: %w`, alg, err)
+			}
+
+			var senderkey ecdsa.PublicKey
+			if err := keyconv.ECDSAPublicKey(&senderkey, d.senderkey); err != nil {
+				return nil, fmt.Errorf(`*ecdsa.PublicKey is required as the sender key to build %s key decrypter: %w`, alg, err)
+			}
+
+			var privkey ecdsa.PrivateKey
+			if err := keyconv.ECDSAPrivateKey(&privkey, d.privkey); err != nil {
+				return nil, fmt.Errorf(`*ecdsa.PrivateKey is required as the key to build %s key decrypter: %w`, alg, err)
+			}
+
+			return keyenc.NewECDH1PUDecrypt(alg, d.ctalg, &pubkey, &senderkey, d.apu, d.apv, d.tag, &privkey), nil
+		}
+	case jwa.MLKEM768, jwa.MLKEM1024, jwa.MLKEM768_A192KW, jwa.MLKEM1024_A256KW:
+		return keyenc.NewMLKEMDecrypt(alg, d.ctalg, d.ek, d.apu, d.apv, d.privkey)
+	case jwa.HPKE_0_KE, jwa.HPKE_3_KE, jwa.HPKE_4_KE:
+		return keyenc.NewHPKEDecrypt(alg, d.ctalg, d.ek, d.privkey)
 	default:
 		return nil, fmt.Errorf(`unsupported algorithm for key decryption (%s)`, alg)
 	}
@@ -0,0 +1,173 @@
+package jwe_test
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"fmt"
+	"testing"
+
+	"github.com/lestrrat-go/jwx/v2/jwa"
+	"github.com/lestrrat-go/jwx/v2/jwe"
+	"github.com/lestrrat-go/jwx/v2/jwk"
+	"github.com/lestrrat-go/jwx/v2/x25519"
+	"github.com/lestrrat-go/jwx/v2/x448"
+	"github.com/stretchr/testify/require"
+)
+
+func TestECDH1PU(t *testing.T) {
+	t.Parallel()
+
+	type keypair struct {
+		Name string
+		Priv interface{}
+		Pub  interface{}
+	}
+
+	genpair := func(t *testing.T, crv string) (keypair, keypair) {
+		t.Helper()
+		var pairs [2]keypair
+		for i := range pairs {
+			switch crv {
+			case "P-256":
+				v, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+				require.NoError(t, err, `ecdsa.GenerateKey should succeed`)
+				pairs[i] = keypair{Name: crv, Priv: v, Pub: &v.PublicKey}
+			case "X25519":
+				pub, priv, err := x25519.GenerateKey(rand.Reader)
+				require.NoError(t, err, `x25519.GenerateKey should succeed`)
+				pairs[i] = keypair{Name: crv, Priv: priv, Pub: pub}
+			case "X448":
+				pub, priv, err := x448.GenerateKey(rand.Reader)
+				require.NoError(t, err, `x448.GenerateKey should succeed`)
+				pairs[i] = keypair{Name: crv, Priv: priv, Pub: pub}
+			}
+		}
+		return pairs[0], pairs[1]
+	}
+
+	testcases := []struct {
+		Algorithm jwa.KeyEncryptionAlgorithm
+		Content   jwa.ContentEncryptionAlgorithm
+	}{
+		{Algorithm: jwa.ECDH_1PU, Content: jwa.A256GCM},
+		{Algorithm: jwa.ECDH_1PU, Content: jwa.A128CBC_HS256},
+		{Algorithm: jwa.ECDH_1PU_A128KW, Content: jwa.A128CBC_HS256},
+		{Algorithm: jwa.ECDH_1PU_A192KW, Content: jwa.A192CBC_HS384},
+		{Algorithm: jwa.ECDH_1PU_A256KW, Content: jwa.A256CBC_HS512},
+	}
+
+	plaintext := []byte("Lorem ipsum")
+	for _, crv := range []string{"P-256", "X25519", "X448"} {
+		crv := crv
+		for _, tc := range testcases {
+			tc := tc
+			t.Run(fmt.Sprintf("%s/%s/%s", crv, tc.Algorithm, tc.Content), func(t *testing.T) {
+				t.Parallel()
+				recipient, sender := genpair(t, crv)
+
+				encrypted, err := jwe.Encrypt(plaintext,
+					jwe.WithKey(tc.Algorithm, recipient.Pub),
+					jwe.WithSenderKey(sender.Priv),
+					jwe.WithContentEncryption(tc.Content),
+				)
+				require.NoError(t, err, `jwe.Encrypt should succeed`)
+
+				decrypted, err := jwe.Decrypt(encrypted,
+					jwe.WithKey(tc.Algorithm, recipient.Priv),
+					jwe.WithSenderKey(sender.Pub),
+				)
+				require.NoError(t, err, `jwe.Decrypt should succeed`)
+				require.Equal(t, plaintext, decrypted, `decrypted content should match`)
+
+				_, other := genpair(t, crv)
+				_, err = jwe.Decrypt(encrypted,
+					jwe.WithKey(tc.Algorithm, recipient.Priv),
+					jwe.WithSenderKey(other.Pub),
+				)
+				require.Error(t, err, `jwe.Decrypt should fail with the wrong sender key`)
+
+				_, err = jwe.Decrypt(encrypted, jwe.WithKey(tc.Algorithm, recipient.Priv))
+				require.Error(t, err, `jwe.Decrypt should fail without a sender key`)
+			})
+		}
+	}
+	t.Run("Missing sender key", func(t *testing.T) {
+		t.Parallel()
+		recipient, _ := genpair(t, "P-256")
+		_, err := jwe.Encrypt(plaintext, jwe.WithKey(jwa.ECDH_1PU, recipient.Pub))
+		require.Error(t, err, `jwe.Encrypt should fail without a sender key`)
+	})
+	t.Run("Key wrapping with AES-GCM", func(t *testing.T) {
+		t.Parallel()
+		recipient, sender := genpair(t, "X25519")
+		_, err := jwe.Encrypt(plaintext,
+			jwe.WithKey(jwa.ECDH_1PU_A128KW, recipient.Pub),
+			jwe.WithSenderKey(sender.Priv),
+			jwe.WithContentEncryption(jwa.A128GCM),
+		)
+		require.Error(t, err, `jwe.Encrypt should fail for key wrapping with AES-GCM`)
+	})
+	t.Run("Sender key provider", func(t *testing.T) {
+		t.Parallel()
+		recipient, sender := genpair(t, "P-256")
+
+		senderKey, err := jwk.FromRaw(sender.Priv)
+		require.NoError(t, err, `jwk.FromRaw should succeed`)
+		require.NoError(t, senderKey.Set(jwk.KeyIDKey, `alice`), `senderKey.Set should succeed`)
+		senderPubKey, err := senderKey.PublicKey()
+		require.NoError(t, err, `senderKey.PublicKey should succeed`)
+
+		encrypted, err := jwe.Encrypt(plaintext,
+			jwe.WithKey(jwa.ECDH_1PU_A256KW, recipient.Pub),
+			jwe.WithSenderKey(senderKey),
+			jwe.WithContentEncryption(jwa.A256CBC_HS512),
+		)
+		require.NoError(t, err, `jwe.Encrypt should succeed`)
+
+		msg, err := jwe.Parse(encrypted)
+		require.NoError(t, err, `jwe.Parse should succeed`)
+		require.Equal(t, `alice`, msg.ProtectedHeaders().SenderKeyID(), `"skid" should be populated`)
+
+		set := jwk.NewSet()
+		require.NoError(t, set.AddKey(senderPubKey), `set.AddKey should succeed`)
+		provider := jwe.SenderKeyProviderFunc(func(_ context.Context, h jwe.Headers) (interface{}, error) {
+			key, ok := set.LookupKeyID(h.SenderKeyID())
+			if !ok {
+				return nil, fmt.Errorf(`sender key %q not found`, h.SenderKeyID())
+			}
+			return key, nil
+		})
+
+		decrypted, err := jwe.Decrypt(encrypted,
+			jwe.WithKey(jwa.ECDH_1PU_A256KW, recipient.Priv),
+			jwe.WithSenderKeyProvider(provider),
+		)
+		require.NoError(t, err, `jwe.Decrypt should succeed`)
+		require.Equal(t, plaintext, decrypted, `decrypted content should match`)
+	})
+	t.Run("Multiple recipients", func(t *testing.T) {
+		t.Parallel()
+		recipient1, sender := genpair(t, "X25519")
+		recipient2, _ := genpair(t, "X25519")
+
+		encrypted, err := jwe.Encrypt(plaintext,
+			jwe.WithJSON(),
+			jwe.WithKey(jwa.ECDH_1PU_A128KW, recipient1.Pub),
+			jwe.WithKey(jwa.ECDH_1PU_A128KW, recipient2.Pub),
+			jwe.WithSenderKey(sender.Priv),
+			jwe.WithContentEncryption(jwa.A128CBC_HS256),
+		)
+		require.NoError(t, err, `jwe.Encrypt should succeed`)
+
+		for _, recipient := range []keypair{recipient1, recipient2} {
+			decrypted, err := jwe.Decrypt(encrypted,
+				jwe.WithKey(jwa.ECDH_1PU_A128KW, recipient.Priv),
+				jwe.WithSenderKey(sender.Pub),
+			)
+			require.NoError(t, err, `jwe.Decrypt should succeed`)
+			require.Equal(t, plaintext, decrypted, `decrypted content should match`)
+		}
+	})
+}
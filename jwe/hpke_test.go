@@ -0,0 +1,227 @@
+//go:build go1.26
+// +build go1.26
+
+package jwe_test
+
+import (
+	"crypto/ecdh"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/hpke"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/lestrrat-go/jwx/v2/jwa"
+	"github.com/lestrrat-go/jwx/v2/jwe"
+	"github.com/lestrrat-go/jwx/v2/jwk"
+	"github.com/lestrrat-go/jwx/v2/x25519"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHPKE(t *testing.T) {
+	t.Parallel()
+
+	eckey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err, `ecdsa.GenerateKey should succeed`)
+	xpub, xpriv, err := x25519.GenerateKey(rand.Reader)
+	require.NoError(t, err, `x25519.GenerateKey should succeed`)
+
+	testcases := []struct {
+		Algorithm jwa.KeyEncryptionAlgorithm
+		PrivKey   interface{}
+		PubKey    interface{}
+	}{
+		{Algorithm: jwa.HPKE_0, PrivKey: eckey, PubKey: &eckey.PublicKey},
+		{Algorithm: jwa.HPKE_0_KE, PrivKey: eckey, PubKey: &eckey.PublicKey},
+		{Algorithm: jwa.HPKE_3, PrivKey: xpriv, PubKey: xpub},
+		{Algorithm: jwa.HPKE_3_KE, PrivKey: xpriv, PubKey: xpub},
+		{Algorithm: jwa.HPKE_4, PrivKey: xpriv, PubKey: xpub},
+		{Algorithm: jwa.HPKE_4_KE, PrivKey: xpriv, PubKey: xpub},
+	}
+
+	plaintext := []byte("Lorem ipsum")
+	for _, tc := range testcases {
+		tc := tc
+		integrated := !strings.HasSuffix(tc.Algorithm.String(), "-KE")
+		t.Run(tc.Algorithm.String(), func(t *testing.T) {
+			t.Parallel()
+			t.Run("Compact", func(t *testing.T) {
+				encrypted, err := jwe.Encrypt(plaintext, jwe.WithKey(tc.Algorithm, tc.PubKey))
+				require.NoError(t, err, `jwe.Encrypt should succeed`)
+
+				msg, err := jwe.Parse(encrypted)
+				require.NoError(t, err, `jwe.Parse should succeed`)
+				require.NotEmpty(t, msg.ProtectedHeaders().EncapsulatedKey(), `"ek" should be populated`)
+				if integrated {
+					_, ok := msg.ProtectedHeaders().Get(jwe.ContentEncryptionKey)
+					require.False(t, ok, `"enc" should not be present in integrated encryption`)
+					require.Empty(t, msg.Recipients()[0].EncryptedKey(), `encrypted key should be empty in integrated encryption`)
+					require.Empty(t, msg.InitializationVector(), `iv should be empty in integrated encryption`)
+					require.Empty(t, msg.Tag(), `tag should be empty in integrated encryption`)
+				} else {
+					require.NotEmpty(t, msg.Recipients()[0].EncryptedKey(), `encrypted key should not be empty in key encryption`)
+				}
+
+				decrypted, err := jwe.Decrypt(encrypted, jwe.WithKey(tc.Algorithm, tc.PrivKey))
+				require.NoError(t, err, `jwe.Decrypt should succeed`)
+				require.Equal(t, plaintext, decrypted, `decrypted payload should match`)
+			})
+			t.Run("JSON", func(t *testing.T) {
+				encrypted, err := jwe.Encrypt(plaintext, jwe.WithJSON(), jwe.WithKey(tc.Algorithm, tc.PubKey), jwe.WithCompress(jwa.Deflate))
+				require.NoError(t, err, `jwe.Encrypt should succeed`)
+
+				decrypted, err := jwe.Decrypt(encrypted, jwe.WithKey(tc.Algorithm, tc.PrivKey))
+				require.NoError(t, err, `jwe.Decrypt should succeed`)
+				require.Equal(t, plaintext, decrypted, `decrypted payload should match`)
+			})
+			t.Run("jwk.Key", func(t *testing.T) {
+				privkey, err := jwk.FromRaw(tc.PrivKey)
+				require.NoError(t, err, `jwk.FromRaw should succeed`)
+				pubkey, err := jwk.PublicKeyOf(privkey)
+				require.NoError(t, err, `jwk.PublicKeyOf should succeed`)
+
+				encrypted, err := jwe.Encrypt(plaintext, jwe.WithKey(tc.Algorithm, pubkey))
+				require.NoError(t, err, `jwe.Encrypt should succeed`)
+
+				decrypted, err := jwe.Decrypt(encrypted, jwe.WithKey(tc.Algorithm, privkey))
+				require.NoError(t, err, `jwe.Decrypt should succeed`)
+				require.Equal(t, plaintext, decrypted, `decrypted payload should match`)
+			})
+			t.Run("Tampered header", func(t *testing.T) {
+				encrypted, err := jwe.Encrypt(plaintext, jwe.WithKey(tc.Algorithm, tc.PubKey))
+				require.NoError(t, err, `jwe.Encrypt should succeed`)
+
+				msg, err := jwe.Parse(encrypted)
+				require.NoError(t, err, `jwe.Parse should succeed`)
+				require.NoError(t, msg.ProtectedHeaders().Set(jwe.KeyIDKey, `tampered`), `Set should succeed`)
+				tampered, err := jwe.Compact(msg)
+				require.NoError(t, err, `jwe.Compact should succeed`)
+
+				_, err = jwe.Decrypt(tampered, jwe.WithKey(tc.Algorithm, tc.PrivKey))
+				require.Error(t, err, `jwe.Decrypt should fail`)
+			})
+		})
+	}
+
+	t.Run("AlgorithmsForKey", func(t *testing.T) {
+		t.Parallel()
+		algs, err := jwe.AlgorithmsForKey(&eckey.PublicKey)
+		require.NoError(t, err, `jwe.AlgorithmsForKey should succeed`)
+		require.Contains(t, algs, jwa.HPKE_0, `result should contain HPKE-0`)
+		require.NotContains(t, algs, jwa.HPKE_3, `result should not contain HPKE-3`)
+
+		algs, err = jwe.AlgorithmsForKey(xpub)
+		require.NoError(t, err, `jwe.AlgorithmsForKey should succeed`)
+		require.Contains(t, algs, jwa.HPKE_3_KE, `result should contain HPKE-3-KE`)
+		require.Contains(t, algs, jwa.HPKE_4, `result should contain HPKE-4`)
+		require.NotContains(t, algs, jwa.HPKE_0, `result should not contain HPKE-0`)
+
+		p384, err := ecdsa.GenerateKey(elliptic.P384(), rand.Reader)
+		require.NoError(t, err, `ecdsa.GenerateKey should succeed`)
+		algs, err = jwe.AlgorithmsForKey(&p384.PublicKey)
+		require.NoError(t, err, `jwe.AlgorithmsForKey should succeed`)
+		require.NotContains(t, algs, jwa.HPKE_0, `result should not contain HPKE-0 for P-384`)
+	})
+	t.Run("ecdh keys", func(t *testing.T) {
+		t.Parallel()
+		privkey, err := ecdh.X25519().GenerateKey(rand.Reader)
+		require.NoError(t, err, `ecdh.GenerateKey should succeed`)
+
+		encrypted, err := jwe.Encrypt(plaintext, jwe.WithKey(jwa.HPKE_3, privkey.PublicKey()))
+		require.NoError(t, err, `jwe.Encrypt should succeed`)
+
+		decrypted, err := jwe.Decrypt(encrypted, jwe.WithKey(jwa.HPKE_3, privkey))
+		require.NoError(t, err, `jwe.Decrypt should succeed`)
+		require.Equal(t, plaintext, decrypted, `decrypted payload should match`)
+	})
+	t.Run("Mismatched curve", func(t *testing.T) {
+		t.Parallel()
+		_, err := jwe.Encrypt(plaintext, jwe.WithKey(jwa.HPKE_3, &eckey.PublicKey))
+		require.Error(t, err, `jwe.Encrypt should fail`)
+		_, err = jwe.Encrypt(plaintext, jwe.WithKey(jwa.HPKE_0_KE, xpub))
+		require.Error(t, err, `jwe.Encrypt should fail`)
+	})
+	t.Run("Multiple recipients", func(t *testing.T) {
+		t.Parallel()
+		rsakey, err := rsa.GenerateKey(rand.Reader, 2048)
+		require.NoError(t, err, `rsa.GenerateKey should succeed`)
+
+		encrypted, err := jwe.Encrypt(
+			plaintext,
+			jwe.WithJSON(),
+			jwe.WithKey(jwa.RSA_OAEP, &rsakey.PublicKey),
+			jwe.WithKey(jwa.HPKE_0_KE, &eckey.PublicKey),
+			jwe.WithKey(jwa.HPKE_3_KE, xpub),
+		)
+		require.NoError(t, err, `jwe.Encrypt should succeed`)
+
+		var raw struct {
+			Recipients []struct {
+				Header map[string]interface{} `json:"header"`
+			} `json:"recipients"`
+		}
+		require.NoError(t, json.Unmarshal(encrypted, &raw), `json.Unmarshal should succeed`)
+		require.Len(t, raw.Recipients, 3, `there should be 3 recipients`)
+		require.NotContains(t, raw.Recipients[0].Header, jwe.EncapsulatedKeyKey, `RSA-OAEP recipient should not have "ek"`)
+		require.Contains(t, raw.Recipients[1].Header, jwe.EncapsulatedKeyKey, `HPKE recipient should have "ek"`)
+		require.Contains(t, raw.Recipients[2].Header, jwe.EncapsulatedKeyKey, `HPKE recipient should have "ek"`)
+
+		keys := []struct {
+			Algorithm jwa.KeyEncryptionAlgorithm
+			Key       interface{}
+		}{
+			{Algorithm: jwa.RSA_OAEP, Key: rsakey},
+			{Algorithm: jwa.HPKE_0_KE, Key: eckey},
+			{Algorithm: jwa.HPKE_3_KE, Key: xpriv},
+		}
+		for _, key := range keys {
+			decrypted, err := jwe.Decrypt(encrypted, jwe.WithKey(key.Algorithm, key.Key))
+			require.NoError(t, err, `jwe.Decrypt should succeed for %s`, key.Algorithm)
+			require.Equal(t, plaintext, decrypted, `decrypted payload should match`)
+		}
+	})
+	t.Run("Recipient_structure", func(t *testing.T) {
+		t.Parallel()
+		// In key encryption mode the HPKE info parameter must be the
+		// Recipient_structure for the content encryption algorithm, and
+		// the HPKE aad must be empty. Open the encrypted key by hand to
+		// make sure that this is what is being used
+		encrypted, err := jwe.Encrypt(plaintext, jwe.WithKey(jwa.HPKE_3_KE, xpub), jwe.WithContentEncryption(jwa.A256GCM))
+		require.NoError(t, err, `jwe.Encrypt should succeed`)
+
+		msg, err := jwe.Parse(encrypted)
+		require.NoError(t, err, `jwe.Parse should succeed`)
+
+		ecdhkey, err := ecdh.X25519().NewPrivateKey(xpriv.Seed())
+		require.NoError(t, err, `ecdh.NewPrivateKey should succeed`)
+		privkey, err := hpke.NewDHKEMPrivateKey(ecdhkey)
+		require.NoError(t, err, `hpke.NewDHKEMPrivateKey should succeed`)
+
+		open := func(enc string) ([]byte, error) {
+			info := append(append([]byte("JOSE-HPKE rcpt\xff"), enc...), 0xff)
+			recipient, err := hpke.NewRecipient(msg.ProtectedHeaders().EncapsulatedKey(), privkey, hpke.HKDFSHA256(), hpke.AES128GCM(), info)
+			require.NoError(t, err, `hpke.NewRecipient should succeed`)
+			return recipient.Open(nil, msg.Recipients()[0].EncryptedKey())
+		}
+
+		cek, err := open(`A256GCM`)
+		require.NoError(t, err, `opening the encrypted key should succeed`)
+		require.Len(t, cek, 32, `content encryption key should be 32 bytes`)
+
+		_, err = open(`A128GCM`)
+		require.Error(t, err, `opening the encrypted key for a different "enc" should fail`)
+	})
+	t.Run("Multiple recipients with integrated encryption", func(t *testing.T) {
+		t.Parallel()
+		_, err := jwe.Encrypt(
+			plaintext,
+			jwe.WithJSON(),
+			jwe.WithKey(jwa.HPKE_0, &eckey.PublicKey),
+			jwe.WithKey(jwa.HPKE_3, xpub),
+		)
+		require.Error(t, err, `jwe.Encrypt should fail`)
+	})
+}
@@ -113,6 +113,12 @@ type stdRecipient struct {
 // which would obviously result in a contradicting integrity value
 // if we tried to re-calculate it from a parsed message.
 //
+// To avoid this, a parsed Message retains the original encoding of the
+// protected header, and uses it when the message is serialized again
+// via `json.Marshal()` or `jwe.Compact()`, as long as the protected
+// header has not been modified. This allows parsed messages to be
+// stored and decrypted later.
+//
 //nolint:govet
 type Message struct {
 	// Comments on each field are taken from https://datatracker.ietf.org/doc/html/rfc7516
@@ -194,12 +200,12 @@ type Message struct {
 	// privateParams map[string]interface{}
 
 	// These two fields below are not available for the public consumers of this object.
-	// rawProtectedHeaders stores the original protected header buffer
+	// rawProtectedHeaders stores the original (base64 encoded) protected header buffer
 	rawProtectedHeaders []byte
-	// storeProtectedHeaders is a hint to be used in UnmarshalJSON().
-	// When this flag is true, UnmarshalJSON() will populate the
-	// rawProtectedHeaders field
-	storeProtectedHeaders bool
+	// protectedHeadersSnapshot stores the encoded form of the protected
+	// headers at the time of parsing, so that we can tell if they have
+	// been modified since, in which case rawProtectedHeaders is stale
+	protectedHeadersSnapshot []byte
 }
 
 // populater is an interface for things that may modify the
@@ -4,14 +4,18 @@ import (
 	"crypto/aes"
 	"crypto/cipher"
 	"fmt"
+	"sync"
 
 	"github.com/lestrrat-go/jwx/v2/jwa"
 	"github.com/lestrrat-go/jwx/v2/jwe/internal/aescbc"
 	"github.com/lestrrat-go/jwx/v2/jwe/internal/keygen"
+	"golang.org/x/crypto/chacha20poly1305"
 )
 
 var gcm = &gcmFetcher{}
 var cbc = &cbcFetcher{}
+var c20p = &chacha20poly1305Fetcher{}
+var xc20p = &xchacha20poly1305Fetcher{}
 
 func (f gcmFetcher) Fetch(key []byte) (cipher.AEAD, error) {
 	aescipher, err := aes.NewCipher(key)
@@ -34,15 +38,93 @@ func (f cbcFetcher) Fetch(key []byte) (cipher.AEAD, error) {
 	return aead, nil
 }
 
-func (c AesContentCipher) KeySize() int {
+func (f chacha20poly1305Fetcher) Fetch(key []byte) (cipher.AEAD, error) {
+	aead, err := chacha20poly1305.New(key)
+	if err != nil {
+		return nil, fmt.Errorf(`cipher: failed to create ChaCha20-Poly1305 cipher: %w`, err)
+	}
+	return aead, nil
+}
+
+func (f xchacha20poly1305Fetcher) Fetch(key []byte) (cipher.AEAD, error) {
+	aead, err := chacha20poly1305.NewX(key)
+	if err != nil {
+		return nil, fmt.Errorf(`cipher: failed to create XChaCha20-Poly1305 cipher: %w`, err)
+	}
+	return aead, nil
+}
+
+func (c AEADContentCipher) KeySize() int {
 	return c.keysize
 }
 
-func (c AesContentCipher) TagSize() int {
+func (c AEADContentCipher) TagSize() int {
 	return c.tagsize
 }
 
-func NewAES(alg jwa.ContentEncryptionAlgorithm) (*AesContentCipher, error) {
+var muFactories sync.RWMutex
+var factories = make(map[jwa.ContentEncryptionAlgorithm]Factory)
+
+// Register registers a factory that creates content ciphers for the
+// given algorithm. Registered factories take precedence over the
+// built-in ciphers.
+func Register(alg jwa.ContentEncryptionAlgorithm, f Factory) {
+	muFactories.Lock()
+	defer muFactories.Unlock()
+	factories[alg] = f
+}
+
+// Unregister removes the factory registered for the given algorithm
+func Unregister(alg jwa.ContentEncryptionAlgorithm) {
+	muFactories.Lock()
+	defer muFactories.Unlock()
+	delete(factories, alg)
+}
+
+// New creates a content cipher for the given content encryption algorithm
+func New(alg jwa.ContentEncryptionAlgorithm) (ContentCipher, error) {
+	muFactories.RLock()
+	f, ok := factories[alg]
+	muFactories.RUnlock()
+	if ok {
+		c, err := f()
+		if err != nil {
+			return nil, fmt.Errorf(`failed to create content cipher for %s: %w`, alg, err)
+		}
+		return c, nil
+	}
+
+	switch alg {
+	case jwa.A128GCM, jwa.A192GCM, jwa.A256GCM, jwa.A128CBC_HS256, jwa.A192CBC_HS384, jwa.A256CBC_HS512:
+		return NewAES(alg)
+	case jwa.C20P, jwa.XC20P:
+		return NewChaCha20Poly1305(alg)
+	default:
+		return nil, fmt.Errorf(`unsupported content encryption algorithm (%s)`, alg)
+	}
+}
+
+// NewChaCha20Poly1305 creates a content cipher based on ChaCha20-Poly1305
+// (C20P) or XChaCha20-Poly1305 (XC20P)
+func NewChaCha20Poly1305(alg jwa.ContentEncryptionAlgorithm) (*AEADContentCipher, error) {
+	var fetcher Fetcher
+	switch alg {
+	case jwa.C20P:
+		fetcher = c20p
+	case jwa.XC20P:
+		fetcher = xc20p
+	default:
+		return nil, fmt.Errorf("failed to create ChaCha20-Poly1305 content cipher: invalid algorithm (%s)", alg)
+	}
+
+	return &AEADContentCipher{
+		keysize: chacha20poly1305.KeySize,
+		tagsize: chacha20poly1305.Overhead,
+		fetch:   fetcher,
+	}, nil
+}
+
+func NewAES(alg jwa.ContentEncryptionAlgorithm) (*AEADContentCipher, error) {
 	var keysize int
 	var tagsize int
 	var fetcher Fetcher
@@ -75,14 +157,14 @@ func NewAES(alg jwa.ContentEncryptionAlgorithm) (*AesContentCipher, error) {
 		return nil, fmt.Errorf("failed to create AES content cipher: invalid algorithm (%s)", alg)
 	}
 
-	return &AesContentCipher{
+	return &AEADContentCipher{
 		keysize: keysize,
 		tagsize: tagsize,
 		fetch:   fetcher,
 	}, nil
 }
 
-func (c AesContentCipher) Encrypt(cek, plaintext, aad []byte) (iv, ciphertxt, tag []byte, err error) {
+func (c AEADContentCipher) Encrypt(cek, plaintext, aad []byte) (iv, ciphertxt, tag []byte, err error) {
 	var aead cipher.AEAD
 	aead, err = c.fetch.Fetch(cek)
 	if err != nil {
@@ -127,7 +209,7 @@ func (c AesContentCipher) Encrypt(cek, plaintext, aad []byte) (iv, ciphertxt, ta
 	return
 }
 
-func (c AesContentCipher) Decrypt(cek, iv, ciphertxt, tag, aad []byte) (plaintext []byte, err error) {
+func (c AEADContentCipher) Decrypt(cek, iv, ciphertxt, tag, aad []byte) (plaintext []byte, err error) {
 	aead, err := c.fetch.Fetch(cek)
 	if err != nil {
 		return nil, fmt.Errorf(`failed to fetch AEAD data: %w`, err)
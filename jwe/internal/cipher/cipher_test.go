@@ -25,3 +25,33 @@ func TestAES(t *testing.T) {
 		t.Logf("keysize = %d", c.KeySize())
 	}
 }
+
+func TestChaCha20Poly1305(t *testing.T) {
+	algs := []jwa.ContentEncryptionAlgorithm{
+		jwa.C20P,
+		jwa.XC20P,
+	}
+	for _, alg := range algs {
+		c, err := cipher.New(alg)
+		if !assert.NoError(t, err, "BuildCipher for %s succeeds", alg) {
+			return
+		}
+		if !assert.Equal(t, 32, c.KeySize(), "key size for %s should be 32", alg) {
+			return
+		}
+
+		cek := make([]byte, c.KeySize())
+		iv, ciphertext, tag, err := c.Encrypt(cek, []byte("Lorem ipsum"), []byte("aad"))
+		if !assert.NoError(t, err, "Encrypt for %s succeeds", alg) {
+			return
+		}
+
+		plaintext, err := c.Decrypt(cek, iv, ciphertext, tag, []byte("aad"))
+		if !assert.NoError(t, err, "Decrypt for %s succeeds", alg) {
+			return
+		}
+		if !assert.Equal(t, []byte("Lorem ipsum"), plaintext, "decrypted content for %s should match", alg) {
+			return
+		}
+	}
+}
@@ -14,19 +14,25 @@ const (
 // encryption key and other data
 type ContentCipher interface {
 	KeySize() int
-	Encrypt(cek, aad, plaintext []byte) ([]byte, []byte, []byte, error)
-	Decrypt(cek, iv, aad, ciphertext, tag []byte) ([]byte, error)
+	Encrypt(cek, plaintext, aad []byte) ([]byte, []byte, []byte, error)
+	Decrypt(cek, iv, ciphertext, tag, aad []byte) ([]byte, error)
 }
 
+// Factory creates a new ContentCipher
+type Factory func() (ContentCipher, error)
+
 type Fetcher interface {
 	Fetch([]byte) (cipher.AEAD, error)
 }
 
 type gcmFetcher struct{}
 type cbcFetcher struct{}
+type chacha20poly1305Fetcher struct{}
+type xchacha20poly1305Fetcher struct{}
 
-// AesContentCipher represents a cipher based on AES
-type AesContentCipher struct {
+// AEADContentCipher represents a cipher based on an AEAD, such as
+// AES-GCM, AES-CBC-HMAC, or (X)ChaCha20-Poly1305
+type AEADContentCipher struct {
 	NonceGenerator keygen.Generator
 	fetch          Fetcher
 	keysize        int
@@ -25,9 +25,9 @@ func (c Generic) Decrypt(cek, iv, ciphertext, tag, aad []byte) ([]byte, error) {
 }
 
 func NewGeneric(alg jwa.ContentEncryptionAlgorithm) (*Generic, error) {
-	c, err := cipher.NewAES(alg)
+	c, err := cipher.New(alg)
 	if err != nil {
-		return nil, fmt.Errorf(`aes crypt: failed to create content cipher: %w`, err)
+		return nil, fmt.Errorf(`failed to create content cipher: %w`, err)
 	}
 
 	return &Generic{
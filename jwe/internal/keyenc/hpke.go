@@ -0,0 +1,289 @@
+//go:build go1.26
+// +build go1.26
+
+package keyenc
+
+import (
+	"crypto/ecdh"
+	"crypto/ecdsa"
+	"crypto/hpke"
+	"fmt"
+
+	"github.com/lestrrat-go/jwx/v2/internal/keyconv"
+	"github.com/lestrrat-go/jwx/v2/jwa"
+	"github.com/lestrrat-go/jwx/v2/jwe/internal/keygen"
+	"github.com/lestrrat-go/jwx/v2/x25519"
+)
+
+// HPKEAvailable is true if the HPKE algorithms can be used
+const HPKEAvailable = true
+
+// hpkeSuite is the combination of KEM, KDF, and AEAD that is
+// identified by a single HPKE algorithm name.
+type hpkeSuite struct {
+	curve ecdh.Curve
+	kdf   hpke.KDF
+	aead  hpke.AEAD
+}
+
+func hpkeSuiteFor(alg jwa.KeyEncryptionAlgorithm) (*hpkeSuite, error) {
+	switch alg {
+	case jwa.HPKE_0, jwa.HPKE_0_KE:
+		return &hpkeSuite{curve: ecdh.P256(), kdf: hpke.HKDFSHA256(), aead: hpke.AES128GCM()}, nil
+	case jwa.HPKE_3, jwa.HPKE_3_KE:
+		return &hpkeSuite{curve: ecdh.X25519(), kdf: hpke.HKDFSHA256(), aead: hpke.AES128GCM()}, nil
+	case jwa.HPKE_4, jwa.HPKE_4_KE:
+		return &hpkeSuite{curve: ecdh.X25519(), kdf: hpke.HKDFSHA256(), aead: hpke.ChaCha20Poly1305()}, nil
+	default:
+		return nil, fmt.Errorf(`invalid HPKE algorithm (%s)`, alg)
+	}
+}
+
+// publicKey converts the given key into a HPKE public key. Private keys
+// are also accepted, in which case their public key is used.
+func (s *hpkeSuite) publicKey(keyif interface{}) (hpke.PublicKey, error) {
+	var pubkey *ecdh.PublicKey
+	switch key := keyif.(type) {
+	case *ecdh.PublicKey:
+		pubkey = key
+	case *ecdh.PrivateKey:
+		pubkey = key.PublicKey()
+	case x25519.PublicKey:
+		v, err := ecdh.X25519().NewPublicKey(key)
+		if err != nil {
+			return nil, fmt.Errorf(`failed to convert x25519.PublicKey: %w`, err)
+		}
+		pubkey = v
+	case x25519.PrivateKey:
+		v, err := ecdh.X25519().NewPrivateKey(key.Seed())
+		if err != nil {
+			return nil, fmt.Errorf(`failed to convert x25519.PrivateKey: %w`, err)
+		}
+		pubkey = v.PublicKey()
+	default:
+		var ecdsakey ecdsa.PublicKey
+		if err := keyconv.ECDSAPublicKey(&ecdsakey, keyif); err != nil {
+			return nil, fmt.Errorf(`unexpected key type %T: %w`, keyif, err)
+		}
+		v, err := ecdsakey.ECDH()
+		if err != nil {
+			return nil, fmt.Errorf(`failed to convert *ecdsa.PublicKey: %w`, err)
+		}
+		pubkey = v
+	}
+
+	if pubkey.Curve() != s.curve {
+		return nil, fmt.Errorf(`key does not match the curve of the HPKE algorithm`)
+	}
+	return hpke.NewDHKEMPublicKey(pubkey)
+}
+
+// privateKey converts the given key into a HPKE private key.
+func (s *hpkeSuite) privateKey(keyif interface{}) (hpke.PrivateKey, error) {
+	var privkey *ecdh.PrivateKey
+	switch key := keyif.(type) {
+	case *ecdh.PrivateKey:
+		privkey = key
+	case x25519.PrivateKey:
+		v, err := ecdh.X25519().NewPrivateKey(key.Seed())
+		if err != nil {
+			return nil, fmt.Errorf(`failed to convert x25519.PrivateKey: %w`, err)
+		}
+		privkey = v
+	default:
+		var ecdsakey ecdsa.PrivateKey
+		if err := keyconv.ECDSAPrivateKey(&ecdsakey, keyif); err != nil {
+			return nil, fmt.Errorf(`unexpected key type %T: %w`, keyif, err)
+		}
+		v, err := ecdsakey.ECDH()
+		if err != nil {
+			return nil, fmt.Errorf(`failed to convert *ecdsa.PrivateKey: %w`, err)
+		}
+		privkey = v
+	}
+
+	if privkey.Curve() != s.curve {
+		return nil, fmt.Errorf(`key does not match the curve of the HPKE algorithm`)
+	}
+	return hpke.NewDHKEMPrivateKey(privkey)
+}
+
+// hpkeRecipientInfo builds the Recipient_structure that is used as the
+// HPKE info parameter in key encryption mode, as described in
+// draft-ietf-jose-hpke-encrypt:
+//
+//	Recipient_structure = ASCII("JOSE-HPKE rcpt") || BYTE(255) ||
+//	                      ASCII(content_encryption_alg) || BYTE(255) ||
+//	                      recipient_extra_info
+//
+// This binds the encrypted key to the content encryption algorithm.
+// recipient_extra_info is always empty.
+func hpkeRecipientInfo(enc jwa.ContentEncryptionAlgorithm) []byte {
+	info := make([]byte, 0, len(hpkeRecipientLabel)+len(enc)+2)
+	info = append(info, hpkeRecipientLabel...)
+	info = append(info, 0xff)
+	info = append(info, enc.String()...)
+	info = append(info, 0xff)
+	return info
+}
+
+const hpkeRecipientLabel = "JOSE-HPKE rcpt"
+
+// HPKEEncrypt encrypts content encryption keys using HPKE
+// (key encryption mode)
+type HPKEEncrypt struct {
+	algorithm jwa.KeyEncryptionAlgorithm
+	keyID     string
+	suite     *hpkeSuite
+	info      []byte
+	pubkey    hpke.PublicKey
+}
+
+// NewHPKEEncrypt creates a new key encrypter using HPKE in key
+// encryption mode. The encapsulated key is stored in the "ek" header,
+// and the encrypted content encryption key is stored as the JWE
+// encrypted key. The HPKE info parameter is the Recipient_structure
+// for enc, and the HPKE aad is empty.
+func NewHPKEEncrypt(alg jwa.KeyEncryptionAlgorithm, enc jwa.ContentEncryptionAlgorithm, keyif interface{}) (Encrypter, error) {
+	suite, err := hpkeSuiteFor(alg)
+	if err != nil {
+		return nil, err
+	}
+	pubkey, err := suite.publicKey(keyif)
+	if err != nil {
+		return nil, fmt.Errorf(`failed to create HPKE public key: %w`, err)
+	}
+	return &HPKEEncrypt{
+		algorithm: alg,
+		suite:     suite,
+		info:      hpkeRecipientInfo(enc),
+		pubkey:    pubkey,
+	}, nil
+}
+
+// Algorithm returns the key encryption algorithm being used
+func (kw HPKEEncrypt) Algorithm() jwa.KeyEncryptionAlgorithm {
+	return kw.algorithm
+}
+
+func (kw *HPKEEncrypt) SetKeyID(v string) {
+	kw.keyID = v
+}
+
+// KeyID returns the key ID associated with this encrypter
+func (kw HPKEEncrypt) KeyID() string {
+	return kw.keyID
+}
+
+// EncryptKey encrypts the content encryption key using HPKE
+func (kw HPKEEncrypt) EncryptKey(cek []byte) (keygen.ByteSource, error) {
+	ek, sender, err := hpke.NewSender(kw.pubkey, kw.suite.kdf, kw.suite.aead, kw.info)
+	if err != nil {
+		return nil, fmt.Errorf(`failed to create HPKE sender: %w`, err)
+	}
+
+	encrypted, err := sender.Seal(nil, cek)
+	if err != nil {
+		return nil, fmt.Errorf(`failed to encrypt key: %w`, err)
+	}
+
+	return keygen.ByteWithEncapsulatedKey{
+		ByteKey:         keygen.ByteKey(encrypted),
+		EncapsulatedKey: ek,
+	}, nil
+}
+
+// HPKEDecrypt decrypts keys using HPKE (key encryption mode)
+type HPKEDecrypt struct {
+	algorithm jwa.KeyEncryptionAlgorithm
+	suite     *hpkeSuite
+	ek        []byte
+	info      []byte
+	privkey   hpke.PrivateKey
+}
+
+// NewHPKEDecrypt creates a new key decrypter using HPKE in key
+// encryption mode. ek is the value of the "ek" header, and enc is the
+// content encryption algorithm that the key is used with.
+func NewHPKEDecrypt(alg jwa.KeyEncryptionAlgorithm, enc jwa.ContentEncryptionAlgorithm, ek []byte, keyif interface{}) (Decrypter, error) {
+	suite, err := hpkeSuiteFor(alg)
+	if err != nil {
+		return nil, err
+	}
+	privkey, err := suite.privateKey(keyif)
+	if err != nil {
+		return nil, fmt.Errorf(`failed to create HPKE private key: %w`, err)
+	}
+	return &HPKEDecrypt{
+		algorithm: alg,
+		suite:     suite,
+		ek:        ek,
+		info:      hpkeRecipientInfo(enc),
+		privkey:   privkey,
+	}, nil
+}
+
+// Algorithm returns the key encryption algorithm being used
+func (kw HPKEDecrypt) Algorithm() jwa.KeyEncryptionAlgorithm {
+	return kw.algorithm
+}
+
+// Decrypt decrypts the encrypted key using HPKE
+func (kw HPKEDecrypt) Decrypt(enckey []byte) ([]byte, error) {
+	if len(kw.ek) == 0 {
+		return nil, fmt.Errorf(`missing 'ek' header for %s`, kw.algorithm)
+	}
+
+	recipient, err := hpke.NewRecipient(kw.ek, kw.privkey, kw.suite.kdf, kw.suite.aead, kw.info)
+	if err != nil {
+		return nil, fmt.Errorf(`failed to create HPKE recipient: %w`, err)
+	}
+
+	cek, err := recipient.Open(nil, enckey)
+	if err != nil {
+		return nil, fmt.Errorf(`failed to decrypt key: %w`, err)
+	}
+	return cek, nil
+}
+
+// NewHPKESender creates a HPKE sending context for integrated encryption,
+// where the payload is encrypted directly using HPKE. It returns the
+// encapsulated key, and a function to encrypt the payload. The HPKE info
+// parameter is empty, and the caller is expected to pass the JWE
+// Additional Authenticated Data as the aad.
+func NewHPKESender(alg jwa.KeyEncryptionAlgorithm, keyif interface{}) ([]byte, func(aad, plaintext []byte) ([]byte, error), error) {
+	suite, err := hpkeSuiteFor(alg)
+	if err != nil {
+		return nil, nil, err
+	}
+	pubkey, err := suite.publicKey(keyif)
+	if err != nil {
+		return nil, nil, fmt.Errorf(`failed to create HPKE public key: %w`, err)
+	}
+	enc, sender, err := hpke.NewSender(pubkey, suite.kdf, suite.aead, nil)
+	if err != nil {
+		return nil, nil, fmt.Errorf(`failed to create HPKE sender: %w`, err)
+	}
+	return enc, sender.Seal, nil
+}
+
+// HPKEOpen decrypts a payload that was encrypted using HPKE integrated
+// encryption. enc is the value of the "ek" header.
+func HPKEOpen(alg jwa.KeyEncryptionAlgorithm, keyif interface{}, enc, aad, ciphertext []byte) ([]byte, error) {
+	suite, err := hpkeSuiteFor(alg)
+	if err != nil {
+		return nil, err
+	}
+	if len(enc) == 0 {
+		return nil, fmt.Errorf(`missing 'ek' header for %s`, alg)
+	}
+	privkey, err := suite.privateKey(keyif)
+	if err != nil {
+		return nil, fmt.Errorf(`failed to create HPKE private key: %w`, err)
+	}
+	recipient, err := hpke.NewRecipient(enc, privkey, suite.kdf, suite.aead, nil)
+	if err != nil {
+		return nil, fmt.Errorf(`failed to create HPKE recipient: %w`, err)
+	}
+	return recipient.Open(aad, ciphertext)
+}
@@ -0,0 +1,29 @@
+//go:build !go1.26
+// +build !go1.26
+
+package keyenc
+
+import (
+	"fmt"
+
+	"github.com/lestrrat-go/jwx/v2/jwa"
+)
+
+// HPKEAvailable is true if the HPKE algorithms can be used
+const HPKEAvailable = false
+
+func NewHPKEEncrypt(alg jwa.KeyEncryptionAlgorithm, _ jwa.ContentEncryptionAlgorithm, _ interface{}) (Encrypter, error) {
+	return nil, fmt.Errorf(`%s requires Go 1.26 or later`, alg)
+}
+
+func NewHPKEDecrypt(alg jwa.KeyEncryptionAlgorithm, _ jwa.ContentEncryptionAlgorithm, _ []byte, _ interface{}) (Decrypter, error) {
+	return nil, fmt.Errorf(`%s requires Go 1.26 or later`, alg)
+}
+
+func NewHPKESender(alg jwa.KeyEncryptionAlgorithm, _ interface{}) ([]byte, func(aad, plaintext []byte) ([]byte, error), error) {
+	return nil, nil, fmt.Errorf(`%s requires Go 1.26 or later`, alg)
+}
+
+func HPKEOpen(alg jwa.KeyEncryptionAlgorithm, _ interface{}, _, _, _ []byte) ([]byte, error) {
+	return nil, fmt.Errorf(`%s requires Go 1.26 or later`, alg)
+}
@@ -41,6 +41,14 @@ type AESGCMEncrypt struct {
 	sharedkey []byte
 }
 
+// ChaCha20Poly1305Encrypt encrypts content encryption keys using
+// (X)ChaCha20-Poly1305 key wrap.
+type ChaCha20Poly1305Encrypt struct {
+	algorithm jwa.KeyEncryptionAlgorithm
+	keyID     string
+	sharedkey []byte
+}
+
 // ECDHESEncrypt encrypts content encryption keys using ECDH-ES.
 type ECDHESEncrypt struct {
 	algorithm jwa.KeyEncryptionAlgorithm
@@ -58,6 +66,51 @@ type ECDHESDecrypt struct {
 	pubkey     interface{}
 }
 
+// ECDH1PUEncrypt encrypts content encryption keys using ECDH-1PU.
+type ECDH1PUEncrypt struct {
+	algorithm jwa.KeyEncryptionAlgorithm
+	enc       jwa.ContentEncryptionAlgorithm
+	keysize   int
+	keyID     string
+	apu       []byte
+	apv       []byte
+	z         []byte
+	epk       interface{}
+}
+
+// ECDH1PUDecrypt decrypts keys using ECDH-1PU.
+type ECDH1PUDecrypt struct {
+	keyalg     jwa.KeyEncryptionAlgorithm
+	contentalg jwa.ContentEncryptionAlgorithm
+	apu        []byte
+	apv        []byte
+	tag        []byte
+	privkey    interface{}
+	pubkey     interface{}
+	senderkey  interface{}
+}
+
+// MLKEMEncrypt encrypts content encryption keys using ML-KEM.
+type MLKEMEncrypt struct {
+	algorithm   jwa.KeyEncryptionAlgorithm
+	enc         jwa.ContentEncryptionAlgorithm
+	keysize     int
+	keyID       string
+	apu         []byte
+	apv         []byte
+	encapsulate func() ([]byte, []byte)
+}
+
+// MLKEMDecrypt decrypts keys using ML-KEM.
+type MLKEMDecrypt struct {
+	keyalg      jwa.KeyEncryptionAlgorithm
+	contentalg  jwa.ContentEncryptionAlgorithm
+	apu         []byte
+	apv         []byte
+	ciphertext  []byte
+	decapsulate func([]byte) ([]byte, error)
+}
+
 // RSAOAEPEncrypt encrypts keys using RSA OAEP algorithm
 type RSAOAEPEncrypt struct {
 	alg    jwa.KeyEncryptionAlgorithm
@@ -16,6 +16,7 @@ import (
 	"hash"
 	"io"
 
+	"golang.org/x/crypto/chacha20poly1305"
 	"golang.org/x/crypto/curve25519"
 	"golang.org/x/crypto/pbkdf2"
 
@@ -25,6 +26,7 @@ import (
 	"github.com/lestrrat-go/jwx/v2/jwe/internal/concatkdf"
 	"github.com/lestrrat-go/jwx/v2/jwe/internal/keygen"
 	"github.com/lestrrat-go/jwx/v2/x25519"
+	"github.com/lestrrat-go/jwx/v2/x448"
 )
 
 func NewNoop(alg jwa.KeyEncryptionAlgorithm, sharedkey []byte) (*Noop, error) {
@@ -145,6 +147,73 @@ func (kw AESGCMEncrypt) EncryptKey(cek []byte) (keygen.ByteSource, error) {
 	}, nil
 }
 
+// NewChaCha20Poly1305Encrypt creates a key-wrap encrypter using
+// ChaCha20-Poly1305 (C20PKW) or XChaCha20-Poly1305 (XC20PKW).
+func NewChaCha20Poly1305Encrypt(alg jwa.KeyEncryptionAlgorithm, sharedkey []byte) (*ChaCha20Poly1305Encrypt, error) {
+	switch alg {
+	case jwa.C20PKW, jwa.XC20PKW:
+	default:
+		return nil, fmt.Errorf("unexpected key encryption algorithm %s", alg)
+	}
+	return &ChaCha20Poly1305Encrypt{
+		algorithm: alg,
+		sharedkey: sharedkey,
+	}, nil
+}
+
+func (kw ChaCha20Poly1305Encrypt) Algorithm() jwa.KeyEncryptionAlgorithm {
+	return kw.algorithm
+}
+
+func (kw *ChaCha20Poly1305Encrypt) SetKeyID(v string) {
+	kw.keyID = v
+}
+
+func (kw ChaCha20Poly1305Encrypt) KeyID() string {
+	return kw.keyID
+}
+
+func (kw ChaCha20Poly1305Encrypt) EncryptKey(cek []byte) (keygen.ByteSource, error) {
+	aead, err := NewChaCha20Poly1305AEAD(kw.algorithm, kw.sharedkey)
+	if err != nil {
+		return nil, err
+	}
+
+	iv := make([]byte, aead.NonceSize())
+	_, err = io.ReadFull(rand.Reader, iv)
+	if err != nil {
+		return nil, fmt.Errorf(`failed to get random iv: %w`, err)
+	}
+
+	encrypted := aead.Seal(nil, iv, cek, nil)
+	tag := encrypted[len(encrypted)-aead.Overhead():]
+	ciphertext := encrypted[:len(encrypted)-aead.Overhead()]
+	return keygen.ByteWithIVAndTag{
+		ByteKey: ciphertext,
+		IV:      iv,
+		Tag:     tag,
+	}, nil
+}
+
+// NewChaCha20Poly1305AEAD creates the AEAD used for the C20PKW and XC20PKW
+// key wrapping algorithms.
+func NewChaCha20Poly1305AEAD(alg jwa.KeyEncryptionAlgorithm, sharedkey []byte) (cipher.AEAD, error) {
+	var aead cipher.AEAD
+	var err error
+	switch alg {
+	case jwa.C20PKW:
+		aead, err = chacha20poly1305.New(sharedkey)
+	case jwa.XC20PKW:
+		aead, err = chacha20poly1305.NewX(sharedkey)
+	default:
+		return nil, fmt.Errorf("unexpected key encryption algorithm %s", alg)
+	}
+	if err != nil {
+		return nil, fmt.Errorf(`failed to create %s cipher from shared key: %w`, alg, err)
+	}
+	return aead, nil
+}
+
 func NewPBES2Encrypt(alg jwa.KeyEncryptionAlgorithm, password []byte) (*PBES2Encrypt, error) {
 	var hashFunc func() hash.Hash
 	var keylen int
@@ -218,6 +287,8 @@ func NewECDHESEncrypt(alg jwa.KeyEncryptionAlgorithm, enc jwa.ContentEncryptionA
 		generator, err = keygen.NewEcdhes(alg, enc, keysize, key, apu, apv)
 	case x25519.PublicKey:
 		generator, err = keygen.NewX25519(alg, enc, keysize, key)
+	case x448.PublicKey:
+		generator, err = keygen.NewX448(alg, enc, keysize, key, apu, apv)
 	default:
 		return nil, fmt.Errorf("unexpected key type %T", keyif)
 	}
@@ -304,6 +375,16 @@ func DeriveZ(privkeyif interface{}, pubkeyif interface{}) ([]byte, error) {
 			return nil, fmt.Errorf(`public key must be x25519.PublicKey, was: %T`, pubkeyif)
 		}
 		return curve25519.X25519(privkey.Seed(), pubkey)
+	case x448.PrivateKey:
+		privkey, ok := privkeyif.(x448.PrivateKey)
+		if !ok {
+			return nil, fmt.Errorf(`private key must be x448.PrivateKey, was: %T`, privkeyif)
+		}
+		pubkey, ok := pubkeyif.(x448.PublicKey)
+		if !ok {
+			return nil, fmt.Errorf(`public key must be x448.PublicKey, was: %T`, pubkeyif)
+		}
+		return x448.X448(privkey.Seed(), pubkey)
 	default:
 		privkey, ok := privkeyif.(*ecdsa.PrivateKey)
 		if !ok {
@@ -327,13 +408,19 @@ func DeriveZ(privkeyif interface{}, pubkeyif interface{}) ([]byte, error) {
 }
 
 func DeriveECDHES(alg, apu, apv []byte, privkey interface{}, pubkey interface{}, keysize uint32) ([]byte, error) {
-	pubinfo := make([]byte, 4)
-	binary.BigEndian.PutUint32(pubinfo, keysize*8)
 	zBytes, err := DeriveZ(privkey, pubkey)
 	if err != nil {
 		return nil, fmt.Errorf(`unable to determine Z: %w`, err)
 	}
-	kdf := concatkdf.New(crypto.SHA256, alg, zBytes, apu, apv, pubinfo, []byte{})
+	return deriveConcatKDF(alg, zBytes, apu, apv, keysize)
+}
+
+// deriveConcatKDF derives a key of keysize bytes from the shared secret z
+// using Concat KDF, as described in https://tools.ietf.org/html/rfc7518#section-4.6.2
+func deriveConcatKDF(alg, z, apu, apv []byte, keysize uint32) ([]byte, error) {
+	pubinfo := make([]byte, 4)
+	binary.BigEndian.PutUint32(pubinfo, keysize*8)
+	kdf := concatkdf.New(crypto.SHA256, alg, z, apu, apv, pubinfo, []byte{})
 	key := make([]byte, keysize)
 	if _, err := kdf.Read(key); err != nil {
 		return nil, fmt.Errorf(`failed to read kdf: %w`, err)
@@ -353,7 +440,7 @@ func (kw ECDHESDecrypt) Decrypt(enckey []byte) ([]byte, error) {
 	switch kw.keyalg {
 	case jwa.ECDH_ES:
 		// Create a content cipher from the content encryption algorithm
-		c, err := contentcipher.NewAES(kw.contentalg)
+		c, err := contentcipher.New(kw.contentalg)
 		if err != nil {
 			return nil, fmt.Errorf(`failed to create content cipher for %s: %w`, kw.contentalg, err)
 		}
@@ -387,10 +474,397 @@ func (kw ECDHESDecrypt) Decrypt(enckey []byte) ([]byte, error) {
 	return Unwrap(block, enckey)
 }
 
+// NewECDH1PUEncrypt creates a new key encrypter based on ECDH-1PU, as
+// described in https://datatracker.ietf.org/doc/html/draft-madden-jose-ecdh-1pu-04.
+// pubkey is the recipient's public key, and senderkey is the sender's static
+// private key. An ephemeral key is generated for each encrypter.
+func NewECDH1PUEncrypt(alg jwa.KeyEncryptionAlgorithm, enc jwa.ContentEncryptionAlgorithm, keysize int, pubkey, senderkey interface{}, apu, apv []byte) (*ECDH1PUEncrypt, error) {
+	var ephemeral, epk interface{}
+	switch pubkey := pubkey.(type) {
+	case *ecdsa.PublicKey:
+		v, err := ecdsa.GenerateKey(pubkey.Curve, rand.Reader)
+		if err != nil {
+			return nil, fmt.Errorf(`failed to generate ephemeral key: %w`, err)
+		}
+		ephemeral, epk = v, &v.PublicKey
+	case x25519.PublicKey:
+		pub, priv, err := x25519.GenerateKey(rand.Reader)
+		if err != nil {
+			return nil, fmt.Errorf(`failed to generate ephemeral key: %w`, err)
+		}
+		ephemeral, epk = priv, pub
+	case x448.PublicKey:
+		pub, priv, err := x448.GenerateKey(rand.Reader)
+		if err != nil {
+			return nil, fmt.Errorf(`failed to generate ephemeral key: %w`, err)
+		}
+		ephemeral, epk = priv, pub
+	default:
+		return nil, fmt.Errorf("unexpected key type %T", pubkey)
+	}
+
+	// Z = Ze || Zs
+	ze, err := DeriveZ(ephemeral, pubkey)
+	if err != nil {
+		return nil, fmt.Errorf(`unable to determine Ze: %w`, err)
+	}
+	zs, err := DeriveZ(senderkey, pubkey)
+	if err != nil {
+		return nil, fmt.Errorf(`unable to determine Zs: %w`, err)
+	}
+
+	return &ECDH1PUEncrypt{
+		algorithm: alg,
+		enc:       enc,
+		keysize:   keysize,
+		apu:       apu,
+		apv:       apv,
+		z:         append(ze, zs...),
+		epk:       epk,
+	}, nil
+}
+
+// Algorithm returns the key encryption algorithm being used
+func (kw ECDH1PUEncrypt) Algorithm() jwa.KeyEncryptionAlgorithm {
+	return kw.algorithm
+}
+
+func (kw *ECDH1PUEncrypt) SetKeyID(v string) {
+	kw.keyID = v
+}
+
+// KeyID returns the key ID associated with this encrypter
+func (kw ECDH1PUEncrypt) KeyID() string {
+	return kw.keyID
+}
+
+// EncryptKey returns the derived key for ECDH-1PU in direct key agreement
+// mode. For the key wrapping variants, the key cannot be wrapped until the
+// authentication tag of the content encryption is known, so the returned
+// ByteSource only carries the ephemeral public key, and WrapKey must be
+// called once the content has been encrypted.
+func (kw ECDH1PUEncrypt) EncryptKey(_ []byte) (keygen.ByteSource, error) {
+	if kw.algorithm != jwa.ECDH_1PU {
+		return keygen.ByteWithECPublicKey{PublicKey: kw.epk}, nil
+	}
+
+	key, err := derive1PU([]byte(kw.enc.String()), kw.z, kw.apu, kw.apv, uint32(kw.keysize), nil)
+	if err != nil {
+		return nil, fmt.Errorf(`failed to derive ECDH-1PU encryption key: %w`, err)
+	}
+	return keygen.ByteWithECPublicKey{
+		ByteKey:   keygen.ByteKey(key),
+		PublicKey: kw.epk,
+	}, nil
+}
+
+// WrapKey wraps the content encryption key using ECDH-1PU key wrapping.
+// tag is the authentication tag produced by the content encryption.
+func (kw ECDH1PUEncrypt) WrapKey(cek, tag []byte) ([]byte, error) {
+	key, err := derive1PU([]byte(kw.algorithm.String()), kw.z, kw.apu, kw.apv, uint32(kw.keysize), tag)
+	if err != nil {
+		return nil, fmt.Errorf(`failed to derive ECDH-1PU key wrapping key: %w`, err)
+	}
+
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, fmt.Errorf(`failed to generate cipher from derived key: %w`, err)
+	}
+
+	jek, err := Wrap(block, cek)
+	if err != nil {
+		return nil, fmt.Errorf(`failed to wrap data: %w`, err)
+	}
+	return jek, nil
+}
+
+// NewECDH1PUDecrypt creates a new key decrypter using ECDH-1PU. pubkey
+// is the ephemeral public key ("epk"), senderkey is the sender's static
+// public key, and tag is the authentication tag of the JWE message.
+func NewECDH1PUDecrypt(keyalg jwa.KeyEncryptionAlgorithm, contentalg jwa.ContentEncryptionAlgorithm, pubkey, senderkey interface{}, apu, apv, tag []byte, privkey interface{}) *ECDH1PUDecrypt {
+	return &ECDH1PUDecrypt{
+		keyalg:     keyalg,
+		contentalg: contentalg,
+		apu:        apu,
+		apv:        apv,
+		tag:        tag,
+		privkey:    privkey,
+		pubkey:     pubkey,
+		senderkey:  senderkey,
+	}
+}
+
+// Algorithm returns the key encryption algorithm being used
+func (kw ECDH1PUDecrypt) Algorithm() jwa.KeyEncryptionAlgorithm {
+	return kw.keyalg
+}
+
+// Decrypt decrypts the encrypted key using ECDH-1PU
+func (kw ECDH1PUDecrypt) Decrypt(enckey []byte) ([]byte, error) {
+	var keysize uint32
+	var tag []byte
+	algBytes := []byte(kw.keyalg.String())
+
+	switch kw.keyalg {
+	case jwa.ECDH_1PU:
+		c, err := contentcipher.New(kw.contentalg)
+		if err != nil {
+			return nil, fmt.Errorf(`failed to create content cipher for %s: %w`, kw.contentalg, err)
+		}
+		keysize = uint32(c.KeySize())
+		algBytes = []byte(kw.contentalg.String())
+	case jwa.ECDH_1PU_A128KW:
+		keysize = 16
+	case jwa.ECDH_1PU_A192KW:
+		keysize = 24
+	case jwa.ECDH_1PU_A256KW:
+		keysize = 32
+	default:
+		return nil, fmt.Errorf("invalid ECDH-1PU key wrap algorithm (%s)", kw.keyalg)
+	}
+
+	if kw.keyalg != jwa.ECDH_1PU {
+		if !IsAESCBCHMAC(kw.contentalg) {
+			return nil, fmt.Errorf(`%s can only be used with AES-CBC-HMAC content encryption (got %s)`, kw.keyalg, kw.contentalg)
+		}
+		tag = kw.tag
+	}
+
+	ze, err := DeriveZ(kw.privkey, kw.pubkey)
+	if err != nil {
+		return nil, fmt.Errorf(`unable to determine Ze: %w`, err)
+	}
+	zs, err := DeriveZ(kw.privkey, kw.senderkey)
+	if err != nil {
+		return nil, fmt.Errorf(`unable to determine Zs: %w`, err)
+	}
+
+	key, err := derive1PU(algBytes, append(ze, zs...), kw.apu, kw.apv, keysize, tag)
+	if err != nil {
+		return nil, fmt.Errorf(`failed to derive ECDH-1PU encryption key: %w`, err)
+	}
+
+	// ECDH-1PU does not wrap keys
+	if kw.keyalg == jwa.ECDH_1PU {
+		return key, nil
+	}
+
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, fmt.Errorf(`failed to create cipher for ECDH-1PU key wrap: %w`, err)
+	}
+
+	return Unwrap(block, enckey)
+}
+
+// derive1PU derives a key from Z using Concat KDF. For the key wrapping
+// variants, the authentication tag is appended to SuppPubInfo as a
+// length prefixed octet sequence.
+func derive1PU(alg, z, apu, apv []byte, keysize uint32, tag []byte) ([]byte, error) {
+	pubinfo := make([]byte, 4, 8+len(tag))
+	binary.BigEndian.PutUint32(pubinfo, keysize*8)
+	if tag != nil {
+		var taglen [4]byte
+		binary.BigEndian.PutUint32(taglen[:], uint32(len(tag)))
+		pubinfo = append(pubinfo, taglen[:]...)
+		pubinfo = append(pubinfo, tag...)
+	}
+
+	kdf := concatkdf.New(crypto.SHA256, alg, z, apu, apv, pubinfo, []byte{})
+	key := make([]byte, keysize)
+	if _, err := kdf.Read(key); err != nil {
+		return nil, fmt.Errorf(`failed to read kdf: %w`, err)
+	}
+	return key, nil
+}
+
+// IsAESCBCHMAC returns true if the content encryption algorithm is one
+// of the AES-CBC-HMAC algorithms.
+func IsAESCBCHMAC(alg jwa.ContentEncryptionAlgorithm) bool {
+	switch alg {
+	case jwa.A128CBC_HS256, jwa.A192CBC_HS384, jwa.A256CBC_HS512:
+		return true
+	default:
+		return false
+	}
+}
+
+// NewMLKEMEncrypt creates a new key encrypter using ML-KEM. The ciphertext
+// produced by the encapsulation is stored in the "ek" header, and the
+// shared secret is fed to Concat KDF in the same manner as ECDH-ES.
+//
+// This follows draft-ietf-jose-pqc-kem ("Post-Quantum Key Encapsulation
+// Mechanisms (PQ KEMs) for JOSE and COSE"). The algorithm names are the
+// ones registered in the "JSON Web Signature and Encryption Algorithms"
+// table in the "IANA Considerations" section of the draft: MLKEM768 and
+// MLKEM1024 for direct key agreement, and MLKEM768+A192KW and
+// MLKEM1024+A256KW for key wrapping. MLKEM512 and MLKEM512+A128KW are
+// not supported.
+//
+// These algorithms are experimental. As the draft is still subject to
+// change, the output (including the Concat KDF inputs and the placement of
+// the KEM ciphertext in the "ek" header) has not been verified against the
+// draft's test vectors or other implementations.
+func NewMLKEMEncrypt(alg jwa.KeyEncryptionAlgorithm, enc jwa.ContentEncryptionAlgorithm, keysize int, keyif interface{}, apu, apv []byte) (*MLKEMEncrypt, error) {
+	encapsulate, err := mlkemEncapsulator(alg, keyif)
+	if err != nil {
+		return nil, err
+	}
+	return &MLKEMEncrypt{
+		algorithm:   alg,
+		enc:         enc,
+		keysize:     keysize,
+		apu:         apu,
+		apv:         apv,
+		encapsulate: encapsulate,
+	}, nil
+}
+
+// Algorithm returns the key encryption algorithm being used
+func (kw MLKEMEncrypt) Algorithm() jwa.KeyEncryptionAlgorithm {
+	return kw.algorithm
+}
+
+func (kw *MLKEMEncrypt) SetKeyID(v string) {
+	kw.keyID = v
+}
+
+// KeyID returns the key ID associated with this encrypter
+func (kw MLKEMEncrypt) KeyID() string {
+	return kw.keyID
+}
+
+// EncryptKey encrypts the content encryption key using ML-KEM
+func (kw MLKEMEncrypt) EncryptKey(cek []byte) (keygen.ByteSource, error) {
+	shared, ciphertext := kw.encapsulate()
+
+	algorithm := kw.algorithm.String()
+	if IsDirectKeyAgreement(kw.algorithm) {
+		algorithm = kw.enc.String()
+	}
+
+	key, err := deriveConcatKDF([]byte(algorithm), shared, kw.apu, kw.apv, uint32(kw.keysize))
+	if err != nil {
+		return nil, fmt.Errorf(`failed to derive ML-KEM encryption key: %w`, err)
+	}
+
+	if IsDirectKeyAgreement(kw.algorithm) {
+		return keygen.ByteWithEncapsulatedKey{
+			ByteKey:         keygen.ByteKey(key),
+			EncapsulatedKey: ciphertext,
+		}, nil
+	}
+
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, fmt.Errorf(`failed to generate cipher from derived key: %w`, err)
+	}
+
+	jek, err := Wrap(block, cek)
+	if err != nil {
+		return nil, fmt.Errorf(`failed to wrap data: %w`, err)
+	}
+
+	return keygen.ByteWithEncapsulatedKey{
+		ByteKey:         keygen.ByteKey(jek),
+		EncapsulatedKey: ciphertext,
+	}, nil
+}
+
+// NewMLKEMDecrypt creates a new key decrypter using ML-KEM. ciphertext
+// is the value of the "ek" header.
+func NewMLKEMDecrypt(keyalg jwa.KeyEncryptionAlgorithm, contentalg jwa.ContentEncryptionAlgorithm, ciphertext, apu, apv []byte, privkey interface{}) (*MLKEMDecrypt, error) {
+	decapsulate, err := mlkemDecapsulator(keyalg, privkey)
+	if err != nil {
+		return nil, err
+	}
+	return &MLKEMDecrypt{
+		keyalg:      keyalg,
+		contentalg:  contentalg,
+		apu:         apu,
+		apv:         apv,
+		ciphertext:  ciphertext,
+		decapsulate: decapsulate,
+	}, nil
+}
+
+// Algorithm returns the key encryption algorithm being used
+func (kw MLKEMDecrypt) Algorithm() jwa.KeyEncryptionAlgorithm {
+	return kw.keyalg
+}
+
+// Decrypt decrypts the encrypted key using ML-KEM
+func (kw MLKEMDecrypt) Decrypt(enckey []byte) ([]byte, error) {
+	var keysize uint32
+	algBytes := []byte(kw.keyalg.String())
+	switch kw.keyalg {
+	case jwa.MLKEM768, jwa.MLKEM1024:
+		c, err := contentcipher.New(kw.contentalg)
+		if err != nil {
+			return nil, fmt.Errorf(`failed to create content cipher for %s: %w`, kw.contentalg, err)
+		}
+		keysize = uint32(c.KeySize())
+		algBytes = []byte(kw.contentalg.String())
+	case jwa.MLKEM768_A192KW:
+		keysize = 24
+	case jwa.MLKEM1024_A256KW:
+		keysize = 32
+	default:
+		return nil, fmt.Errorf("invalid ML-KEM key encryption algorithm (%s)", kw.keyalg)
+	}
+
+	if len(kw.ciphertext) == 0 {
+		return nil, fmt.Errorf(`missing 'ek' header for %s`, kw.keyalg)
+	}
+
+	shared, err := kw.decapsulate(kw.ciphertext)
+	if err != nil {
+		return nil, fmt.Errorf(`failed to decapsulate shared key: %w`, err)
+	}
+
+	key, err := deriveConcatKDF(algBytes, shared, kw.apu, kw.apv, keysize)
+	if err != nil {
+		return nil, fmt.Errorf(`failed to derive ML-KEM encryption key: %w`, err)
+	}
+
+	if IsDirectKeyAgreement(kw.keyalg) {
+		return key, nil
+	}
+
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, fmt.Errorf(`failed to create cipher for ML-KEM key wrap: %w`, err)
+	}
+
+	return Unwrap(block, enckey)
+}
+
+// IsDirectKeyAgreement returns true if the algorithm derives the content
+// encryption key directly, instead of wrapping a randomly generated one.
+func IsDirectKeyAgreement(alg jwa.KeyEncryptionAlgorithm) bool {
+	switch alg {
+	case jwa.ECDH_ES, jwa.ECDH_1PU, jwa.MLKEM768, jwa.MLKEM1024:
+		return true
+	default:
+		return false
+	}
+}
+
+// IsHPKEIntegrated returns true if the algorithm uses HPKE to encrypt
+// the payload directly, without a content encryption key.
+func IsHPKEIntegrated(alg jwa.KeyEncryptionAlgorithm) bool {
+	switch alg {
+	case jwa.HPKE_0, jwa.HPKE_3, jwa.HPKE_4:
+		return true
+	default:
+		return false
+	}
+}
+
 // NewRSAOAEPEncrypt creates a new key encrypter using RSA OAEP
 func NewRSAOAEPEncrypt(alg jwa.KeyEncryptionAlgorithm, pubkey *rsa.PublicKey) (*RSAOAEPEncrypt, error) {
 	switch alg {
-	case jwa.RSA_OAEP, jwa.RSA_OAEP_256:
+	case jwa.RSA_OAEP, jwa.RSA_OAEP_256, jwa.RSA_OAEP_384, jwa.RSA_OAEP_512:
 	default:
 		return nil, fmt.Errorf("invalid RSA OAEP encrypt algorithm (%s)", alg)
 	}
@@ -462,8 +936,12 @@ func (e RSAOAEPEncrypt) EncryptKey(cek []byte) (keygen.ByteSource, error) {
 		hash = sha1.New()
 	case jwa.RSA_OAEP_256:
 		hash = sha256.New()
+	case jwa.RSA_OAEP_384:
+		hash = sha512.New384()
+	case jwa.RSA_OAEP_512:
+		hash = sha512.New()
 	default:
-		return nil, fmt.Errorf(`failed to generate key encrypter for RSA-OAEP: RSA_OAEP/RSA_OAEP_256 required`)
+		return nil, fmt.Errorf(`failed to generate key encrypter for RSA-OAEP: RSA_OAEP/RSA_OAEP_256/RSA_OAEP_384/RSA_OAEP_512 required`)
 	}
 	encrypted, err := rsa.EncryptOAEP(hash, rand.Reader, e.pubkey, cek, []byte{})
 	if err != nil {
@@ -536,7 +1014,7 @@ func (d RSAPKCS15Decrypt) Decrypt(enckey []byte) ([]byte, error) {
 // NewRSAOAEPDecrypt creates a new key decrypter using RSA OAEP
 func NewRSAOAEPDecrypt(alg jwa.KeyEncryptionAlgorithm, privkey *rsa.PrivateKey) (*RSAOAEPDecrypt, error) {
 	switch alg {
-	case jwa.RSA_OAEP, jwa.RSA_OAEP_256:
+	case jwa.RSA_OAEP, jwa.RSA_OAEP_256, jwa.RSA_OAEP_384, jwa.RSA_OAEP_512:
 	default:
 		return nil, fmt.Errorf("invalid RSA OAEP decrypt algorithm (%s)", alg)
 	}
@@ -560,8 +1038,12 @@ func (d RSAOAEPDecrypt) Decrypt(enckey []byte) ([]byte, error) {
 		hash = sha1.New()
 	case jwa.RSA_OAEP_256:
 		hash = sha256.New()
+	case jwa.RSA_OAEP_384:
+		hash = sha512.New384()
+	case jwa.RSA_OAEP_512:
+		hash = sha512.New()
 	default:
-		return nil, fmt.Errorf(`failed to generate key encrypter for RSA-OAEP: RSA_OAEP/RSA_OAEP_256 required`)
+		return nil, fmt.Errorf(`failed to generate key encrypter for RSA-OAEP: RSA_OAEP/RSA_OAEP_256/RSA_OAEP_384/RSA_OAEP_512 required`)
 	}
 	return rsa.DecryptOAEP(hash, rand.Reader, d.privkey, enckey, []byte{})
 }
@@ -0,0 +1,60 @@
+//go:build go1.24
+// +build go1.24
+
+package keyenc
+
+import (
+	"crypto/mlkem"
+	"fmt"
+
+	"github.com/lestrrat-go/jwx/v2/jwa"
+)
+
+// mlkemEncapsulator returns the encapsulation function for the given
+// ML-KEM algorithm. key may be either the encapsulation key, or the
+// decapsulation key from which the encapsulation key can be derived.
+func mlkemEncapsulator(alg jwa.KeyEncryptionAlgorithm, key interface{}) (func() ([]byte, []byte), error) {
+	switch alg {
+	case jwa.MLKEM768, jwa.MLKEM768_A192KW:
+		switch key := key.(type) {
+		case *mlkem.EncapsulationKey768:
+			return key.Encapsulate, nil
+		case *mlkem.DecapsulationKey768:
+			return key.EncapsulationKey().Encapsulate, nil
+		default:
+			return nil, fmt.Errorf(`*mlkem.EncapsulationKey768 is required for %s (got %T)`, alg, key)
+		}
+	case jwa.MLKEM1024, jwa.MLKEM1024_A256KW:
+		switch key := key.(type) {
+		case *mlkem.EncapsulationKey1024:
+			return key.Encapsulate, nil
+		case *mlkem.DecapsulationKey1024:
+			return key.EncapsulationKey().Encapsulate, nil
+		default:
+			return nil, fmt.Errorf(`*mlkem.EncapsulationKey1024 is required for %s (got %T)`, alg, key)
+		}
+	default:
+		return nil, fmt.Errorf(`invalid ML-KEM key encryption algorithm (%s)`, alg)
+	}
+}
+
+// mlkemDecapsulator returns the decapsulation function for the given
+// ML-KEM algorithm.
+func mlkemDecapsulator(alg jwa.KeyEncryptionAlgorithm, key interface{}) (func([]byte) ([]byte, error), error) {
+	switch alg {
+	case jwa.MLKEM768, jwa.MLKEM768_A192KW:
+		dk, ok := key.(*mlkem.DecapsulationKey768)
+		if !ok {
+			return nil, fmt.Errorf(`*mlkem.DecapsulationKey768 is required for %s (got %T)`, alg, key)
+		}
+		return dk.Decapsulate, nil
+	case jwa.MLKEM1024, jwa.MLKEM1024_A256KW:
+		dk, ok := key.(*mlkem.DecapsulationKey1024)
+		if !ok {
+			return nil, fmt.Errorf(`*mlkem.DecapsulationKey1024 is required for %s (got %T)`, alg, key)
+		}
+		return dk.Decapsulate, nil
+	default:
+		return nil, fmt.Errorf(`invalid ML-KEM key encryption algorithm (%s)`, alg)
+	}
+}
@@ -0,0 +1,18 @@
+//go:build !go1.24
+// +build !go1.24
+
+package keyenc
+
+import (
+	"fmt"
+
+	"github.com/lestrrat-go/jwx/v2/jwa"
+)
+
+func mlkemEncapsulator(alg jwa.KeyEncryptionAlgorithm, _ interface{}) (func() ([]byte, []byte), error) {
+	return nil, fmt.Errorf(`%s requires Go 1.24 or later`, alg)
+}
+
+func mlkemDecapsulator(alg jwa.KeyEncryptionAlgorithm, _ interface{}) (func([]byte) ([]byte, error), error) {
+	return nil, fmt.Errorf(`%s requires Go 1.24 or later`, alg)
+}
@@ -5,6 +5,7 @@ import (
 
 	"github.com/lestrrat-go/jwx/v2/jwa"
 	"github.com/lestrrat-go/jwx/v2/x25519"
+	"github.com/lestrrat-go/jwx/v2/x448"
 )
 
 type Generator interface {
@@ -35,6 +36,16 @@ type X25519 struct {
 	pubkey    x25519.PublicKey
 }
 
+// X448 generates keys using ECDH-ES algorithm / X448 curve
+type X448 struct {
+	algorithm jwa.KeyEncryptionAlgorithm
+	enc       jwa.ContentEncryptionAlgorithm
+	keysize   int
+	pubkey    x448.PublicKey
+	apu       []byte
+	apv       []byte
+}
+
 // ByteKey is a generated key that only has the key's byte buffer
 // as its instance data. If a key needs to do more, such as providing
 // values to be set in a JWE header, that key type wraps a ByteKey
@@ -48,6 +59,14 @@ type ByteWithECPublicKey struct {
 	PublicKey interface{}
 }
 
+// ByteWithEncapsulatedKey holds the ciphertext produced by a KEM
+// along with the key itself. This is required to set the "ek" value
+// in the JWE headers
+type ByteWithEncapsulatedKey struct {
+	ByteKey
+	EncapsulatedKey []byte
+}
+
 type ByteWithIVAndTag struct {
 	ByteKey
 	IV  []byte
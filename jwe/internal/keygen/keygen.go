@@ -15,6 +15,7 @@ import (
 	"github.com/lestrrat-go/jwx/v2/jwe/internal/concatkdf"
 	"github.com/lestrrat-go/jwx/v2/jwk"
 	"github.com/lestrrat-go/jwx/v2/x25519"
+	"github.com/lestrrat-go/jwx/v2/x448"
 )
 
 // Bytes returns the byte from this ByteKey
@@ -142,6 +143,56 @@ func (g X25519) Generate() (ByteSource, error) {
 	}, nil
 }
 
+// NewX448 creates a new key generator using ECDH-ES
+func NewX448(alg jwa.KeyEncryptionAlgorithm, enc jwa.ContentEncryptionAlgorithm, keysize int, pubkey x448.PublicKey, apu, apv []byte) (*X448, error) {
+	return &X448{
+		algorithm: alg,
+		enc:       enc,
+		keysize:   keysize,
+		pubkey:    pubkey,
+		apu:       apu,
+		apv:       apv,
+	}, nil
+}
+
+// Size returns the key size associated with this generator
+func (g X448) Size() int {
+	return g.keysize
+}
+
+// Generate generates new keys using ECDH-ES
+func (g X448) Generate() (ByteSource, error) {
+	pub, priv, err := x448.GenerateKey(rand.Reader)
+	if err != nil {
+		return nil, fmt.Errorf(`failed to generate key for X448: %w`, err)
+	}
+
+	var algorithm string
+	if g.algorithm == jwa.ECDH_ES {
+		algorithm = g.enc.String()
+	} else {
+		algorithm = g.algorithm.String()
+	}
+
+	pubinfo := make([]byte, 4)
+	binary.BigEndian.PutUint32(pubinfo, uint32(g.keysize)*8)
+
+	zBytes, err := x448.X448(priv.Seed(), g.pubkey)
+	if err != nil {
+		return nil, fmt.Errorf(`failed to compute Z: %w`, err)
+	}
+	kdf := concatkdf.New(crypto.SHA256, []byte(algorithm), zBytes, g.apu, g.apv, pubinfo, []byte{})
+	kek := make([]byte, g.keysize)
+	if _, err := kdf.Read(kek); err != nil {
+		return nil, fmt.Errorf(`failed to read kdf: %w`, err)
+	}
+
+	return ByteWithECPublicKey{
+		PublicKey: pub,
+		ByteKey:   ByteKey(kek),
+	}, nil
+}
+
 // HeaderPopulate populates the header with the required EC-DSA public key
 // information ('epk' key)
 func (k ByteWithECPublicKey) Populate(h Setter) error {
@@ -156,6 +207,15 @@ func (k ByteWithECPublicKey) Populate(h Setter) error {
 	return nil
 }
 
+// HeaderPopulate populates the header with the KEM ciphertext
+// ('ek' key)
+func (k ByteWithEncapsulatedKey) Populate(h Setter) error {
+	if err := h.Set("ek", k.EncapsulatedKey); err != nil {
+		return fmt.Errorf(`failed to write header: %w`, err)
+	}
+	return nil
+}
+
 // HeaderPopulate populates the header with the required AES GCM
 // parameters ('iv' and 'tag')
 func (k ByteWithIVAndTag) Populate(h Setter) error {
@@ -6,13 +6,17 @@ package jwe
 import (
 	"bytes"
 	"context"
+	"crypto"
 	"crypto/ecdsa"
+	"crypto/elliptic"
 	"crypto/rsa"
 	"fmt"
 	"io"
 
 	"github.com/lestrrat-go/blackmagic"
+	"github.com/lestrrat-go/jwx/v2"
 	"github.com/lestrrat-go/jwx/v2/internal/base64"
+	"github.com/lestrrat-go/jwx/v2/internal/ecutil"
 	"github.com/lestrrat-go/jwx/v2/internal/json"
 	"github.com/lestrrat-go/jwx/v2/internal/keyconv"
 	"github.com/lestrrat-go/jwx/v2/jwk"
@@ -22,6 +26,7 @@ import (
 	"github.com/lestrrat-go/jwx/v2/jwe/internal/keyenc"
 	"github.com/lestrrat-go/jwx/v2/jwe/internal/keygen"
 	"github.com/lestrrat-go/jwx/v2/x25519"
+	"github.com/lestrrat-go/jwx/v2/x448"
 )
 
 const (
@@ -57,6 +62,14 @@ type recipientBuilder struct {
 	alg     jwa.KeyEncryptionAlgorithm
 	key     interface{}
 	headers Headers
+	// seal is populated by Build() when alg is an HPKE integrated
+	// encryption algorithm, and is used to encrypt the payload
+	seal func(aad, plaintext []byte) ([]byte, error)
+	// senderKey is the sender's static private key for ECDH-1PU
+	senderKey interface{}
+	// wrap is populated by Build() for algorithms that can only compute
+	// the encrypted key after the content has been encrypted
+	wrap func(tag []byte) ([]byte, error)
 }
 
 func (b *recipientBuilder) Build(cek []byte, calg jwa.ContentEncryptionAlgorithm, cc *content_crypt.Generic) (Recipient, []byte, error) {
@@ -66,10 +79,26 @@ func (b *recipientBuilder) Build(cek []byte, calg jwa.ContentEncryptionAlgorithm
 	rawKey := b.key
 
 	var keyID string
-	if ke, ok := b.key.(KeyEncrypter); ok {
+	ke, ok := b.key.(KeyEncrypter)
+	if !ok {
+		if f, registered := lookupKeyEncrypterFactory(b.alg); registered {
+			v, err := f.Create(b.key)
+			if err != nil {
+				return nil, nil, fmt.Errorf(`failed to create key encrypter for %s: %w`, b.alg, err)
+			}
+			ke = v
+			if jwkKey, ok := b.key.(jwk.Key); ok {
+				keyID = jwkKey.KeyID()
+			}
+		}
+	}
+
+	if ke != nil {
 		enc = &keyEncrypterWrapper{encrypter: ke}
-		if kider, ok := enc.(KeyIDer); ok {
-			keyID = kider.KeyID()
+		if kider, ok := ke.(KeyIDer); ok {
+			if v := kider.KeyID(); v != "" {
+				keyID = v
+			}
 		}
 	} else if jwkKey, ok := b.key.(jwk.Key); ok {
 		// Meanwhile, grab the kid as well
@@ -96,7 +125,7 @@ func (b *recipientBuilder) Build(cek []byte, calg jwa.ContentEncryptionAlgorithm
 				return nil, nil, fmt.Errorf(`failed to create RSA PKCS encrypter: %w`, err)
 			}
 			enc = v
-		case jwa.RSA_OAEP, jwa.RSA_OAEP_256:
+		case jwa.RSA_OAEP, jwa.RSA_OAEP_256, jwa.RSA_OAEP_384, jwa.RSA_OAEP_512:
 			var pubkey rsa.PublicKey
 			if err := keyconv.RSAPublicKey(&pubkey, rawKey); err != nil {
 				return nil, nil, fmt.Errorf(`failed to generate public key from key (%T): %w`, rawKey, err)
@@ -109,6 +138,7 @@ func (b *recipientBuilder) Build(cek []byte, calg jwa.ContentEncryptionAlgorithm
 			enc = v
 		case jwa.A128KW, jwa.A192KW, jwa.A256KW,
 			jwa.A128GCMKW, jwa.A192GCMKW, jwa.A256GCMKW,
+			jwa.C20PKW, jwa.XC20PKW,
 			jwa.PBES2_HS256_A128KW, jwa.PBES2_HS384_A192KW, jwa.PBES2_HS512_A256KW:
 			sharedkey, ok := rawKey.([]byte)
 			if !ok {
@@ -121,6 +151,8 @@ func (b *recipientBuilder) Build(cek []byte, calg jwa.ContentEncryptionAlgorithm
 				enc, err = keyenc.NewAES(b.alg, sharedkey)
 			case jwa.PBES2_HS256_A128KW, jwa.PBES2_HS384_A192KW, jwa.PBES2_HS512_A256KW:
 				enc, err = keyenc.NewPBES2Encrypt(b.alg, sharedkey)
+			case jwa.C20PKW, jwa.XC20PKW:
+				enc, err = keyenc.NewChaCha20Poly1305Encrypt(b.alg, sharedkey)
 			default:
 				enc, err = keyenc.NewAESGCMEncrypt(b.alg, sharedkey)
 			}
@@ -148,7 +180,7 @@ func (b *recipientBuilder) Build(cek []byte, calg jwa.ContentEncryptionAlgorithm
 			}
 
 			switch key := rawKey.(type) {
-			case x25519.PublicKey:
+			case x25519.PublicKey, x448.PublicKey:
 				var apu, apv []byte
 				if hdrs := b.headers; hdrs != nil {
 					apu = hdrs.AgreementPartyUInfo()
@@ -178,6 +210,111 @@ func (b *recipientBuilder) Build(cek []byte, calg jwa.ContentEncryptionAlgorithm
 				}
 				enc = v
 			}
+		case jwa.ECDH_1PU, jwa.ECDH_1PU_A128KW, jwa.ECDH_1PU_A192KW, jwa.ECDH_1PU_A256KW:
+			if b.senderKey == nil {
+				return nil, nil, fmt.Errorf(`sender key must be specified via jwe.WithSenderKey() for %s`, b.alg)
+			}
+
+			var keysize int
+			switch b.alg {
+			case jwa.ECDH_1PU:
+				keysize = cc.KeySize()
+			case jwa.ECDH_1PU_A128KW:
+				keysize = 16
+			case jwa.ECDH_1PU_A192KW:
+				keysize = 24
+			case jwa.ECDH_1PU_A256KW:
+				keysize = 32
+			}
+
+			// The key wrapping variants include the authentication tag in the
+			// key derivation, which is only well defined for AES-CBC-HMAC
+			if b.alg != jwa.ECDH_1PU && !keyenc.IsAESCBCHMAC(calg) {
+				return nil, nil, fmt.Errorf(`%s can only be used with AES-CBC-HMAC content encryption (got %s)`, b.alg, calg)
+			}
+
+			senderKey := b.senderKey
+			if jwkKey, ok := senderKey.(jwk.Key); ok {
+				var raw interface{}
+				if err := jwkKey.Raw(&raw); err != nil {
+					return nil, nil, fmt.Errorf(`failed to retrieve raw key out of sender key %T: %w`, senderKey, err)
+				}
+				senderKey = raw
+			}
+
+			var pubkey interface{}
+			switch key := rawKey.(type) {
+			case x25519.PublicKey, x448.PublicKey:
+				pubkey = key
+			default:
+				var ecdsaPubkey ecdsa.PublicKey
+				if err := keyconv.ECDSAPublicKey(&ecdsaPubkey, rawKey); err != nil {
+					return nil, nil, fmt.Errorf(`failed to generate public key from key (%T): %w`, key, err)
+				}
+				pubkey = &ecdsaPubkey
+
+				var ecdsaPrivkey ecdsa.PrivateKey
+				if err := keyconv.ECDSAPrivateKey(&ecdsaPrivkey, senderKey); err != nil {
+					return nil, nil, fmt.Errorf(`failed to generate private key from sender key (%T): %w`, senderKey, err)
+				}
+				senderKey = &ecdsaPrivkey
+			}
+
+			var apu, apv []byte
+			if hdrs := b.headers; hdrs != nil {
+				apu = hdrs.AgreementPartyUInfo()
+				apv = hdrs.AgreementPartyVInfo()
+			}
+
+			v, err := keyenc.NewECDH1PUEncrypt(b.alg, calg, keysize, pubkey, senderKey, apu, apv)
+			if err != nil {
+				return nil, nil, fmt.Errorf(`failed to create ECDH-1PU key encrypter: %w`, err)
+			}
+			enc = v
+
+			if b.alg != jwa.ECDH_1PU {
+				b.wrap = func(tag []byte) ([]byte, error) {
+					return v.WrapKey(cek, tag)
+				}
+			}
+		case jwa.MLKEM768, jwa.MLKEM1024, jwa.MLKEM768_A192KW, jwa.MLKEM1024_A256KW:
+			var keysize int
+			switch b.alg {
+			case jwa.MLKEM768, jwa.MLKEM1024:
+				// Same as ECDH-ES, the derived key is used as the CEK
+				keysize = cc.KeySize()
+			case jwa.MLKEM768_A192KW:
+				keysize = 24
+			case jwa.MLKEM1024_A256KW:
+				keysize = 32
+			}
+
+			var apu, apv []byte
+			if hdrs := b.headers; hdrs != nil {
+				apu = hdrs.AgreementPartyUInfo()
+				apv = hdrs.AgreementPartyVInfo()
+			}
+
+			v, err := keyenc.NewMLKEMEncrypt(b.alg, calg, keysize, rawKey, apu, apv)
+			if err != nil {
+				return nil, nil, fmt.Errorf(`failed to create ML-KEM key encrypter: %w`, err)
+			}
+			enc = v
+		case jwa.HPKE_0_KE, jwa.HPKE_3_KE, jwa.HPKE_4_KE:
+			v, err := keyenc.NewHPKEEncrypt(b.alg, calg, rawKey)
+			if err != nil {
+				return nil, nil, fmt.Errorf(`failed to create HPKE key encrypter: %w`, err)
+			}
+			enc = v
+		case jwa.HPKE_0, jwa.HPKE_3, jwa.HPKE_4:
+			ek, seal, err := keyenc.NewHPKESender(b.alg, rawKey)
+			if err != nil {
+				return nil, nil, fmt.Errorf(`failed to create HPKE sender: %w`, err)
+			}
+			b.seal = seal
+			// There is no content encryption key to encrypt, but we still
+			// need to populate the "ek" header
+			enc = &hpkeIntegratedEncrypter{alg: b.alg, ek: ek}
 		case jwa.DIRECT:
 			sharedkey, ok := rawKey.([]byte)
 			if !ok {
@@ -209,7 +346,7 @@ func (b *recipientBuilder) Build(cek []byte, calg jwa.ContentEncryptionAlgorithm
 	if err != nil {
 		return nil, nil, fmt.Errorf(`failed to encrypt key: %w`, err)
 	}
-	if enc.Algorithm() == jwa.ECDH_ES || enc.Algorithm() == jwa.DIRECT {
+	if enc.Algorithm() == jwa.DIRECT || keyenc.IsDirectKeyAgreement(enc.Algorithm()) {
 		rawCEK = enckey.Bytes()
 	} else {
 		if err := r.SetEncryptedKey(enckey.Bytes()); err != nil {
@@ -262,9 +399,14 @@ func Encrypt(payload []byte, options ...EncryptOption) ([]byte, error) {
 	var protected Headers
 	var mergeProtected bool
 	var useRawCEK bool
+	var integrated bool
+	var senderKey interface{}
+	policy := jwx.DefaultPolicy()
 	for _, option := range options {
 		//nolint:forcetypeassert
 		switch option.Ident() {
+		case identPolicy{}:
+			policy = option.Value().(*jwx.Policy)
 		case identKey{}:
 			data := option.Value().(*withKey)
 			v, ok := data.alg.(jwa.KeyEncryptionAlgorithm)
@@ -272,16 +414,20 @@ func Encrypt(payload []byte, options ...EncryptOption) ([]byte, error) {
 				return nil, fmt.Errorf(`jwe.Encrypt: expected alg to be jwa.KeyEncryptionAlgorithm, but got %T`, data.alg)
 			}
 
-			switch v {
-			case jwa.DIRECT, jwa.ECDH_ES:
+			if v == jwa.DIRECT || keyenc.IsDirectKeyAgreement(v) {
 				useRawCEK = true
 			}
+			if keyenc.IsHPKEIntegrated(v) {
+				integrated = true
+			}
 
 			builders = append(builders, &recipientBuilder{
 				alg:     v,
 				key:     data.key,
 				headers: data.headers,
 			})
+		case identSenderKey{}:
+			senderKey = option.Value()
 		case identContentEncryptionAlgorithm{}:
 			calg = option.Value().(jwa.ContentEncryptionAlgorithm)
 		case identCompress{}:
@@ -321,6 +467,24 @@ func Encrypt(payload []byte, options ...EncryptOption) ([]byte, error) {
 		}
 	}
 
+	for _, builder := range builders {
+		if err := policy.CheckKeyEncryption(builder.alg, builder.key); err != nil {
+			return nil, fmt.Errorf(`jwe.Encrypt: %w`, err)
+		}
+		builder.senderKey = senderKey
+	}
+
+	if integrated {
+		if len(builders) != 1 {
+			return nil, fmt.Errorf(`jwe.Encrypt: multiple recipients for HPKE integrated encryption not supported`)
+		}
+		return encryptHPKEIntegrated(payload, builders[0], protected, compression, format)
+	}
+
+	if err := policy.CheckContentEncryption(calg); err != nil {
+		return nil, fmt.Errorf(`jwe.Encrypt: %w`, err)
+	}
+
 	// There is exactly one content encrypter.
 	contentcrypt, err := content_crypt.NewGeneric(calg)
 	if err != nil {
@@ -354,6 +518,14 @@ func Encrypt(payload []byte, options ...EncryptOption) ([]byte, error) {
 		protected = NewHeaders()
 	}
 
+	if jwkKey, ok := senderKey.(jwk.Key); ok {
+		if kid := jwkKey.KeyID(); kid != "" && protected.SenderKeyID() == "" {
+			if err := protected.Set(SenderKeyIDKey, kid); err != nil {
+				return nil, fmt.Errorf(`jwe.Encrypt: failed to set "skid" in protected header: %w`, err)
+			}
+		}
+	}
+
 	if err := protected.Set(ContentEncryptionKey, calg); err != nil {
 		return nil, fmt.Errorf(`jwe.Encrypt: failed to set "enc" in protected header: %w`, err)
 	}
@@ -388,6 +560,19 @@ func Encrypt(payload []byte, options ...EncryptOption) ([]byte, error) {
 		return nil, fmt.Errorf(`failed to encrypt payload: %w`, err)
 	}
 
+	for i, builder := range builders {
+		if builder.wrap == nil {
+			continue
+		}
+		enckey, err := builder.wrap(tag)
+		if err != nil {
+			return nil, fmt.Errorf(`jwe.Encrypt: failed to encrypt key for recipient #%d: %w`, i, err)
+		}
+		if err := recipients[i].SetEncryptedKey(enckey); err != nil {
+			return nil, fmt.Errorf(`jwe.Encrypt: failed to set encrypted key for recipient #%d: %w`, i, err)
+		}
+	}
+
 	msg := NewMessage()
 
 	if err := msg.Set(CipherTextKey, ciphertext); err != nil {
@@ -406,6 +591,10 @@ func Encrypt(payload []byte, options ...EncryptOption) ([]byte, error) {
 		return nil, fmt.Errorf(`failed to set %s: %w`, TagKey, err)
 	}
 
+	return serializeMessage(msg, format)
+}
+
+func serializeMessage(msg *Message, format int) ([]byte, error) {
 	switch format {
 	case fmtCompact:
 		return Compact(msg)
@@ -418,12 +607,85 @@ func Encrypt(payload []byte, options ...EncryptOption) ([]byte, error) {
 	}
 }
 
+// hpkeIntegratedEncrypter is a placeholder keyenc.Encrypter used for HPKE
+// integrated encryption. No content encryption key is involved, so the
+// only thing it does is to provide the "ek" header value.
+type hpkeIntegratedEncrypter struct {
+	alg jwa.KeyEncryptionAlgorithm
+	ek  []byte
+}
+
+func (e *hpkeIntegratedEncrypter) Algorithm() jwa.KeyEncryptionAlgorithm {
+	return e.alg
+}
+
+func (e *hpkeIntegratedEncrypter) EncryptKey(_ []byte) (keygen.ByteSource, error) {
+	return keygen.ByteWithEncapsulatedKey{EncapsulatedKey: e.ek}, nil
+}
+
+// encryptHPKEIntegrated encrypts the payload using HPKE integrated
+// encryption. The payload is encrypted directly by HPKE using the
+// protected header as the additional authenticated data, so the
+// message has no "enc" header, encrypted key, initialization vector,
+// nor authentication tag.
+func encryptHPKEIntegrated(payload []byte, builder *recipientBuilder, protected Headers, compression jwa.CompressionAlgorithm, format int) ([]byte, error) {
+	r, _, err := builder.Build(nil, "", nil)
+	if err != nil {
+		return nil, fmt.Errorf(`jwe.Encrypt: failed to create recipient: %w`, err)
+	}
+
+	if protected == nil {
+		protected = NewHeaders()
+	}
+
+	if compression != jwa.NoCompress {
+		payload, err = compress(payload)
+		if err != nil {
+			return nil, fmt.Errorf(`jwe.Encrypt: failed to compress payload before encryption: %w`, err)
+		}
+		if err := protected.Set(CompressionKey, compression); err != nil {
+			return nil, fmt.Errorf(`jwe.Encrypt: failed to set "zip" in protected header: %w`, err)
+		}
+	}
+
+	protected, err = protected.Merge(context.TODO(), r.Headers())
+	if err != nil {
+		return nil, fmt.Errorf(`jwe.Encrypt: failed to merge protected headers: %w`, err)
+	}
+
+	aad, err := protected.Encode()
+	if err != nil {
+		return nil, fmt.Errorf(`failed to base64 encode protected headers: %w`, err)
+	}
+
+	ciphertext, err := builder.seal(aad, payload)
+	if err != nil {
+		return nil, fmt.Errorf(`failed to encrypt payload: %w`, err)
+	}
+
+	msg := NewMessage()
+	if err := msg.Set(CipherTextKey, ciphertext); err != nil {
+		return nil, fmt.Errorf(`failed to set %s: %w`, CipherTextKey, err)
+	}
+	if err := msg.Set(ProtectedHeadersKey, protected); err != nil {
+		return nil, fmt.Errorf(`failed to set %s: %w`, ProtectedHeadersKey, err)
+	}
+	if err := msg.Set(RecipientsKey, []Recipient{r}); err != nil {
+		return nil, fmt.Errorf(`failed to set %s: %w`, RecipientsKey, err)
+	}
+
+	return serializeMessage(msg, format)
+}
+
 type decryptCtx struct {
-	msg              *Message
-	aad              []byte
-	computedAad      []byte
-	keyProviders     []KeyProvider
-	protectedHeaders Headers
+	msg                *Message
+	aad                []byte
+	computedAad        []byte
+	keyProviders       []KeyProvider
+	senderKeyProviders []SenderKeyProvider
+	protectedHeaders   Headers
+	policy             *jwx.Policy
+	limits             decryptLimits
 }
 
 // Decrypt takes the key encryption algorithm and the corresponding
@@ -437,18 +699,36 @@ type decryptCtx struct {
 // `key` must be a private key. It can be either in its raw format (e.g. *rsa.PrivateKey) or a jwk.Key
 func Decrypt(buf []byte, options ...DecryptOption) ([]byte, error) {
 	var keyProviders []KeyProvider
+	var senderKeyProviders []SenderKeyProvider
 	var keyUsed interface{}
+	var understood []string
+	policy := jwx.DefaultPolicy()
+	limits := defaultDecryptLimits()
 
 	var dst *Message
 	//nolint:forcetypeassert
 	for _, option := range options {
 		switch option.Ident() {
+		case identPolicy{}:
+			policy = option.Value().(*jwx.Policy)
+		case identCriticalHeaders{}:
+			understood = append(understood, option.Value().([]string)...)
+		case identMaxPBES2Count{}:
+			limits.maxPBES2Count = int64(option.Value().(int))
+		case identMaxDecompressedSize{}:
+			limits.maxDecompressedSize = option.Value().(int64)
+		case identMaxRecipients{}:
+			limits.maxRecipients = int64(option.Value().(int))
 		case identMessage{}:
 			dst = option.Value().(*Message)
 		case identKeyProvider{}:
 			keyProviders = append(keyProviders, option.Value().(KeyProvider))
 		case identKeyUsed{}:
 			keyUsed = option.Value()
+		case identSenderKey{}:
+			senderKeyProviders = append(senderKeyProviders, &staticSenderKeyProvider{key: option.Value()})
+		case identSenderKeyProvider{}:
+			senderKeyProviders = append(senderKeyProviders, option.Value().(SenderKeyProvider))
 		case identKey{}:
 			pair := option.Value().(*withKey)
 			alg, ok := pair.alg.(jwa.KeyEncryptionAlgorithm)
@@ -466,11 +746,19 @@ func Decrypt(buf []byte, options ...DecryptOption) ([]byte, error) {
 		return nil, fmt.Errorf(`jwe.Decrypt: no key providers have been provided (see jwe.WithKey(), jwe.WithKeySet(), and jwe.WithKeyProvider()`)
 	}
 
-	msg, err := parseJSONOrCompact(buf, true)
+	msg, err := parseJSONOrCompact(buf)
 	if err != nil {
 		return nil, fmt.Errorf(`failed to parse buffer for Decrypt: %w`, err)
 	}
 
+	if max := limits.maxRecipients; max > 0 && int64(len(msg.recipients)) > max {
+		return nil, fmt.Errorf(`jwe.Decrypt: %w (%d > %d)`, errMaxRecipientsExceeded, len(msg.recipients), max)
+	}
+
+	if err := msg.checkCritical(understood); err != nil {
+		return nil, fmt.Errorf(`jwe.Decrypt: %w`, err)
+	}
+
 	// Process things that are common to the message
 	ctx := context.TODO()
 	h, err := msg.protectedHeaders.Clone(ctx)
@@ -482,21 +770,21 @@ func Decrypt(buf []byte, options ...DecryptOption) ([]byte, error) {
 		return nil, fmt.Errorf(`failed to merge headers for message decryption: %w`, err)
 	}
 
+	// HPKE integrated encryption does not use a content encryption algorithm
+	if calg := h.ContentEncryption(); calg != "" {
+		if err := policy.CheckContentEncryption(calg); err != nil {
+			return nil, fmt.Errorf(`jwe.Decrypt: %w`, err)
+		}
+	}
+
 	var aad []byte
 	if aadContainer := msg.authenticatedData; aadContainer != nil {
 		aad = base64.Encode(aadContainer)
 	}
 
-	var computedAad []byte
-	if len(msg.rawProtectedHeaders) > 0 {
-		computedAad = msg.rawProtectedHeaders
-	} else {
-		// this is probably not required once msg.Decrypt is deprecated
-		var err error
-		computedAad, err = msg.protectedHeaders.Encode()
-		if err != nil {
-			return nil, fmt.Errorf(`failed to encode protected headers: %w`, err)
-		}
+	computedAad, err := msg.encodeProtectedHeaders(msg.protectedHeaders)
+	if err != nil {
+		return nil, fmt.Errorf(`failed to encode protected headers: %w`, err)
 	}
 
 	// for each recipient, attempt to match the key providers
@@ -516,7 +804,10 @@ func Decrypt(buf []byte, options ...DecryptOption) ([]byte, error) {
 	dctx.computedAad = computedAad
 	dctx.msg = msg
 	dctx.keyProviders = keyProviders
+	dctx.senderKeyProviders = senderKeyProviders
 	dctx.protectedHeaders = h
+	dctx.policy = policy
+	dctx.limits = limits
 
 	var lastError error
 	for _, recipient := range recipients {
@@ -527,8 +818,6 @@ func Decrypt(buf []byte, options ...DecryptOption) ([]byte, error) {
 		}
 		if dst != nil {
 			*dst = *msg
-			dst.rawProtectedHeaders = nil
-			dst.storeProtectedHeaders = false
 		}
 		return decrypted, nil
 	}
@@ -553,6 +842,11 @@ func (dctx *decryptCtx) try(ctx context.Context, recipient Recipient, keyUsed in
 			alg := pair.alg.(jwa.KeyEncryptionAlgorithm)
 			key := pair.key
 
+			if err := dctx.policy.CheckKeyEncryption(alg, key); err != nil {
+				lastError = err
+				continue
+			}
+
 			decrypted, err := dctx.decryptContent(ctx, alg, key, recipient)
 			if err != nil {
 				lastError = err
@@ -567,10 +861,20 @@ func (dctx *decryptCtx) try(ctx context.Context, recipient Recipient, keyUsed in
 			return decrypted, nil
 		}
 	}
-	return nil, fmt.Errorf(`jwe.Decrypt: tried %d keys, but failed to match any of the keys with recipient (last error = %s)`, tried, lastError)
+	return nil, fmt.Errorf(`jwe.Decrypt: tried %d keys, but failed to match any of the keys with recipient (last error = %w)`, tried, lastError)
 }
 
 func (dctx *decryptCtx) decryptContent(ctx context.Context, alg jwa.KeyEncryptionAlgorithm, key interface{}, recipient Recipient) ([]byte, error) {
+	if _, ok := key.(KeyDecrypter); !ok {
+		if f, registered := lookupKeyDecrypterFactory(alg); registered {
+			kd, err := f.Create(key)
+			if err != nil {
+				return nil, fmt.Errorf(`failed to create key decrypter for %s: %w`, alg, err)
+			}
+			key = kd
+		}
+	}
+
 	if jwkKey, ok := key.(jwk.Key); ok {
 		var raw interface{}
 		if err := jwkKey.Raw(&raw); err != nil {
@@ -579,6 +883,17 @@ func (dctx *decryptCtx) decryptContent(ctx context.Context, alg jwa.KeyEncryptio
 		key = raw
 	}
 
+	// Keys whose private part is not accessible can only be used via
+	// crypto.Decrypter
+	switch alg {
+	case jwa.RSA1_5, jwa.RSA_OAEP, jwa.RSA_OAEP_256, jwa.RSA_OAEP_384, jwa.RSA_OAEP_512:
+		if cd, ok := key.(crypto.Decrypter); ok {
+			if _, ok := key.(*rsa.PrivateKey); !ok {
+				key = &cryptoKeyDecrypter{decrypter: cd}
+			}
+		}
+	}
+
 	dec := newDecrypter(alg, dctx.msg.protectedHeaders.ContentEncryption(), key).
 		AuthenticatedData(dctx.aad).
 		ComputedAuthenticatedData(dctx.computedAad).
@@ -600,8 +915,19 @@ func (dctx *decryptCtx) decryptContent(ctx context.Context, alg jwa.KeyEncryptio
 		return nil, fmt.Errorf(`failed to copy headers (2): %w`, err)
 	}
 
+	if keyenc.IsHPKEIntegrated(alg) {
+		return dctx.decryptHPKEIntegrated(alg, key, h2)
+	}
+
 	switch alg {
-	case jwa.ECDH_ES, jwa.ECDH_ES_A128KW, jwa.ECDH_ES_A192KW, jwa.ECDH_ES_A256KW:
+	case jwa.HPKE_0_KE, jwa.HPKE_3_KE, jwa.HPKE_4_KE:
+		ek := h2.EncapsulatedKey()
+		if len(ek) == 0 {
+			return nil, fmt.Errorf(`failed to get 'ek' field`)
+		}
+		dec.EncapsulatedKey(ek)
+	case jwa.ECDH_ES, jwa.ECDH_ES_A128KW, jwa.ECDH_ES_A192KW, jwa.ECDH_ES_A256KW,
+		jwa.ECDH_1PU, jwa.ECDH_1PU_A128KW, jwa.ECDH_1PU_A192KW, jwa.ECDH_1PU_A256KW:
 		epkif, ok := h2.Get(EphemeralPublicKeyKey)
 		if !ok {
 			return nil, fmt.Errorf(`failed to get 'epk' field`)
@@ -629,7 +955,29 @@ func (dctx *decryptCtx) decryptContent(ctx context.Context, alg jwa.KeyEncryptio
 		if apv := h2.AgreementPartyVInfo(); len(apv) > 0 {
 			dec.AgreementPartyVInfo(apv)
 		}
-	case jwa.A128GCMKW, jwa.A192GCMKW, jwa.A256GCMKW:
+
+		switch alg {
+		case jwa.ECDH_1PU, jwa.ECDH_1PU_A128KW, jwa.ECDH_1PU_A192KW, jwa.ECDH_1PU_A256KW:
+			senderKey, err := dctx.fetchSenderKey(ctx, h2)
+			if err != nil {
+				return nil, fmt.Errorf(`failed to fetch sender key: %w`, err)
+			}
+			dec.SenderPublicKey(senderKey)
+		}
+	case jwa.MLKEM768, jwa.MLKEM1024, jwa.MLKEM768_A192KW, jwa.MLKEM1024_A256KW:
+		ek := h2.EncapsulatedKey()
+		if len(ek) == 0 {
+			return nil, fmt.Errorf(`failed to get 'ek' field`)
+		}
+		dec.EncapsulatedKey(ek)
+
+		if apu := h2.AgreementPartyUInfo(); len(apu) > 0 {
+			dec.AgreementPartyUInfo(apu)
+		}
+		if apv := h2.AgreementPartyVInfo(); len(apv) > 0 {
+			dec.AgreementPartyVInfo(apv)
+		}
+	case jwa.A128GCMKW, jwa.A192GCMKW, jwa.A256GCMKW, jwa.C20PKW, jwa.XC20PKW:
 		ivB64, ok := h2.Get(InitializationVectorKey)
 		if ok {
 			ivB64Str, ok := ivB64.(string)
@@ -672,6 +1020,9 @@ func (dctx *decryptCtx) decryptContent(ctx context.Context, alg jwa.KeyEncryptio
 		if !ok {
 			return nil, fmt.Errorf("unexpected type for 'p2c': %T", count)
 		}
+		if max := dctx.limits.maxPBES2Count; max > 0 && countFlt > float64(max) {
+			return nil, fmt.Errorf(`%w (%d)`, errMaxPBES2CountExceeded, max)
+		}
 		salt, err := base64.DecodeString(saltB64Str)
 		if err != nil {
 			return nil, fmt.Errorf(`failed to b64-decode 'salt': %w`, err)
@@ -686,7 +1037,7 @@ func (dctx *decryptCtx) decryptContent(ctx context.Context, alg jwa.KeyEncryptio
 	}
 
 	if h2.Compression() == jwa.Deflate {
-		buf, err := uncompress(plaintext)
+		buf, err := uncompress(plaintext, dctx.limits.maxDecompressedSize)
 		if err != nil {
 			return nil, fmt.Errorf(`jwe.Derypt: failed to uncompress payload: %w`, err)
 		}
@@ -700,25 +1051,85 @@ func (dctx *decryptCtx) decryptContent(ctx context.Context, alg jwa.KeyEncryptio
 	return plaintext, nil
 }
 
+// fetchSenderKey resolves the sender's static public key for ECDH-1PU
+// using the sender key providers.
+func (dctx *decryptCtx) fetchSenderKey(ctx context.Context, h Headers) (interface{}, error) {
+	if len(dctx.senderKeyProviders) == 0 {
+		return nil, fmt.Errorf(`no sender key providers have been provided (see jwe.WithSenderKey() and jwe.WithSenderKeyProvider())`)
+	}
+
+	var lastError error
+	for i, kp := range dctx.senderKeyProviders {
+		key, err := kp.FetchSenderKey(ctx, h)
+		if err != nil {
+			lastError = fmt.Errorf(`sender key provider %d failed: %w`, i, err)
+			continue
+		}
+		if key == nil {
+			continue
+		}
+
+		pubkey, err := jwk.PublicRawKeyOf(key)
+		if err != nil {
+			return nil, fmt.Errorf(`failed to retrieve public key from sender key (%T): %w`, key, err)
+		}
+		return pubkey, nil
+	}
+
+	if lastError != nil {
+		return nil, lastError
+	}
+	return nil, fmt.Errorf(`sender key providers did not provide a key`)
+}
+
+// decryptHPKEIntegrated decrypts a message that has been encrypted
+// using HPKE integrated encryption.
+func (dctx *decryptCtx) decryptHPKEIntegrated(alg jwa.KeyEncryptionAlgorithm, key interface{}, h Headers) ([]byte, error) {
+	ek := h.EncapsulatedKey()
+	if len(ek) == 0 {
+		return nil, fmt.Errorf(`failed to get 'ek' field`)
+	}
+
+	aad := dctx.computedAad
+	if dctx.aad != nil {
+		aad = append(append(aad, '.'), dctx.aad...)
+	}
+
+	plaintext, err := keyenc.HPKEOpen(alg, key, ek, aad, dctx.msg.cipherText)
+	if err != nil {
+		return nil, fmt.Errorf(`jwe.Decrypt: decryption failed: %w`, err)
+	}
+
+	if h.Compression() == jwa.Deflate {
+		buf, err := uncompress(plaintext, dctx.limits.maxDecompressedSize)
+		if err != nil {
+			return nil, fmt.Errorf(`jwe.Derypt: failed to uncompress payload: %w`, err)
+		}
+		plaintext = buf
+	}
+
+	return plaintext, nil
+}
+
 // Parse parses the JWE message into a Message object. The JWE message
 // can be either compact or full JSON format.
 //
 // Parse() currently does not take any options, but the API accepts it
 // in anticipation of future addition.
 func Parse(buf []byte, _ ...ParseOption) (*Message, error) {
-	return parseJSONOrCompact(buf, false)
+	return parseJSONOrCompact(buf)
 }
 
-func parseJSONOrCompact(buf []byte, storeProtectedHeaders bool) (*Message, error) {
+func parseJSONOrCompact(buf []byte) (*Message, error) {
 	buf = bytes.TrimSpace(buf)
 	if len(buf) == 0 {
 		return nil, fmt.Errorf(`empty buffer`)
 	}
 
 	if buf[0] == '{' {
-		return parseJSON(buf, storeProtectedHeaders)
+		return parseJSON(buf)
 	}
-	return parseCompact(buf, storeProtectedHeaders)
+	return parseCompact(buf)
 }
 
 // ParseString is the same as Parse, but takes a string.
@@ -735,16 +1146,15 @@ func ParseReader(src io.Reader) (*Message, error) {
 	return Parse(buf)
 }
 
-func parseJSON(buf []byte, storeProtectedHeaders bool) (*Message, error) {
+func parseJSON(buf []byte) (*Message, error) {
 	m := NewMessage()
-	m.storeProtectedHeaders = storeProtectedHeaders
 	if err := json.Unmarshal(buf, &m); err != nil {
 		return nil, fmt.Errorf(`failed to parse JSON: %w`, err)
 	}
 	return m, nil
 }
 
-func parseCompact(buf []byte, storeProtectedHeaders bool) (*Message, error) {
+func parseCompact(buf []byte) (*Message, error) {
 	parts := bytes.Split(buf, []byte{'.'})
 	if len(parts) != 5 {
 		return nil, fmt.Errorf(`compact JWE format must have five parts (%d)`, len(parts))
@@ -794,9 +1204,8 @@ func parseCompact(buf []byte, storeProtectedHeaders bool) (*Message, error) {
 		return nil, fmt.Errorf(`failed to set %s: %w`, TagKey, err)
 	}
 
-	if storeProtectedHeaders {
-		// This is later used for decryption.
-		m.rawProtectedHeaders = parts[0]
+	if err := m.storeRawProtectedHeaders(parts[0]); err != nil {
+		return nil, err
 	}
 
 	return m, nil
@@ -822,3 +1231,130 @@ func parseCompact(buf []byte, storeProtectedHeaders bool) (*Message, error) {
 func RegisterCustomField(name string, object interface{}) {
 	registry.Register(name, object)
 }
+
+// Helpers for key algorithm discovery. Algorithms for EC and OKP keys that
+// depend on the curve are kept separately, as are algorithms for AKP keys,
+// which are keyed by the "alg" of the key
+var keyTypeToAlgorithms = make(map[jwa.KeyType][]jwa.KeyEncryptionAlgorithm)
+var curveToAlgorithms = make(map[jwa.EllipticCurveAlgorithm][]jwa.KeyEncryptionAlgorithm)
+var akpAlgorithms = make(map[jwa.KeyAlgorithm][]jwa.KeyEncryptionAlgorithm)
+
+func init() {
+	for _, alg := range []jwa.KeyEncryptionAlgorithm{jwa.RSA1_5, jwa.RSA_OAEP, jwa.RSA_OAEP_256, jwa.RSA_OAEP_384, jwa.RSA_OAEP_512} {
+		addAlgorithmForKeyType(jwa.RSA, alg)
+	}
+	for _, alg := range []jwa.KeyEncryptionAlgorithm{jwa.ECDH_ES, jwa.ECDH_ES_A128KW, jwa.ECDH_ES_A192KW, jwa.ECDH_ES_A256KW} {
+		addAlgorithmForKeyType(jwa.EC, alg)
+		addAlgorithmForCurve(jwa.X25519, alg)
+		addAlgorithmForCurve(jwa.X448, alg)
+	}
+	if keyenc.HPKEAvailable {
+		for _, alg := range []jwa.KeyEncryptionAlgorithm{jwa.HPKE_0, jwa.HPKE_0_KE} {
+			addAlgorithmForCurve(jwa.P256, alg)
+		}
+		for _, alg := range []jwa.KeyEncryptionAlgorithm{jwa.HPKE_3, jwa.HPKE_3_KE, jwa.HPKE_4, jwa.HPKE_4_KE} {
+			addAlgorithmForCurve(jwa.X25519, alg)
+		}
+	}
+	// AKP keys for ML-KEM only exist if the jwk package supports them,
+	// so these do not need to be guarded
+	for _, alg := range []jwa.KeyAlgorithm{jwa.MLKEM768, jwa.MLKEM768_A192KW} {
+		akpAlgorithms[alg] = []jwa.KeyEncryptionAlgorithm{jwa.MLKEM768, jwa.MLKEM768_A192KW}
+	}
+	for _, alg := range []jwa.KeyAlgorithm{jwa.MLKEM1024, jwa.MLKEM1024_A256KW} {
+		akpAlgorithms[alg] = []jwa.KeyEncryptionAlgorithm{jwa.MLKEM1024, jwa.MLKEM1024_A256KW}
+	}
+	for _, alg := range []jwa.KeyEncryptionAlgorithm{jwa.A128KW, jwa.A192KW, jwa.A256KW, jwa.A128GCMKW, jwa.A192GCMKW, jwa.A256GCMKW, jwa.C20PKW, jwa.XC20PKW, jwa.PBES2_HS256_A128KW, jwa.PBES2_HS384_A192KW, jwa.PBES2_HS512_A256KW, jwa.DIRECT} {
+		addAlgorithmForKeyType(jwa.OctetSeq, alg)
+	}
+}
+
+func addAlgorithmForKeyType(kty jwa.KeyType, alg jwa.KeyEncryptionAlgorithm) {
+	keyTypeToAlgorithms[kty] = append(keyTypeToAlgorithms[kty], alg)
+}
+
+func addAlgorithmForCurve(crv jwa.EllipticCurveAlgorithm, alg jwa.KeyEncryptionAlgorithm) {
+	curveToAlgorithms[crv] = append(curveToAlgorithms[crv], alg)
+}
+
+func algorithmForCurve(crv elliptic.Curve) jwa.EllipticCurveAlgorithm {
+	if crv == nil {
+		return jwa.InvalidEllipticCurve
+	}
+	alg, ok := ecutil.AlgorithmForCurve(crv)
+	if !ok {
+		return jwa.InvalidEllipticCurve
+	}
+	return alg
+}
+
+// AlgorithmsForKey returns the possible key encryption algorithms that can
+// be used for a given key.
+//
+// For EC and OKP keys the result depends on the curve: ECDH-ES is offered
+// for all EC curves as well as X25519 and X448, and the HPKE algorithms
+// are offered for P-256 (HPKE-0) and X25519 (HPKE-3, HPKE-4) if they are
+// available (Go 1.26 or later). OKP keys for signatures, such as Ed25519,
+// can not be used for key encryption. For AKP keys, the ML-KEM algorithms
+// matching the parameter set specified by the "alg" of the key are returned.
+//
+// Algorithms that require more than one key, such as ECDH-1PU, are not
+// included.
+func AlgorithmsForKey(key interface{}) ([]jwa.KeyEncryptionAlgorithm, error) {
+	var kty jwa.KeyType
+	var crv jwa.EllipticCurveAlgorithm
+	var alg jwa.KeyAlgorithm
+	switch key := key.(type) {
+	case jwk.Key:
+		kty = key.KeyType()
+		switch kty {
+		case jwa.EC:
+			if v, ok := key.Get(jwk.ECDSACrvKey); ok {
+				crv, _ = v.(jwa.EllipticCurveAlgorithm)
+			}
+		case jwa.OKP:
+			if v, ok := key.Get(jwk.OKPCrvKey); ok {
+				crv, _ = v.(jwa.EllipticCurveAlgorithm)
+			}
+		case jwa.AKP:
+			alg = key.Algorithm()
+		}
+	case rsa.PublicKey, *rsa.PublicKey, rsa.PrivateKey, *rsa.PrivateKey:
+		kty = jwa.RSA
+	case ecdsa.PublicKey:
+		kty, crv = jwa.EC, algorithmForCurve(key.Curve)
+	case *ecdsa.PublicKey:
+		kty, crv = jwa.EC, algorithmForCurve(key.Curve)
+	case ecdsa.PrivateKey:
+		kty, crv = jwa.EC, algorithmForCurve(key.Curve)
+	case *ecdsa.PrivateKey:
+		kty, crv = jwa.EC, algorithmForCurve(key.Curve)
+	case x25519.PublicKey, x25519.PrivateKey:
+		kty, crv = jwa.OKP, jwa.X25519
+	case x448.PublicKey, x448.PrivateKey:
+		kty, crv = jwa.OKP, jwa.X448
+	case []byte:
+		kty = jwa.OctetSeq
+	default:
+		// Other raw keys, such as ML-KEM keys, are handled by converting
+		// them into a jwk.Key first
+		jwkKey, err := jwk.FromRaw(key)
+		if err != nil {
+			return nil, fmt.Errorf(`invalid key %T`, key)
+		}
+		return AlgorithmsForKey(jwkKey)
+	}
+
+	var algs []jwa.KeyEncryptionAlgorithm
+	switch kty {
+	case jwa.AKP:
+		algs = akpAlgorithms[alg]
+	default:
+		algs = append(algs, keyTypeToAlgorithms[kty]...)
+		algs = append(algs, curveToAlgorithms[crv]...)
+	}
+	if len(algs) == 0 {
+		return nil, fmt.Errorf(`key (kty=%q) can not be used for key encryption`, kty)
+	}
+	return algs, nil
+}
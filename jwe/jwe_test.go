@@ -4,11 +4,16 @@ import (
 	"bytes"
 	"context"
 	"crypto"
+	"crypto/aes"
+	"crypto/cipher"
 	"crypto/ecdsa"
+	"crypto/ed25519"
 	"crypto/elliptic"
 	"crypto/rand"
 	"crypto/rsa"
+	"crypto/sha256"
 	"encoding/base64"
+	"errors"
 	"fmt"
 	"os"
 	"strings"
@@ -22,6 +27,7 @@ import (
 	"github.com/lestrrat-go/jwx/v2/jwe"
 	"github.com/lestrrat-go/jwx/v2/jwk"
 	"github.com/lestrrat-go/jwx/v2/x25519"
+	"github.com/lestrrat-go/jwx/v2/x448"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -340,6 +346,86 @@ func TestRoundtrip_RSAES_OAEP_AES_GCM(t *testing.T) {
 	}
 }
 
+func TestRoundtrip_RSAES_OAEP_SHA2(t *testing.T) {
+	t.Parallel()
+	plaintext := []byte("Lorem ipsum")
+	for _, alg := range []jwa.KeyEncryptionAlgorithm{jwa.RSA_OAEP_256, jwa.RSA_OAEP_384, jwa.RSA_OAEP_512} {
+		alg := alg
+		t.Run(alg.String(), func(t *testing.T) {
+			t.Parallel()
+			encrypted, err := jwe.Encrypt(plaintext, jwe.WithKey(alg, &rsaPrivKey.PublicKey))
+			require.NoError(t, err, `jwe.Encrypt should succeed`)
+
+			decrypted, err := jwe.Decrypt(encrypted, jwe.WithKey(alg, rsaPrivKey))
+			require.NoError(t, err, `jwe.Decrypt should succeed`)
+			require.Equal(t, plaintext, decrypted, `decrypted content should match`)
+
+			// The wrong hash function should not work
+			_, err = jwe.Decrypt(encrypted, jwe.WithKey(jwa.RSA_OAEP, rsaPrivKey))
+			require.Error(t, err, `jwe.Decrypt should fail with RSA-OAEP`)
+
+			t.Run("WithInferAlgorithmFromKey", func(t *testing.T) {
+				key, err := jwk.FromRaw(rsaPrivKey)
+				require.NoError(t, err, `jwk.FromRaw should succeed`)
+				set := jwk.NewSet()
+				require.NoError(t, set.AddKey(key), `set.AddKey should succeed`)
+
+				_, err = jwe.Decrypt(encrypted, jwe.WithKeySet(set, jwe.WithRequireKid(false)))
+				require.Error(t, err, `jwe.Decrypt should fail without an algorithm in the key`)
+
+				decrypted, err := jwe.Decrypt(encrypted, jwe.WithKeySet(set, jwe.WithRequireKid(false), jwe.WithInferAlgorithmFromKey(true)))
+				require.NoError(t, err, `jwe.Decrypt should succeed`)
+				require.Equal(t, plaintext, decrypted, `decrypted content should match`)
+			})
+		})
+	}
+}
+
+func TestAlgorithmsForKey(t *testing.T) {
+	t.Parallel()
+	testcases := []struct {
+		Name     string
+		Key      interface{}
+		Expected jwa.KeyEncryptionAlgorithm
+	}{
+		{Name: "RSA", Key: &rsaPrivKey.PublicKey, Expected: jwa.RSA_OAEP_512},
+		{Name: "EC", Key: &ecdsa.PublicKey{}, Expected: jwa.ECDH_ES_A256KW},
+		{Name: "OKP (X25519)", Key: x25519.PublicKey(nil), Expected: jwa.ECDH_ES},
+		{Name: "OKP (X448)", Key: x448.PublicKey(nil), Expected: jwa.ECDH_ES_A128KW},
+		{Name: "oct", Key: []byte(nil), Expected: jwa.A256GCMKW},
+	}
+	for _, tc := range testcases {
+		tc := tc
+		t.Run(tc.Name, func(t *testing.T) {
+			t.Parallel()
+			algs, err := jwe.AlgorithmsForKey(tc.Key)
+			require.NoError(t, err, `jwe.AlgorithmsForKey should succeed`)
+			require.Contains(t, algs, tc.Expected, `result should contain %s`, tc.Expected)
+		})
+	}
+
+	_, err := jwe.AlgorithmsForKey(struct{}{})
+	require.Error(t, err, `jwe.AlgorithmsForKey should fail for unknown keys`)
+
+	// ECDH-ES can not be used with signature only curves
+	edpub, _, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err, `ed25519.GenerateKey should succeed`)
+	edkey, err := jwk.FromRaw(edpub)
+	require.NoError(t, err, `jwk.FromRaw should succeed`)
+	for _, key := range []interface{}{edpub, edkey} {
+		_, err = jwe.AlgorithmsForKey(key)
+		require.Error(t, err, `jwe.AlgorithmsForKey should fail for Ed25519 keys (%T)`, key)
+	}
+
+	xpub, _, err := x25519.GenerateKey(rand.Reader)
+	require.NoError(t, err, `x25519.GenerateKey should succeed`)
+	xkey, err := jwk.FromRaw(xpub)
+	require.NoError(t, err, `jwk.FromRaw should succeed`)
+	algs, err := jwe.AlgorithmsForKey(xkey)
+	require.NoError(t, err, `jwe.AlgorithmsForKey should succeed for X25519 jwk.Key`)
+	require.Contains(t, algs, jwa.ECDH_ES_A256KW, `result should contain %s`, jwa.ECDH_ES_A256KW)
+}
+
 func TestRoundtrip_RSA1_5_A128CBC_HS256(t *testing.T) {
 	var plaintext = []byte{
 		76, 105, 118, 101, 32, 108, 111, 110, 103, 32, 97, 110, 100, 32,
@@ -440,6 +526,12 @@ func TestEncode_ECDH(t *testing.T) {
 		elliptic.P384(),
 		elliptic.P521(),
 	}
+	// The Brainpool curves are only available with the jwx_brainpool build tag
+	for _, alg := range []jwa.EllipticCurveAlgorithm{jwa.BrainpoolP256r1, jwa.BrainpoolP384r1, jwa.BrainpoolP512r1} {
+		if crv, ok := jwk.CurveForAlgorithm(alg); ok {
+			curves = append(curves, crv)
+		}
+	}
 	for _, crv := range curves {
 		crv := crv
 		t.Run(crv.Params().Name, func(t *testing.T) {
@@ -462,6 +554,15 @@ func TestEncode_X25519(t *testing.T) {
 	testEncodeECDHWithKey(t, privkey, pubkey)
 }
 
+func TestEncode_X448(t *testing.T) {
+	pubkey, privkey, err := x448.GenerateKey(rand.Reader)
+	if !assert.NoError(t, err, `x448.GenerateKey should succeed`) {
+		return
+	}
+
+	testEncodeECDHWithKey(t, privkey, pubkey)
+}
+
 func Test_GHIssue207(t *testing.T) {
 	const plaintext = "hi\n"
 	var testcases = []struct {
@@ -534,6 +635,8 @@ func TestEncode_Direct(t *testing.T) {
 		{jwa.A192GCM, 24},
 		{jwa.A256CBC_HS512, 64},
 		{jwa.A256GCM, 32},
+		{jwa.C20P, 32},
+		{jwa.XC20P, 32},
 	}
 	plaintext := []byte("Lorem ipsum")
 
@@ -565,6 +668,50 @@ func TestEncode_Direct(t *testing.T) {
 	}
 }
 
+func TestEncode_ChaCha20Poly1305(t *testing.T) {
+	sharedkey := make([]byte, 32)
+	_, err := rand.Read(sharedkey)
+	if !assert.NoError(t, err, `rand.Read should succeed`) {
+		return
+	}
+
+	ecPrivkey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if !assert.NoError(t, err, `ecdsa.GenerateKey should succeed`) {
+		return
+	}
+
+	var testcases = []struct {
+		KeyAlgorithm     jwa.KeyEncryptionAlgorithm
+		ContentAlgorithm jwa.ContentEncryptionAlgorithm
+		EncryptKey       interface{}
+		DecryptKey       interface{}
+	}{
+		{jwa.C20PKW, jwa.C20P, sharedkey, sharedkey},
+		{jwa.C20PKW, jwa.A256GCM, sharedkey, sharedkey},
+		{jwa.XC20PKW, jwa.XC20P, sharedkey, sharedkey},
+		{jwa.XC20PKW, jwa.A128CBC_HS256, sharedkey, sharedkey},
+		{jwa.ECDH_ES, jwa.C20P, &ecPrivkey.PublicKey, ecPrivkey},
+		{jwa.ECDH_ES_A256KW, jwa.XC20P, &ecPrivkey.PublicKey, ecPrivkey},
+	}
+	plaintext := []byte("Lorem ipsum")
+
+	for _, tc := range testcases {
+		tc := tc
+		t.Run(tc.KeyAlgorithm.String()+"/"+tc.ContentAlgorithm.String(), func(t *testing.T) {
+			encrypted, err := jwe.Encrypt(plaintext, jwe.WithKey(tc.KeyAlgorithm, tc.EncryptKey), jwe.WithContentEncryption(tc.ContentAlgorithm))
+			if !assert.NoError(t, err, `jwe.Encrypt should succeed`) {
+				return
+			}
+			decrypted, err := jwe.Decrypt(encrypted, jwe.WithKey(tc.KeyAlgorithm, tc.DecryptKey))
+			if !assert.NoError(t, err, `jwe.Decrypt should succeed`) {
+				return
+			}
+
+			assert.Equal(t, plaintext, decrypted, `jwe.Decrypt should match input plaintext`)
+		})
+	}
+}
+
 // Decrypts messages generated by `jose` tool. It helps check compatibility with other jwx implementations.
 func TestDecodePredefined_Direct(t *testing.T) {
 	var testcases = []struct {
@@ -883,3 +1030,350 @@ func TestGH924(t *testing.T) {
 	require.NoError(t, err, `jwe.Decrypt should succeed`)
 	require.Equal(t, payload, decrypted, `decrypt messages match`)
 }
+
+const sillyContentAlgo jwa.ContentEncryptionAlgorithm = "SillyXOR"
+
+// sillyContentCipher XORs the payload with the content encryption key,
+// and uses a truncated SHA-256 hash of the key, aad and the ciphertext as
+// the authentication tag. Do NOT use this for anything other than tests.
+type sillyContentCipher struct{}
+
+func (sillyContentCipher) KeySize() int {
+	return 16
+}
+
+func (sillyContentCipher) xor(cek, src []byte) []byte {
+	dst := make([]byte, len(src))
+	for i := range src {
+		dst[i] = src[i] ^ cek[i%len(cek)]
+	}
+	return dst
+}
+
+func (sillyContentCipher) tag(cek, aad, ciphertext []byte) []byte {
+	h := sha256.New()
+	h.Write(cek)
+	h.Write(aad)
+	h.Write(ciphertext)
+	return h.Sum(nil)[:16]
+}
+
+func (c sillyContentCipher) Encrypt(cek, plaintext, aad []byte) ([]byte, []byte, []byte, error) {
+	ciphertext := c.xor(cek, plaintext)
+	return []byte("silly-iv"), ciphertext, c.tag(cek, aad, ciphertext), nil
+}
+
+func (c sillyContentCipher) Decrypt(cek, _, ciphertext, tag, aad []byte) ([]byte, error) {
+	if !bytes.Equal(c.tag(cek, aad, ciphertext), tag) {
+		return nil, fmt.Errorf(`tag mismatch`)
+	}
+	return c.xor(cek, ciphertext), nil
+}
+
+func TestRegisterContentCipher(t *testing.T) {
+	// Note: This has global effect. You can't run this in parallel with other tests
+	jwe.RegisterContentCipher(sillyContentAlgo, jwe.ContentCipherFactoryFn(func() (jwe.ContentCipher, error) {
+		return sillyContentCipher{}, nil
+	}))
+	defer jwe.UnregisterContentCipher(sillyContentAlgo)
+	defer jwa.UnregisterContentEncryptionAlgorithm(sillyContentAlgo)
+
+	var ca jwa.ContentEncryptionAlgorithm
+	require.NoError(t, ca.Accept(sillyContentAlgo.String()), `jwa.ContentEncryptionAlgorithm.Accept should succeed`)
+
+	sharedkey := []byte("0123456789abcdef")
+	ecPrivkey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err, `ecdsa.GenerateKey should succeed`)
+
+	testcases := []struct {
+		Algorithm  jwa.KeyEncryptionAlgorithm
+		EncryptKey interface{}
+		DecryptKey interface{}
+	}{
+		{Algorithm: jwa.DIRECT, EncryptKey: sharedkey, DecryptKey: sharedkey},
+		{Algorithm: jwa.A128KW, EncryptKey: sharedkey, DecryptKey: sharedkey},
+		{Algorithm: jwa.ECDH_ES, EncryptKey: &ecPrivkey.PublicKey, DecryptKey: ecPrivkey},
+	}
+
+	payload := []byte("Lorem Ipsum")
+	for _, tc := range testcases {
+		tc := tc
+		t.Run(tc.Algorithm.String(), func(t *testing.T) {
+			encrypted, err := jwe.Encrypt(payload, jwe.WithKey(tc.Algorithm, tc.EncryptKey), jwe.WithContentEncryption(sillyContentAlgo))
+			require.NoError(t, err, `jwe.Encrypt should succeed`)
+
+			msg, err := jwe.Parse(encrypted)
+			require.NoError(t, err, `jwe.Parse should succeed`)
+			require.Equal(t, sillyContentAlgo, msg.ProtectedHeaders().ContentEncryption(), `"enc" should match`)
+
+			decrypted, err := jwe.Decrypt(encrypted, jwe.WithKey(tc.Algorithm, tc.DecryptKey))
+			require.NoError(t, err, `jwe.Decrypt should succeed`)
+			require.Equal(t, payload, decrypted, `decrypted payload should match`)
+		})
+	}
+}
+
+const sillyKeyAlgo jwa.KeyEncryptionAlgorithm = "SillyXORKW"
+
+// sillyKeyEncrypterDecrypter XORs the content encryption key with the
+// shared key. Do NOT use this for anything other than tests.
+type sillyKeyEncrypterDecrypter struct {
+	key []byte
+}
+
+func newSillyKeyEncrypterDecrypter(key interface{}) (*sillyKeyEncrypterDecrypter, error) {
+	if jwkKey, ok := key.(jwk.Key); ok {
+		var raw []byte
+		if err := jwkKey.Raw(&raw); err != nil {
+			return nil, fmt.Errorf(`failed to retrieve raw key: %w`, err)
+		}
+		key = raw
+	}
+	sharedkey, ok := key.([]byte)
+	if !ok {
+		return nil, fmt.Errorf(`expected []byte, got %T`, key)
+	}
+	return &sillyKeyEncrypterDecrypter{key: sharedkey}, nil
+}
+
+func (kd *sillyKeyEncrypterDecrypter) xor(src []byte) []byte {
+	dst := make([]byte, len(src))
+	for i := range src {
+		dst[i] = src[i] ^ kd.key[i%len(kd.key)]
+	}
+	return dst
+}
+
+func (kd *sillyKeyEncrypterDecrypter) Algorithm() jwa.KeyEncryptionAlgorithm {
+	return sillyKeyAlgo
+}
+
+func (kd *sillyKeyEncrypterDecrypter) EncryptKey(cek []byte) ([]byte, error) {
+	return kd.xor(cek), nil
+}
+
+func (kd *sillyKeyEncrypterDecrypter) DecryptKey(_ jwa.KeyEncryptionAlgorithm, enckey []byte, _ jwe.Recipient, _ *jwe.Message) ([]byte, error) {
+	return kd.xor(enckey), nil
+}
+
+func TestRegisterKeyEncrypter(t *testing.T) {
+	// Note: This has global effect. You can't run this in parallel with other tests
+	jwe.RegisterKeyEncrypter(sillyKeyAlgo, jwe.KeyEncrypterFactoryFn(func(key interface{}) (jwe.KeyEncrypter, error) {
+		return newSillyKeyEncrypterDecrypter(key)
+	}))
+	defer jwe.UnregisterKeyEncrypter(sillyKeyAlgo)
+
+	jwe.RegisterKeyDecrypter(sillyKeyAlgo, jwe.KeyDecrypterFactoryFn(func(key interface{}) (jwe.KeyDecrypter, error) {
+		return newSillyKeyEncrypterDecrypter(key)
+	}))
+	defer jwe.UnregisterKeyDecrypter(sillyKeyAlgo)
+	defer jwa.UnregisterKeyEncryptionAlgorithm(sillyKeyAlgo)
+
+	var ka jwa.KeyEncryptionAlgorithm
+	require.NoError(t, ka.Accept(sillyKeyAlgo.String()), `jwa.KeyEncryptionAlgorithm.Accept should succeed`)
+
+	sharedkey := []byte("0123456789abcdef")
+	jwkKey, err := jwk.FromRaw(sharedkey)
+	require.NoError(t, err, `jwk.FromRaw should succeed`)
+	require.NoError(t, jwkKey.Set(jwk.KeyIDKey, `silly`), `jwkKey.Set should succeed`)
+	require.NoError(t, jwkKey.Set(jwk.AlgorithmKey, sillyKeyAlgo), `jwkKey.Set should succeed`)
+
+	payload := []byte("Lorem Ipsum")
+	t.Run("Raw key", func(t *testing.T) {
+		encrypted, err := jwe.Encrypt(payload, jwe.WithKey(sillyKeyAlgo, sharedkey))
+		require.NoError(t, err, `jwe.Encrypt should succeed`)
+
+		msg, err := jwe.Parse(encrypted)
+		require.NoError(t, err, `jwe.Parse should succeed`)
+		require.Equal(t, sillyKeyAlgo, msg.Recipients()[0].Headers().Algorithm(), `"alg" should match`)
+
+		decrypted, err := jwe.Decrypt(encrypted, jwe.WithKey(sillyKeyAlgo, sharedkey))
+		require.NoError(t, err, `jwe.Decrypt should succeed`)
+		require.Equal(t, payload, decrypted, `decrypted payload should match`)
+
+		_, err = jwe.Decrypt(encrypted, jwe.WithKey(sillyKeyAlgo, []byte("fedcba9876543210")))
+		require.Error(t, err, `jwe.Decrypt should fail with the wrong key`)
+	})
+	t.Run("jwk.Key and key set", func(t *testing.T) {
+		encrypted, err := jwe.Encrypt(payload, jwe.WithKey(sillyKeyAlgo, jwkKey))
+		require.NoError(t, err, `jwe.Encrypt should succeed`)
+
+		msg, err := jwe.Parse(encrypted)
+		require.NoError(t, err, `jwe.Parse should succeed`)
+		require.Equal(t, `silly`, msg.Recipients()[0].Headers().KeyID(), `"kid" should match`)
+
+		set := jwk.NewSet()
+		require.NoError(t, set.AddKey(jwkKey), `set.AddKey should succeed`)
+
+		decrypted, err := jwe.Decrypt(encrypted, jwe.WithKeySet(set))
+		require.NoError(t, err, `jwe.Decrypt should succeed`)
+		require.Equal(t, payload, decrypted, `decrypted payload should match`)
+	})
+}
+
+func TestRawProtectedHeaders(t *testing.T) {
+	t.Parallel()
+
+	// Construct a message whose protected header is not encoded in the
+	// way that we would encode it ourselves
+	key := make([]byte, 16)
+	_, err := rand.Read(key)
+	require.NoError(t, err, `rand.Read should succeed`)
+	payload := []byte(`Lorem ipsum`)
+
+	protected := base64.RawURLEncoding.EncodeToString([]byte(`{"enc":"A128GCM",` + "\r\n" + ` "alg":"dir"}`))
+	block, err := aes.NewCipher(key)
+	require.NoError(t, err, `aes.NewCipher should succeed`)
+	aead, err := cipher.NewGCM(block)
+	require.NoError(t, err, `cipher.NewGCM should succeed`)
+	iv := make([]byte, aead.NonceSize())
+	_, err = rand.Read(iv)
+	require.NoError(t, err, `rand.Read should succeed`)
+	sealed := aead.Seal(nil, iv, payload, []byte(protected))
+	tagOffset := len(sealed) - aead.Overhead()
+
+	encrypted := strings.Join([]string{
+		protected,
+		``,
+		base64.RawURLEncoding.EncodeToString(iv),
+		base64.RawURLEncoding.EncodeToString(sealed[:tagOffset]),
+		base64.RawURLEncoding.EncodeToString(sealed[tagOffset:]),
+	}, `.`)
+
+	decrypted, err := jwe.Decrypt([]byte(encrypted), jwe.WithKey(jwa.DIRECT, key))
+	require.NoError(t, err, `jwe.Decrypt should succeed`)
+	require.Equal(t, payload, decrypted, `payload should match`)
+
+	t.Run("Compact", func(t *testing.T) {
+		t.Parallel()
+		msg, err := jwe.Parse([]byte(encrypted))
+		require.NoError(t, err, `jwe.Parse should succeed`)
+
+		serialized, err := jwe.Compact(msg)
+		require.NoError(t, err, `jwe.Compact should succeed`)
+		require.Equal(t, encrypted, string(serialized), `serialized message should be byte-exact`)
+	})
+	t.Run("JSON", func(t *testing.T) {
+		t.Parallel()
+		msg, err := jwe.Parse([]byte(encrypted))
+		require.NoError(t, err, `jwe.Parse should succeed`)
+
+		serialized, err := json.Marshal(msg)
+		require.NoError(t, err, `json.Marshal should succeed`)
+
+		decrypted, err := jwe.Decrypt(serialized, jwe.WithKey(jwa.DIRECT, key))
+		require.NoError(t, err, `jwe.Decrypt should succeed`)
+		require.Equal(t, payload, decrypted, `payload should match`)
+	})
+	t.Run("WithMessage", func(t *testing.T) {
+		t.Parallel()
+		msg := jwe.NewMessage()
+		_, err := jwe.Decrypt([]byte(encrypted), jwe.WithKey(jwa.DIRECT, key), jwe.WithMessage(msg))
+		require.NoError(t, err, `jwe.Decrypt should succeed`)
+
+		serialized, err := jwe.Compact(msg)
+		require.NoError(t, err, `jwe.Compact should succeed`)
+		require.Equal(t, encrypted, string(serialized), `serialized message should be byte-exact`)
+	})
+}
+
+func TestCriticalHeaders(t *testing.T) {
+	t.Parallel()
+	key := make([]byte, 16)
+	_, err := rand.Read(key)
+	require.NoError(t, err, `rand.Read should succeed`)
+
+	encrypt := func(t *testing.T, name string) []byte {
+		hdrs := jwe.NewHeaders()
+		require.NoError(t, hdrs.Set(jwe.CriticalKey, []string{name}), `hdrs.Set should succeed`)
+		require.NoError(t, hdrs.Set(name, true), `hdrs.Set should succeed`)
+		encrypted, err := jwe.Encrypt([]byte(`Lorem ipsum`), jwe.WithKey(jwa.A128KW, key), jwe.WithProtectedHeaders(hdrs))
+		require.NoError(t, err, `jwe.Encrypt should succeed`)
+		return encrypted
+	}
+
+	t.Run("Unknown header", func(t *testing.T) {
+		t.Parallel()
+		encrypted := encrypt(t, `x-unknown`)
+		_, err := jwe.Decrypt(encrypted, jwe.WithKey(jwa.A128KW, key))
+		require.Error(t, err, `jwe.Decrypt should fail`)
+
+		_, err = jwe.Decrypt(encrypted, jwe.WithKey(jwa.A128KW, key), jwe.WithCriticalHeaders(`x-unknown`))
+		require.NoError(t, err, `jwe.Decrypt should succeed with jwe.WithCriticalHeaders`)
+	})
+	t.Run("Registered header", func(t *testing.T) {
+		t.Parallel()
+		jwe.RegisterCriticalHeader(`x-registered-jwe-test`)
+		encrypted := encrypt(t, `x-registered-jwe-test`)
+		_, err := jwe.Decrypt(encrypted, jwe.WithKey(jwa.A128KW, key))
+		require.NoError(t, err, `jwe.Decrypt should succeed`)
+	})
+}
+
+func TestDecryptLimits(t *testing.T) {
+	t.Run("WithMaxPBES2Count", func(t *testing.T) {
+		t.Parallel()
+		password := []byte(`correct horse battery staple`)
+		encrypted, err := jwe.Encrypt([]byte(`Lorem ipsum`), jwe.WithKey(jwa.PBES2_HS256_A128KW, password))
+		require.NoError(t, err, `jwe.Encrypt should succeed`)
+
+		_, err = jwe.Decrypt(encrypted, jwe.WithKey(jwa.PBES2_HS256_A128KW, password), jwe.WithMaxPBES2Count(1000))
+		require.True(t, errors.Is(err, jwe.ErrMaxPBES2CountExceeded()), `jwe.Decrypt should fail with ErrMaxPBES2CountExceeded (%s)`, err)
+
+		_, err = jwe.Decrypt(encrypted, jwe.WithKey(jwa.PBES2_HS256_A128KW, password))
+		require.NoError(t, err, `jwe.Decrypt should succeed with the default limit`)
+
+		// Tamper with the header so that it uses a count above the default.
+		// The message is rejected before the key derivation is performed,
+		// so the now invalid authentication tag does not matter
+		parts := strings.Split(string(encrypted), ".")
+		hdrbuf, err := base64.RawURLEncoding.DecodeString(parts[0])
+		require.NoError(t, err, `base64.RawURLEncoding.DecodeString should succeed`)
+		var hdrs map[string]interface{}
+		require.NoError(t, json.Unmarshal(hdrbuf, &hdrs), `json.Unmarshal should succeed`)
+		hdrs["p2c"] = 10001
+		hdrbuf, err = json.Marshal(hdrs)
+		require.NoError(t, err, `json.Marshal should succeed`)
+		parts[0] = base64.RawURLEncoding.EncodeToString(hdrbuf)
+
+		_, err = jwe.Decrypt([]byte(strings.Join(parts, ".")), jwe.WithKey(jwa.PBES2_HS256_A128KW, password))
+		require.True(t, errors.Is(err, jwe.ErrMaxPBES2CountExceeded()), `jwe.Decrypt should fail with ErrMaxPBES2CountExceeded without options (%s)`, err)
+	})
+	t.Run("WithMaxDecompressedSize", func(t *testing.T) {
+		t.Parallel()
+		payload := make([]byte, 1024*1024)
+		key := make([]byte, 32)
+		encrypted, err := jwe.Encrypt(payload, jwe.WithKey(jwa.A256KW, key), jwe.WithCompress(jwa.Deflate))
+		require.NoError(t, err, `jwe.Encrypt should succeed`)
+
+		_, err = jwe.Decrypt(encrypted, jwe.WithKey(jwa.A256KW, key), jwe.WithMaxDecompressedSize(int64(len(payload)-1)))
+		require.True(t, errors.Is(err, jwe.ErrMaxDecompressedSizeExceeded()), `jwe.Decrypt should fail with ErrMaxDecompressedSizeExceeded (%s)`, err)
+
+		decrypted, err := jwe.Decrypt(encrypted, jwe.WithKey(jwa.A256KW, key), jwe.WithMaxDecompressedSize(int64(len(payload))))
+		require.NoError(t, err, `jwe.Decrypt should succeed`)
+		require.Equal(t, payload, decrypted, `decrypted payload should match`)
+	})
+	t.Run("WithMaxRecipients", func(t *testing.T) {
+		// not parallel, as this test changes global settings
+		key := make([]byte, 32)
+		encrypted, err := jwe.Encrypt([]byte(`Lorem ipsum`),
+			jwe.WithJSON(),
+			jwe.WithKey(jwa.A256KW, key),
+			jwe.WithKey(jwa.A256KW, key),
+			jwe.WithKey(jwa.A256KW, key),
+		)
+		require.NoError(t, err, `jwe.Encrypt should succeed`)
+
+		_, err = jwe.Decrypt(encrypted, jwe.WithKey(jwa.A256KW, key), jwe.WithMaxRecipients(2))
+		require.True(t, errors.Is(err, jwe.ErrMaxRecipientsExceeded()), `jwe.Decrypt should fail with ErrMaxRecipientsExceeded (%s)`, err)
+
+		jwe.Settings(jwe.WithMaxRecipients(2))
+		defer jwe.Settings(jwe.WithMaxRecipients(0))
+
+		_, err = jwe.Decrypt(encrypted, jwe.WithKey(jwa.A256KW, key))
+		require.True(t, errors.Is(err, jwe.ErrMaxRecipientsExceeded()), `jwe.Decrypt should fail with ErrMaxRecipientsExceeded (%s)`, err)
+
+		_, err = jwe.Decrypt(encrypted, jwe.WithKey(jwa.A256KW, key), jwe.WithMaxRecipients(3))
+		require.NoError(t, err, `jwe.Decrypt should succeed when the limit is overridden`)
+	})
+}
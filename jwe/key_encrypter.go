@@ -0,0 +1,166 @@
+package jwe
+
+import (
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"fmt"
+	"sync"
+
+	"github.com/lestrrat-go/jwx/v2/jwa"
+	"github.com/lestrrat-go/jwx/v2/jwe/internal/cipher"
+)
+
+// KeyEncrypterFactory creates KeyEncrypter objects for custom key
+// encryption algorithms registered via `jwe.RegisterKeyEncrypter`.
+// The key passed to Create is the key specified in `jwe.WithKey()`,
+// as-is: it may be a raw key or a `jwk.Key`.
+type KeyEncrypterFactory interface {
+	Create(key interface{}) (KeyEncrypter, error)
+}
+type KeyEncrypterFactoryFn func(interface{}) (KeyEncrypter, error)
+
+func (fn KeyEncrypterFactoryFn) Create(key interface{}) (KeyEncrypter, error) {
+	return fn(key)
+}
+
+// KeyDecrypterFactory creates KeyDecrypter objects for custom key
+// encryption algorithms registered via `jwe.RegisterKeyDecrypter`.
+// The key passed to Create is the key provided by the key providers
+// (e.g. `jwe.WithKey()`, `jwe.WithKeySet()`), as-is: it may be a raw
+// key or a `jwk.Key`.
+type KeyDecrypterFactory interface {
+	Create(key interface{}) (KeyDecrypter, error)
+}
+type KeyDecrypterFactoryFn func(interface{}) (KeyDecrypter, error)
+
+func (fn KeyDecrypterFactoryFn) Create(key interface{}) (KeyDecrypter, error) {
+	return fn(key)
+}
+
+var muKeyEncrypterDB sync.RWMutex
+var keyEncrypterDB = make(map[jwa.KeyEncryptionAlgorithm]KeyEncrypterFactory)
+
+var muKeyDecrypterDB sync.RWMutex
+var keyDecrypterDB = make(map[jwa.KeyEncryptionAlgorithm]KeyDecrypterFactory)
+
+// RegisterKeyEncrypter is used to register a factory object that creates
+// KeyEncrypter objects based on the given algorithm.
+//
+// Once registered, `jwe.Encrypt()` will use the factory to create a
+// KeyEncrypter from the key given in `jwe.WithKey(alg, key)` whenever
+// `alg` matches. Factories registered via this function take
+// precedence over the built-in key encryption algorithms.
+//
+// Unlike the `UnregisterKeyEncrypter` function, this function automatically
+// calls `jwa.RegisterKeyEncryptionAlgorithm` to register the algorithm
+// in the known algorithms database.
+//
+// This API is experimental and may change without notice, even
+// in minor releases.
+func RegisterKeyEncrypter(alg jwa.KeyEncryptionAlgorithm, f KeyEncrypterFactory) {
+	jwa.RegisterKeyEncryptionAlgorithm(alg)
+	muKeyEncrypterDB.Lock()
+	keyEncrypterDB[alg] = f
+	muKeyEncrypterDB.Unlock()
+}
+
+// UnregisterKeyEncrypter removes the key encrypter factory associated
+// with the given algorithm.
+//
+// Note that when you call this function, the algorithm itself is
+// not automatically unregistered from the known algorithms database.
+// This is because the algorithm may still be required for decryption or
+// some other operation (however unlikely, it is still possible).
+// Therefore, in order to completely remove the algorithm, you must
+// call `jwa.UnregisterKeyEncryptionAlgorithm` yourself.
+func UnregisterKeyEncrypter(alg jwa.KeyEncryptionAlgorithm) {
+	muKeyEncrypterDB.Lock()
+	delete(keyEncrypterDB, alg)
+	muKeyEncrypterDB.Unlock()
+}
+
+// RegisterKeyDecrypter is used to register a factory object that creates
+// KeyDecrypter objects based on the given algorithm.
+//
+// Once registered, `jwe.Decrypt()` will use the factory to create a
+// KeyDecrypter from each key provided by the key providers for `alg`.
+// Factories registered via this function take precedence over the
+// built-in key encryption algorithms.
+//
+// Unlike the `UnregisterKeyDecrypter` function, this function automatically
+// calls `jwa.RegisterKeyEncryptionAlgorithm` to register the algorithm
+// in the known algorithms database.
+//
+// This API is experimental and may change without notice, even
+// in minor releases.
+func RegisterKeyDecrypter(alg jwa.KeyEncryptionAlgorithm, f KeyDecrypterFactory) {
+	jwa.RegisterKeyEncryptionAlgorithm(alg)
+	muKeyDecrypterDB.Lock()
+	keyDecrypterDB[alg] = f
+	muKeyDecrypterDB.Unlock()
+}
+
+// UnregisterKeyDecrypter removes the key decrypter factory associated
+// with the given algorithm.
+//
+// Note that when you call this function, the algorithm itself is
+// not automatically unregistered from the known algorithms database.
+// This is because the algorithm may still be required for encryption or
+// some other operation (however unlikely, it is still possible).
+// Therefore, in order to completely remove the algorithm, you must
+// call `jwa.UnregisterKeyEncryptionAlgorithm` yourself.
+func UnregisterKeyDecrypter(alg jwa.KeyEncryptionAlgorithm) {
+	muKeyDecrypterDB.Lock()
+	delete(keyDecrypterDB, alg)
+	muKeyDecrypterDB.Unlock()
+}
+
+func lookupKeyEncrypterFactory(alg jwa.KeyEncryptionAlgorithm) (KeyEncrypterFactory, bool) {
+	muKeyEncrypterDB.RLock()
+	defer muKeyEncrypterDB.RUnlock()
+	f, ok := keyEncrypterDB[alg]
+	return f, ok
+}
+
+func lookupKeyDecrypterFactory(alg jwa.KeyEncryptionAlgorithm) (KeyDecrypterFactory, bool) {
+	muKeyDecrypterDB.RLock()
+	defer muKeyDecrypterDB.RUnlock()
+	f, ok := keyDecrypterDB[alg]
+	return f, ok
+}
+
+// cryptoKeyDecrypter is a KeyDecrypter that decrypts RSA1_5 and RSA-OAEP
+// encrypted keys using a crypto.Decrypter whose private key is not
+// directly accessible, such as keys created using `jwk.FromOpaque()`
+type cryptoKeyDecrypter struct {
+	decrypter crypto.Decrypter
+}
+
+func (kd *cryptoKeyDecrypter) DecryptKey(alg jwa.KeyEncryptionAlgorithm, encryptedKey []byte, _ Recipient, msg *Message) ([]byte, error) {
+	var opts crypto.DecrypterOpts
+	switch alg {
+	case jwa.RSA1_5:
+		c, err := cipher.New(msg.ProtectedHeaders().ContentEncryption())
+		if err != nil {
+			return nil, fmt.Errorf(`failed to build content cipher: %w`, err)
+		}
+		opts = &rsa.PKCS1v15DecryptOptions{SessionKeyLen: c.KeySize()}
+	case jwa.RSA_OAEP:
+		opts = &rsa.OAEPOptions{Hash: crypto.SHA1}
+	case jwa.RSA_OAEP_256:
+		opts = &rsa.OAEPOptions{Hash: crypto.SHA256}
+	case jwa.RSA_OAEP_384:
+		opts = &rsa.OAEPOptions{Hash: crypto.SHA384}
+	case jwa.RSA_OAEP_512:
+		opts = &rsa.OAEPOptions{Hash: crypto.SHA512}
+	default:
+		return nil, fmt.Errorf(`crypto.Decrypter cannot be used to decrypt keys using %s`, alg)
+	}
+
+	cek, err := kd.decrypter.Decrypt(rand.Reader, encryptedKey, opts)
+	if err != nil {
+		return nil, fmt.Errorf(`failed to decrypt key using %T: %w`, kd.decrypter, err)
+	}
+	return cek, nil
+}
@@ -102,11 +102,12 @@ func (kp *staticKeyProvider) FetchKeys(_ context.Context, sink KeySink, _ Recipi
 }
 
 type keySetProvider struct {
-	set        jwk.Set
-	requireKid bool
+	set            jwk.Set
+	requireKid     bool
+	inferAlgorithm bool // true if the algorithm should be inferred from key type
 }
 
-func (kp *keySetProvider) selectKey(sink KeySink, key jwk.Key, _ Recipient, _ *Message) error {
+func (kp *keySetProvider) selectKey(sink KeySink, key jwk.Key, r Recipient, msg *Message) error {
 	if usage := key.KeyUsage(); usage != "" && usage != jwk.ForEncryption.String() {
 		return nil
 	}
@@ -121,6 +122,31 @@ func (kp *keySetProvider) selectKey(sink KeySink, key jwk.Key, _ Recipient, _ *M
 		return nil
 	}
 
+	if kp.inferAlgorithm {
+		algs, err := AlgorithmsForKey(key)
+		if err != nil {
+			return fmt.Errorf(`failed to get a list of key encryption algorithms for key type %s: %w`, key.KeyType(), err)
+		}
+
+		// The message must tell us which algorithm was used, as trying
+		// all of them would only produce garbage content encryption keys
+		recipientAlg := r.Headers().Algorithm()
+		if recipientAlg == "" && msg != nil {
+			if h := msg.ProtectedHeaders(); h != nil {
+				recipientAlg = h.Algorithm()
+			}
+		}
+		if recipientAlg != "" {
+			for _, alg := range algs {
+				if recipientAlg == alg {
+					sink.Key(alg, key)
+					return nil
+				}
+			}
+		}
+		return fmt.Errorf(`algorithm in the message does not match any of the inferred algorithms`)
+	}
+
 	return nil
 }
 
@@ -159,3 +185,33 @@ type KeyProviderFunc func(context.Context, KeySink, Recipient, *Message) error
 func (kp KeyProviderFunc) FetchKeys(ctx context.Context, sink KeySink, r Recipient, msg *Message) error {
 	return kp(ctx, sink, r, msg)
 }
+
+// SenderKeyProvider is responsible for providing the sender's static
+// public key, which is required to decrypt messages that have been
+// encrypted using ECDH-1PU key agreement.
+//
+// The headers passed to FetchSenderKey are the merged result of the
+// protected headers and the recipient headers, so implementations
+// would typically look up the key using the "skid" header
+// (`(jwe.Headers).SenderKeyID()`).
+//
+// Either a raw public key or a `jwk.Key` may be returned.
+type SenderKeyProvider interface {
+	FetchSenderKey(context.Context, Headers) (interface{}, error)
+}
+
+// SenderKeyProviderFunc is a type of SenderKeyProvider that is implemented
+// by a single function.
+type SenderKeyProviderFunc func(context.Context, Headers) (interface{}, error)
+
+func (kp SenderKeyProviderFunc) FetchSenderKey(ctx context.Context, h Headers) (interface{}, error) {
+	return kp(ctx, h)
+}
+
+type staticSenderKeyProvider struct {
+	key interface{}
+}
+
+func (kp *staticSenderKeyProvider) FetchSenderKey(_ context.Context, _ Headers) (interface{}, error) {
+	return kp.key, nil
+}
@@ -1,6 +1,7 @@
 package jwe
 
 import (
+	"bytes"
 	"context"
 	"fmt"
 	"sort"
@@ -229,7 +230,7 @@ func (m *Message) MarshalJSON() ([]byte, error) {
 
 	var encodedProtectedHeaders []byte
 	if h := m.ProtectedHeaders(); h != nil {
-		v, err := h.Encode()
+		v, err := m.encodeProtectedHeaders(h)
 		if err != nil {
 			return nil, fmt.Errorf(`failed to encode protected headers: %w`, err)
 		}
@@ -433,9 +434,8 @@ func (m *Message) UnmarshalJSON(buf []byte) error {
 	}
 
 	m.protectedHeaders = h
-	if m.storeProtectedHeaders {
-		// this is later used for decryption
-		m.rawProtectedHeaders = base64.Encode(protectedHeadersRaw)
+	if err := m.storeRawProtectedHeaders([]byte(protectedHeadersStr)); err != nil {
+		return err
 	}
 
 	if iz, ok := proxy.UnprotectedHeaders.(isZeroer); ok {
@@ -453,6 +453,36 @@ func (m *Message) UnmarshalJSON(buf []byte) error {
 	return nil
 }
 
+// storeRawProtectedHeaders records the protected headers as they
+// appeared in the parsed message, so that the message can be
+// re-serialized without altering the bytes that the authentication
+// tag was computed over
+func (m *Message) storeRawProtectedHeaders(raw []byte) error {
+	snapshot, err := m.protectedHeaders.Encode()
+	if err != nil {
+		return fmt.Errorf(`failed to encode protected headers: %w`, err)
+	}
+	m.rawProtectedHeaders = append([]byte(nil), raw...)
+	m.protectedHeadersSnapshot = snapshot
+	return nil
+}
+
+// encodeProtectedHeaders encodes the given headers, which should be the
+// protected headers of this message (possibly merged with other headers).
+// If the result is identical to the protected headers that were parsed,
+// the original encoding is used.
+func (m *Message) encodeProtectedHeaders(h Headers) ([]byte, error) {
+	encoded, err := h.Encode()
+	if err != nil {
+		return nil, err
+	}
+
+	if m.rawProtectedHeaders != nil && bytes.Equal(encoded, m.protectedHeadersSnapshot) {
+		return m.rawProtectedHeaders, nil
+	}
+	return encoded, nil
+}
+
 func (m *Message) makeDummyRecipient(enckeybuf string, protected Headers) error {
 	// Recipients in this case should not contain the content encryption key,
 	// so move that out
@@ -517,7 +547,7 @@ func Compact(m *Message, _ ...CompactOption) ([]byte, error) {
 		return nil, fmt.Errorf(`failed to merge recipient header: %w`, err)
 	}
 
-	protected, err := hcopy.Encode()
+	protected, err := m.encodeProtectedHeaders(hcopy)
 	if err != nil {
 		return nil, fmt.Errorf(`failed to encode header: %w`, err)
 	}
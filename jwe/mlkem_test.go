@@ -0,0 +1,155 @@
+//go:build go1.24
+// +build go1.24
+
+package jwe_test
+
+import (
+	"crypto/mlkem"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/json"
+	"testing"
+
+	"github.com/lestrrat-go/jwx/v2/jwa"
+	"github.com/lestrrat-go/jwx/v2/jwe"
+	"github.com/lestrrat-go/jwx/v2/jwk"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEncode_MLKEM(t *testing.T) {
+	t.Parallel()
+
+	dk768, err := mlkem.GenerateKey768()
+	require.NoError(t, err, `mlkem.GenerateKey768 should succeed`)
+	dk1024, err := mlkem.GenerateKey1024()
+	require.NoError(t, err, `mlkem.GenerateKey1024 should succeed`)
+
+	testcases := []struct {
+		Algorithm jwa.KeyEncryptionAlgorithm
+		PrivKey   interface{}
+		PubKey    interface{}
+	}{
+		{Algorithm: jwa.MLKEM768, PrivKey: dk768, PubKey: dk768.EncapsulationKey()},
+		{Algorithm: jwa.MLKEM768_A192KW, PrivKey: dk768, PubKey: dk768.EncapsulationKey()},
+		{Algorithm: jwa.MLKEM1024, PrivKey: dk1024, PubKey: dk1024.EncapsulationKey()},
+		{Algorithm: jwa.MLKEM1024_A256KW, PrivKey: dk1024, PubKey: dk1024.EncapsulationKey()},
+	}
+
+	plaintext := []byte("Lorem ipsum")
+	for _, tc := range testcases {
+		tc := tc
+		t.Run(tc.Algorithm.String(), func(t *testing.T) {
+			t.Parallel()
+			for _, calg := range []jwa.ContentEncryptionAlgorithm{jwa.A128GCM, jwa.A256GCM, jwa.A128CBC_HS256, jwa.A256CBC_HS512} {
+				calg := calg
+				t.Run(calg.String(), func(t *testing.T) {
+					encrypted, err := jwe.Encrypt(plaintext, jwe.WithKey(tc.Algorithm, tc.PubKey), jwe.WithContentEncryption(calg))
+					require.NoError(t, err, `jwe.Encrypt should succeed`)
+
+					msg, err := jwe.Parse(encrypted)
+					require.NoError(t, err, `jwe.Parse should succeed`)
+					require.NotEmpty(t, msg.ProtectedHeaders().EncapsulatedKey(), `"ek" should be populated`)
+					if tc.Algorithm == jwa.MLKEM768 || tc.Algorithm == jwa.MLKEM1024 {
+						require.Empty(t, msg.Recipients()[0].EncryptedKey(), `encrypted key should be empty in direct key agreement mode`)
+					} else {
+						require.NotEmpty(t, msg.Recipients()[0].EncryptedKey(), `encrypted key should not be empty in key wrap mode`)
+					}
+
+					decrypted, err := jwe.Decrypt(encrypted, jwe.WithKey(tc.Algorithm, tc.PrivKey))
+					require.NoError(t, err, `jwe.Decrypt should succeed`)
+					require.Equal(t, plaintext, decrypted, `decrypted payload should match`)
+				})
+			}
+			t.Run("jwk.Key", func(t *testing.T) {
+				privkey, err := jwk.FromRaw(tc.PrivKey)
+				require.NoError(t, err, `jwk.FromRaw should succeed`)
+				pubkey, err := jwk.PublicKeyOf(privkey)
+				require.NoError(t, err, `jwk.PublicKeyOf should succeed`)
+
+				encrypted, err := jwe.Encrypt(plaintext, jwe.WithKey(tc.Algorithm, pubkey))
+				require.NoError(t, err, `jwe.Encrypt should succeed`)
+
+				decrypted, err := jwe.Decrypt(encrypted, jwe.WithKey(tc.Algorithm, privkey))
+				require.NoError(t, err, `jwe.Decrypt should succeed`)
+				require.Equal(t, plaintext, decrypted, `decrypted payload should match`)
+			})
+		})
+	}
+
+	t.Run("AlgorithmsForKey", func(t *testing.T) {
+		t.Parallel()
+		algs, err := jwe.AlgorithmsForKey(dk768.EncapsulationKey())
+		require.NoError(t, err, `jwe.AlgorithmsForKey should succeed`)
+		require.Equal(t, []jwa.KeyEncryptionAlgorithm{jwa.MLKEM768, jwa.MLKEM768_A192KW}, algs, `algorithms should match`)
+
+		key, err := jwk.FromRaw(dk1024)
+		require.NoError(t, err, `jwk.FromRaw should succeed`)
+		algs, err = jwe.AlgorithmsForKey(key)
+		require.NoError(t, err, `jwe.AlgorithmsForKey should succeed`)
+		require.Equal(t, []jwa.KeyEncryptionAlgorithm{jwa.MLKEM1024, jwa.MLKEM1024_A256KW}, algs, `algorithms should match`)
+	})
+	t.Run("Mismatched parameter set", func(t *testing.T) {
+		t.Parallel()
+		_, err := jwe.Encrypt(plaintext, jwe.WithKey(jwa.MLKEM1024, dk768.EncapsulationKey()))
+		require.Error(t, err, `jwe.Encrypt should fail`)
+
+		encrypted, err := jwe.Encrypt(plaintext, jwe.WithKey(jwa.MLKEM768, dk768.EncapsulationKey()))
+		require.NoError(t, err, `jwe.Encrypt should succeed`)
+
+		other, err := mlkem.GenerateKey768()
+		require.NoError(t, err, `mlkem.GenerateKey768 should succeed`)
+		_, err = jwe.Decrypt(encrypted, jwe.WithKey(jwa.MLKEM768, other))
+		require.Error(t, err, `jwe.Decrypt should fail`)
+		_, err = jwe.Decrypt(encrypted, jwe.WithKey(jwa.MLKEM768, dk1024))
+		require.Error(t, err, `jwe.Decrypt should fail`)
+	})
+	t.Run("Multiple recipients", func(t *testing.T) {
+		t.Parallel()
+		rsakey, err := rsa.GenerateKey(rand.Reader, 2048)
+		require.NoError(t, err, `rsa.GenerateKey should succeed`)
+
+		encrypted, err := jwe.Encrypt(
+			plaintext,
+			jwe.WithJSON(),
+			jwe.WithKey(jwa.RSA_OAEP, &rsakey.PublicKey),
+			jwe.WithKey(jwa.MLKEM768_A192KW, dk768.EncapsulationKey()),
+			jwe.WithKey(jwa.MLKEM1024_A256KW, dk1024.EncapsulationKey()),
+		)
+		require.NoError(t, err, `jwe.Encrypt should succeed`)
+
+		var raw struct {
+			Recipients []struct {
+				Header map[string]interface{} `json:"header"`
+			} `json:"recipients"`
+		}
+		require.NoError(t, json.Unmarshal(encrypted, &raw), `json.Unmarshal should succeed`)
+		require.Len(t, raw.Recipients, 3, `there should be 3 recipients`)
+		require.NotContains(t, raw.Recipients[0].Header, jwe.EncapsulatedKeyKey, `RSA-OAEP recipient should not have "ek"`)
+		require.Contains(t, raw.Recipients[1].Header, jwe.EncapsulatedKeyKey, `ML-KEM recipient should have "ek"`)
+		require.Contains(t, raw.Recipients[2].Header, jwe.EncapsulatedKeyKey, `ML-KEM recipient should have "ek"`)
+
+		keys := []struct {
+			Algorithm jwa.KeyEncryptionAlgorithm
+			Key       interface{}
+		}{
+			{Algorithm: jwa.RSA_OAEP, Key: rsakey},
+			{Algorithm: jwa.MLKEM768_A192KW, Key: dk768},
+			{Algorithm: jwa.MLKEM1024_A256KW, Key: dk1024},
+		}
+		for _, key := range keys {
+			decrypted, err := jwe.Decrypt(encrypted, jwe.WithKey(key.Algorithm, key.Key))
+			require.NoError(t, err, `jwe.Decrypt should succeed for %s`, key.Algorithm)
+			require.Equal(t, plaintext, decrypted, `decrypted payload should match`)
+		}
+	})
+	t.Run("Multiple recipients with direct key agreement", func(t *testing.T) {
+		t.Parallel()
+		_, err := jwe.Encrypt(
+			plaintext,
+			jwe.WithJSON(),
+			jwe.WithKey(jwa.MLKEM768, dk768.EncapsulationKey()),
+			jwe.WithKey(jwa.MLKEM1024, dk1024.EncapsulationKey()),
+		)
+		require.Error(t, err, `jwe.Encrypt should fail`)
+	})
+}
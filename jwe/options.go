@@ -70,20 +70,32 @@ func WithKey(alg jwa.KeyAlgorithm, key interface{}, options ...WithKeySuboption)
 
 func WithKeySet(set jwk.Set, options ...WithKeySetSuboption) DecryptOption {
 	requireKid := true
+	var inferAlgorithm bool
 	for _, option := range options {
 		//nolint:forcetypeassert
 		switch option.Ident() {
 		case identRequireKid{}:
 			requireKid = option.Value().(bool)
+		case identInferAlgorithmFromKey{}:
+			inferAlgorithm = option.Value().(bool)
 		}
 	}
 
 	return WithKeyProvider(&keySetProvider{
-		set:        set,
-		requireKid: requireKid,
+		set:            set,
+		requireKid:     requireKid,
+		inferAlgorithm: inferAlgorithm,
 	})
 }
 
+// WithCriticalHeaders specifies the names of header parameters that
+// are understood by the caller, in addition to those registered via
+// `jwe.RegisterCriticalHeader()`. Messages whose "crit" header lists
+// names that are not understood are not decrypted.
+func WithCriticalHeaders(names ...string) DecryptOption {
+	return &decryptOption{option.New(identCriticalHeaders{}, names)}
+}
+
 // WithJSON specifies that the result of `jwe.Encrypt()` is serialized in
 // JSON format.
 //
@@ -5,6 +5,7 @@ package jwe
 import (
 	"io/fs"
 
+	"github.com/lestrrat-go/jwx/v2"
 	"github.com/lestrrat-go/jwx/v2/jwa"
 	"github.com/lestrrat-go/option"
 )
@@ -62,6 +63,36 @@ type encryptOption struct {
 
 func (*encryptOption) encryptOption() {}
 
+// GlobalDecryptOption describes options that can be passed to either
+// `jwe.Settings()` or `jwe.Decrypt()`. When passed to `jwe.Settings()`,
+// the option changes the default value used by all subsequent calls
+// to `jwe.Decrypt()`.
+type GlobalDecryptOption interface {
+	Option
+	globalOption()
+	decryptOption()
+}
+
+type globalDecryptOption struct {
+	Option
+}
+
+func (*globalDecryptOption) globalOption() {}
+
+func (*globalDecryptOption) decryptOption() {}
+
+// GlobalOption describes options that can be passed to `jwe.Settings()`
+type GlobalOption interface {
+	Option
+	globalOption()
+}
+
+type globalOption struct {
+	Option
+}
+
+func (*globalOption) globalOption() {}
+
 // ReadFileOption is a type of `Option` that can be passed to `jwe.Parse`
 type ParseOption interface {
 	Option
@@ -112,16 +143,24 @@ func (*withKeySetSuboption) withKeySetSuboption() {}
 
 type identCompress struct{}
 type identContentEncryptionAlgorithm struct{}
+type identCriticalHeaders struct{}
 type identFS struct{}
+type identInferAlgorithmFromKey struct{}
 type identKey struct{}
 type identKeyProvider struct{}
 type identKeyUsed struct{}
+type identMaxDecompressedSize struct{}
+type identMaxPBES2Count struct{}
+type identMaxRecipients struct{}
 type identMergeProtectedHeaders struct{}
 type identMessage struct{}
 type identPerRecipientHeaders struct{}
+type identPolicy struct{}
 type identPretty struct{}
 type identProtectedHeaders struct{}
 type identRequireKid struct{}
+type identSenderKey struct{}
+type identSenderKeyProvider struct{}
 type identSerialization struct{}
 
 func (identCompress) String() string {
@@ -132,10 +171,18 @@ func (identContentEncryptionAlgorithm) String() string {
 	return "WithContentEncryption"
 }
 
+func (identCriticalHeaders) String() string {
+	return "WithCriticalHeaders"
+}
+
 func (identFS) String() string {
 	return "WithFS"
 }
 
+func (identInferAlgorithmFromKey) String() string {
+	return "WithInferAlgorithmFromKey"
+}
+
 func (identKey) String() string {
 	return "WithKey"
 }
@@ -148,6 +195,18 @@ func (identKeyUsed) String() string {
 	return "WithKeyUsed"
 }
 
+func (identMaxDecompressedSize) String() string {
+	return "WithMaxDecompressedSize"
+}
+
+func (identMaxPBES2Count) String() string {
+	return "WithMaxPBES2Count"
+}
+
+func (identMaxRecipients) String() string {
+	return "WithMaxRecipients"
+}
+
 func (identMergeProtectedHeaders) String() string {
 	return "WithMergeProtectedHeaders"
 }
@@ -160,6 +219,10 @@ func (identPerRecipientHeaders) String() string {
 	return "WithPerRecipientHeaders"
 }
 
+func (identPolicy) String() string {
+	return "WithPolicy"
+}
+
 func (identPretty) String() string {
 	return "WithPretty"
 }
@@ -172,6 +235,14 @@ func (identRequireKid) String() string {
 	return "WithRequireKid"
 }
 
+func (identSenderKey) String() string {
+	return "WithSenderKey"
+}
+
+func (identSenderKeyProvider) String() string {
+	return "WithSenderKeyProvider"
+}
+
 func (identSerialization) String() string {
 	return "WithSerialization"
 }
@@ -186,6 +257,9 @@ func WithCompress(v jwa.CompressionAlgorithm) EncryptOption {
 
 // WithContentEncryptionAlgorithm specifies the algorithm to encrypt the
 // JWE message content with. If not provided, `jwa.A256GCM` is used.
+//
+// This option is ignored when an HPKE integrated encryption algorithm
+// (e.g. `jwa.HPKE_0`) is used, as the content is encrypted by HPKE itself.
 func WithContentEncryption(v jwa.ContentEncryptionAlgorithm) EncryptOption {
 	return &encryptOption{option.New(identContentEncryptionAlgorithm{}, v)}
 }
@@ -195,6 +269,17 @@ func WithFS(v fs.FS) ReadFileOption {
 	return &readFileOption{option.New(identFS{}, v)}
 }
 
+// WithInferAlgorithmFromKey specifies whether the key encryption algorithm
+// should be inferred by looking at the provided key, in case the key
+// does not have a proper `alg` field.
+//
+// When enabled, keys without an `alg` field are used if the algorithm
+// in the recipient's `alg` header is one of the algorithms returned
+// by `jwe.AlgorithmsForKey()` for that key.
+func WithInferAlgorithmFromKey(v bool) WithKeySetSuboption {
+	return &withKeySetSuboption{option.New(identInferAlgorithmFromKey{}, v)}
+}
+
 func WithKeyProvider(v KeyProvider) DecryptOption {
 	return &decryptOption{option.New(identKeyProvider{}, v)}
 }
@@ -213,6 +298,39 @@ func WithKeyUsed(v interface{}) DecryptOption {
 	return &decryptOption{option.New(identKeyUsed{}, v)}
 }
 
+// WithMaxDecompressedSize specifies the maximum size in bytes of the
+// payload after decompression, for messages that were compressed using
+// `zip: DEF`. When the payload is larger, decompression is aborted and the
+// error can be matched against `jwe.ErrMaxDecompressedSizeExceeded()`
+// using `errors.Is()`.
+//
+// The default value is 10MB. A value less than or equal to 0 removes the limit.
+func WithMaxDecompressedSize(v int64) GlobalDecryptOption {
+	return &globalDecryptOption{option.New(identMaxDecompressedSize{}, v)}
+}
+
+// WithMaxPBES2Count specifies the maximum value of the `p2c` (PBES2
+// iteration count) header that is accepted by `jwe.Decrypt()`. Messages
+// with larger values are rejected before the key derivation is performed,
+// and the error can be matched against `jwe.ErrMaxPBES2CountExceeded()`
+// using `errors.Is()`.
+//
+// The default value is 10000. A value less than or equal to 0 removes the limit.
+func WithMaxPBES2Count(v int) GlobalDecryptOption {
+	return &globalDecryptOption{option.New(identMaxPBES2Count{}, v)}
+}
+
+// WithMaxRecipients specifies the maximum number of recipients that
+// a message may contain in order to be processed by `jwe.Decrypt()`.
+// Messages with more recipients are rejected before any decryption is
+// attempted, and the error can be matched against `jwe.ErrMaxRecipientsExceeded()`
+// using `errors.Is()`.
+//
+// By default there is no limit. A value less than or equal to 0 removes the limit.
+func WithMaxRecipients(v int) GlobalDecryptOption {
+	return &globalDecryptOption{option.New(identMaxRecipients{}, v)}
+}
+
 // WithMergeProtectedHeaders specify that when given multiple headers
 // as options to `jwe.Encrypt`, these headers should be merged instead
 // of overwritten
@@ -232,6 +350,18 @@ func WithMessage(v *Message) DecryptOption {
 	return &decryptOption{option.New(identMessage{}, v)}
 }
 
+// WithPolicy specifies the `jwx.Policy` to enforce when encrypting or
+// decrypting, in place of the policy installed via `jwx.SetDefaultPolicy()`.
+//
+// When encrypting, an error is returned if the content encryption
+// algorithm or any of the key encryption algorithm and key pairs violate
+// the policy. When decrypting, an error is returned if the content
+// encryption algorithm violates the policy, and key encryption algorithm
+// and key pairs that violate the policy are not used.
+func WithPolicy(v *jwx.Policy) EncryptDecryptOption {
+	return &encryptDecryptOption{option.New(identPolicy{}, v)}
+}
+
 // WithPretty specifies whether the JSON output should be formatted and
 // indented
 func WithPretty(v bool) WithJSONSuboption {
@@ -245,6 +375,30 @@ func WithRequireKid(v bool) WithKeySetSuboption {
 	return &withKeySetSuboption{option.New(identRequireKid{}, v)}
 }
 
+// WithSenderKey specifies the sender's static key for ECDH-1PU key
+// agreement (`jwa.ECDH_1PU` and its key wrapping variants).
+//
+// When passed to `jwe.Encrypt()`, `v` must be the sender's private key.
+// If `v` is a `jwk.Key` with a key ID, the "skid" header is populated
+// using its value, unless it has already been specified.
+//
+// When passed to `jwe.Decrypt()`, `v` must be the sender's public key.
+// This is a shorthand for specifying a `jwe.SenderKeyProvider` that
+// always returns `v`.
+//
+// Either a raw key or `jwk.Key` may be passed as `v`.
+func WithSenderKey(v interface{}) EncryptDecryptOption {
+	return &encryptDecryptOption{option.New(identSenderKey{}, v)}
+}
+
+// WithSenderKeyProvider specifies the `jwe.SenderKeyProvider` to be used
+// to resolve the sender's static public key when decrypting messages
+// using ECDH-1PU key agreement. Providers are tried in the order they
+// are specified, along with any key specified via `jwe.WithSenderKey()`.
+func WithSenderKeyProvider(v SenderKeyProvider) DecryptOption {
+	return &decryptOption{option.New(identSenderKeyProvider{}, v)}
+}
+
 // WithCompact specifies that the result of `jwe.Encrypt()` is serialized in
 // compact format.
 //
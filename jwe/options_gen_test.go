@@ -11,15 +11,23 @@ import (
 func TestOptionIdent(t *testing.T) {
 	require.Equal(t, "WithCompress", identCompress{}.String())
 	require.Equal(t, "WithContentEncryption", identContentEncryptionAlgorithm{}.String())
+	require.Equal(t, "WithCriticalHeaders", identCriticalHeaders{}.String())
 	require.Equal(t, "WithFS", identFS{}.String())
+	require.Equal(t, "WithInferAlgorithmFromKey", identInferAlgorithmFromKey{}.String())
 	require.Equal(t, "WithKey", identKey{}.String())
 	require.Equal(t, "WithKeyProvider", identKeyProvider{}.String())
 	require.Equal(t, "WithKeyUsed", identKeyUsed{}.String())
+	require.Equal(t, "WithMaxDecompressedSize", identMaxDecompressedSize{}.String())
+	require.Equal(t, "WithMaxPBES2Count", identMaxPBES2Count{}.String())
+	require.Equal(t, "WithMaxRecipients", identMaxRecipients{}.String())
 	require.Equal(t, "WithMergeProtectedHeaders", identMergeProtectedHeaders{}.String())
 	require.Equal(t, "WithMessage", identMessage{}.String())
 	require.Equal(t, "WithPerRecipientHeaders", identPerRecipientHeaders{}.String())
+	require.Equal(t, "WithPolicy", identPolicy{}.String())
 	require.Equal(t, "WithPretty", identPretty{}.String())
 	require.Equal(t, "WithProtectedHeaders", identProtectedHeaders{}.String())
 	require.Equal(t, "WithRequireKid", identRequireKid{}.String())
+	require.Equal(t, "WithSenderKey", identSenderKey{}.String())
+	require.Equal(t, "WithSenderKeyProvider", identSenderKeyProvider{}.String())
 	require.Equal(t, "WithSerialization", identSerialization{}.String())
 }
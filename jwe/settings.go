@@ -0,0 +1,83 @@
+package jwe
+
+import (
+	"errors"
+	"sync/atomic"
+)
+
+const (
+	defaultMaxPBES2Count       = 10000
+	defaultMaxDecompressedSize = 10 * 1024 * 1024 // 10MB
+)
+
+var maxPBES2Count int64 = defaultMaxPBES2Count
+var maxDecompressedSize int64 = defaultMaxDecompressedSize
+var maxRecipients int64
+
+// Settings controls global settings that are specific to JWE messages.
+//
+// The limits that are applied to untrusted input in `jwe.Decrypt()` can
+// be changed by passing `jwe.WithMaxPBES2Count()`, `jwe.WithMaxDecompressedSize()`,
+// and `jwe.WithMaxRecipients()`. The same options may also be passed to
+// `jwe.Decrypt()` directly, in which case they take precedence over the
+// values specified here.
+func Settings(options ...GlobalOption) {
+	//nolint:forcetypeassert
+	for _, option := range options {
+		switch option.Ident() {
+		case identMaxPBES2Count{}:
+			atomic.StoreInt64(&maxPBES2Count, int64(option.Value().(int)))
+		case identMaxDecompressedSize{}:
+			atomic.StoreInt64(&maxDecompressedSize, option.Value().(int64))
+		case identMaxRecipients{}:
+			atomic.StoreInt64(&maxRecipients, int64(option.Value().(int)))
+		}
+	}
+}
+
+// decryptLimits holds the limits that are applied in `jwe.Decrypt()`.
+// Values less than or equal to 0 mean that there is no limit
+type decryptLimits struct {
+	maxPBES2Count       int64
+	maxDecompressedSize int64
+	maxRecipients       int64
+}
+
+func defaultDecryptLimits() decryptLimits {
+	return decryptLimits{
+		maxPBES2Count:       atomic.LoadInt64(&maxPBES2Count),
+		maxDecompressedSize: atomic.LoadInt64(&maxDecompressedSize),
+		maxRecipients:       atomic.LoadInt64(&maxRecipients),
+	}
+}
+
+var errMaxPBES2CountExceeded = errors.New(`p2c value exceeds the maximum allowed PBES2 count`)
+var errMaxDecompressedSizeExceeded = errors.New(`decompressed payload exceeds the maximum allowed size`)
+var errMaxRecipientsExceeded = errors.New(`number of recipients exceeds the maximum allowed`)
+
+// ErrMaxPBES2CountExceeded returns the opaque error value that is returned
+// when `jwe.Decrypt()` encounters a `p2c` value larger than the limit
+// specified by `jwe.WithMaxPBES2Count()`.
+//
+// The return value should only be used for comparison using `errors.Is()`
+func ErrMaxPBES2CountExceeded() error {
+	return errMaxPBES2CountExceeded
+}
+
+// ErrMaxDecompressedSizeExceeded returns the opaque error value that is
+// returned when `jwe.Decrypt()` decompresses a payload that is larger
+// than the limit specified by `jwe.WithMaxDecompressedSize()`.
+//
+// The return value should only be used for comparison using `errors.Is()`
+func ErrMaxDecompressedSizeExceeded() error {
+	return errMaxDecompressedSizeExceeded
+}
+
+// ErrMaxRecipientsExceeded returns the opaque error value that is returned
+// when `jwe.Decrypt()` encounters a message with more recipients than the
+// limit specified by `jwe.WithMaxRecipients()`.
+//
+// The return value should only be used for comparison using `errors.Is()`
+func ErrMaxRecipientsExceeded() error {
+	return errMaxRecipientsExceeded
+}
@@ -0,0 +1,223 @@
+package jwk
+
+import (
+	"bytes"
+	"crypto"
+	"fmt"
+	"io"
+	"reflect"
+	"sync"
+
+	"github.com/lestrrat-go/blackmagic"
+	"github.com/lestrrat-go/jwx/v2/internal/base64"
+	"github.com/lestrrat-go/jwx/v2/jwa"
+)
+
+// AKP (Algorithm Key Pair) keys do not carry a curve or other parameters
+// that describe the raw key. Instead, the format of the "pub" and "priv"
+// members are determined by the value of "alg". Therefore conversion
+// between raw keys and AKP keys is delegated to an akpAlgorithm, which
+// is registered for each algorithm that is supported in the current build.
+type akpAlgorithm struct {
+	alg jwa.KeyAlgorithm
+	// fromRaw returns the public key and private key (seed) encodings for
+	// the given raw key. priv is nil if the key is a public key. ok is
+	// false if the raw key is not handled by this algorithm. It may be
+	// nil for algorithms that share their raw key types with another
+	// algorithm, in which case the key can only be created from JSON.
+	fromRaw func(interface{}) (pub, priv []byte, ok bool)
+	// publicKey creates a raw public key from its encoding
+	publicKey func([]byte) (interface{}, error)
+	// privateKey creates a raw private key from its encoding, and returns
+	// the raw key along with its public key encoding
+	privateKey func([]byte) (interface{}, []byte, error)
+	// generate creates a new raw private key
+	generate func(io.Reader) (interface{}, error)
+}
+
+var muAKPAlgorithms sync.RWMutex
+var akpAlgorithms = make(map[string]*akpAlgorithm)
+
+func registerAKPAlgorithm(alg jwa.KeyAlgorithm, v *akpAlgorithm) {
+	muAKPAlgorithms.Lock()
+	defer muAKPAlgorithms.Unlock()
+	v.alg = alg
+	akpAlgorithms[alg.String()] = v
+}
+
+func lookupAKPAlgorithm(alg jwa.KeyAlgorithm) (*akpAlgorithm, error) {
+	if alg.String() == "" {
+		return nil, fmt.Errorf(`required field alg is missing`)
+	}
+	muAKPAlgorithms.RLock()
+	defer muAKPAlgorithms.RUnlock()
+	v, ok := akpAlgorithms[alg.String()]
+	if !ok {
+		return nil, fmt.Errorf(`unsupported algorithm for AKP key: %s`, alg)
+	}
+	return v, nil
+}
+
+// akpFromRaw looks for an algorithm that can handle the given raw key,
+// and returns the algorithm along with the key encodings.
+func akpFromRaw(rawKey interface{}) (jwa.KeyAlgorithm, []byte, []byte, bool) {
+	muAKPAlgorithms.RLock()
+	defer muAKPAlgorithms.RUnlock()
+	for _, v := range akpAlgorithms {
+		if v.fromRaw == nil {
+			continue
+		}
+		if pub, priv, ok := v.fromRaw(rawKey); ok {
+			return v.alg, pub, priv, true
+		}
+	}
+	return nil, nil, nil, false
+}
+
+func (k *akpPublicKey) FromRaw(rawKey interface{}) error {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+
+	alg, pub, _, ok := akpFromRaw(rawKey)
+	if !ok {
+		return fmt.Errorf(`unknown key type %T`, rawKey)
+	}
+
+	k.algorithm = &alg
+	k.pub = pub
+	return nil
+}
+
+func (k *akpPrivateKey) FromRaw(rawKey interface{}) error {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+
+	alg, pub, priv, ok := akpFromRaw(rawKey)
+	if !ok || priv == nil {
+		return fmt.Errorf(`unknown key type %T`, rawKey)
+	}
+
+	k.algorithm = &alg
+	k.pub = pub
+	k.priv = priv
+	return nil
+}
+
+// Raw returns the raw public key represented by this JWK. The type
+// of the raw key depends on the value of the "alg" field.
+func (k *akpPublicKey) Raw(v interface{}) error {
+	k.mu.RLock()
+	defer k.mu.RUnlock()
+
+	h, err := lookupAKPAlgorithm(k.Algorithm())
+	if err != nil {
+		return fmt.Errorf(`failed to build public key: %w`, err)
+	}
+
+	pubk, err := h.publicKey(k.pub)
+	if err != nil {
+		return fmt.Errorf(`failed to build public key: %w`, err)
+	}
+
+	return assignAKPRawKey(v, pubk)
+}
+
+// Raw returns the raw private key represented by this JWK. The type
+// of the raw key depends on the value of the "alg" field.
+func (k *akpPrivateKey) Raw(v interface{}) error {
+	k.mu.RLock()
+	defer k.mu.RUnlock()
+
+	h, err := lookupAKPAlgorithm(k.Algorithm())
+	if err != nil {
+		return fmt.Errorf(`failed to build private key: %w`, err)
+	}
+
+	privk, pub, err := h.privateKey(k.priv)
+	if err != nil {
+		return fmt.Errorf(`failed to build private key: %w`, err)
+	}
+
+	if !bytes.Equal(k.pub, pub) {
+		return fmt.Errorf(`invalid pub value given priv value`)
+	}
+
+	return assignAKPRawKey(v, privk)
+}
+
+// assignAKPRawKey assigns the raw key src to dst. Raw AKP keys are
+// pointers to opaque types that should not be copied by value, so on top
+// of what blackmagic.AssignIfCompatible accepts, dst may also be a pointer
+// to a variable of the same pointer type (e.g. **mldsa.PrivateKey)
+func assignAKPRawKey(dst, src interface{}) error {
+	if rv := reflect.ValueOf(dst); rv.Kind() == reflect.Ptr && !rv.IsNil() {
+		if elem := rv.Elem(); elem.Kind() == reflect.Ptr && reflect.TypeOf(src).AssignableTo(elem.Type()) {
+			elem.Set(reflect.ValueOf(src))
+			return nil
+		}
+	}
+	return blackmagic.AssignIfCompatible(dst, src)
+}
+
+func makeAKPPublicKey(v interface {
+	makePairs() []*HeaderPair
+}) (Key, error) {
+	newKey := newAKPPublicKey()
+
+	// Iterate and copy everything except for the bits that should not be in the public key
+	for _, pair := range v.makePairs() {
+		switch pair.Key {
+		case AKPPrivKey:
+			continue
+		default:
+			//nolint:forcetypeassert
+			key := pair.Key.(string)
+			if err := newKey.Set(key, pair.Value); err != nil {
+				return nil, fmt.Errorf(`failed to set field %q: %w`, key, err)
+			}
+		}
+	}
+
+	return newKey, nil
+}
+
+func (k *akpPrivateKey) PublicKey() (Key, error) {
+	return makeAKPPublicKey(k)
+}
+
+func (k *akpPublicKey) PublicKey() (Key, error) {
+	return makeAKPPublicKey(k)
+}
+
+func akpThumbprint(hash crypto.Hash, alg jwa.KeyAlgorithm, pub string) ([]byte, error) {
+	if alg.String() == "" {
+		return nil, fmt.Errorf(`required field alg is missing`)
+	}
+	h := hash.New()
+	fmt.Fprint(h, `{"alg":"`)
+	fmt.Fprint(h, alg.String())
+	fmt.Fprint(h, `","kty":"AKP","pub":"`)
+	fmt.Fprint(h, pub)
+	fmt.Fprint(h, `"}`)
+	return h.Sum(nil), nil
+}
+
+// Thumbprint returns the JWK thumbprint using the indicated
+// hashing algorithm. For AKP keys, the required members are
+// "alg", "kty", and "pub".
+func (k akpPublicKey) Thumbprint(hash crypto.Hash) ([]byte, error) {
+	k.mu.RLock()
+	defer k.mu.RUnlock()
+
+	return akpThumbprint(hash, k.Algorithm(), base64.EncodeToString(k.pub))
+}
+
+// Thumbprint returns the JWK thumbprint using the indicated
+// hashing algorithm. For AKP keys, the required members are
+// "alg", "kty", and "pub".
+func (k akpPrivateKey) Thumbprint(hash crypto.Hash) ([]byte, error) {
+	k.mu.RLock()
+	defer k.mu.RUnlock()
+
+	return akpThumbprint(hash, k.Algorithm(), base64.EncodeToString(k.pub))
+}
@@ -0,0 +1,61 @@
+//go:build go1.27
+// +build go1.27
+
+package jwk
+
+import (
+	"crypto/mldsa"
+	"fmt"
+	"io"
+
+	"github.com/lestrrat-go/jwx/v2/jwa"
+)
+
+func init() {
+	registerAKPAlgorithm(jwa.MLDSA44, newMLDSAAlgorithm(mldsa.MLDSA44()))
+	registerAKPAlgorithm(jwa.MLDSA65, newMLDSAAlgorithm(mldsa.MLDSA65()))
+	registerAKPAlgorithm(jwa.MLDSA87, newMLDSAAlgorithm(mldsa.MLDSA87()))
+}
+
+// newMLDSAAlgorithm creates the AKP conversion routines for the given
+// ML-DSA parameter set. "pub" holds the encoded public key, and "priv"
+// holds the 32 byte seed from which the private key is derived.
+func newMLDSAAlgorithm(params mldsa.Parameters) *akpAlgorithm {
+	return &akpAlgorithm{
+		fromRaw: func(rawKey interface{}) ([]byte, []byte, bool) {
+			switch rawKey := rawKey.(type) {
+			case *mldsa.PrivateKey:
+				pubkey := rawKey.PublicKey()
+				if pubkey.Parameters() != params {
+					return nil, nil, false
+				}
+				return pubkey.Bytes(), rawKey.Bytes(), true
+			case *mldsa.PublicKey:
+				if rawKey.Parameters() != params {
+					return nil, nil, false
+				}
+				return rawKey.Bytes(), nil, true
+			default:
+				return nil, nil, false
+			}
+		},
+		publicKey: func(pub []byte) (interface{}, error) {
+			v, err := mldsa.NewPublicKey(params, pub)
+			if err != nil {
+				return nil, fmt.Errorf(`failed to create %s public key: %w`, params, err)
+			}
+			return v, nil
+		},
+		privateKey: func(priv []byte) (interface{}, []byte, error) {
+			v, err := mldsa.NewPrivateKey(params, priv)
+			if err != nil {
+				return nil, nil, fmt.Errorf(`failed to create %s private key: %w`, params, err)
+			}
+			return v, v.PublicKey().Bytes(), nil
+		},
+		generate: func(io.Reader) (interface{}, error) {
+			// crypto/mldsa always uses crypto/rand
+			return mldsa.GenerateKey(params)
+		},
+	}
+}
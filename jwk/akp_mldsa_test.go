@@ -0,0 +1,168 @@
+//go:build go1.27
+// +build go1.27
+
+package jwk_test
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/mldsa"
+	"crypto/sha256"
+	"encoding/json"
+	"testing"
+
+	"github.com/lestrrat-go/jwx/v2/internal/base64"
+	"github.com/lestrrat-go/jwx/v2/jwa"
+	"github.com/lestrrat-go/jwx/v2/jwk"
+	"github.com/stretchr/testify/require"
+)
+
+func requireSameAKPKey(t *testing.T, expected, actual jwk.Key) {
+	t.Helper()
+	expectedJSON, err := json.Marshal(expected)
+	require.NoError(t, err, `json.Marshal should succeed`)
+	actualJSON, err := json.Marshal(actual)
+	require.NoError(t, err, `json.Marshal should succeed`)
+	require.JSONEq(t, string(expectedJSON), string(actualJSON), `keys should match`)
+}
+
+func TestAKP(t *testing.T) {
+	t.Parallel()
+
+	testcases := []struct {
+		Algorithm  jwa.SignatureAlgorithm
+		Parameters mldsa.Parameters
+	}{
+		{Algorithm: jwa.MLDSA44, Parameters: mldsa.MLDSA44()},
+		{Algorithm: jwa.MLDSA65, Parameters: mldsa.MLDSA65()},
+		{Algorithm: jwa.MLDSA87, Parameters: mldsa.MLDSA87()},
+	}
+
+	for _, tc := range testcases {
+		tc := tc
+		t.Run(tc.Algorithm.String(), func(t *testing.T) {
+			t.Parallel()
+			seed := bytes.Repeat([]byte{0x42}, mldsa.PrivateKeySize)
+			raw, err := mldsa.NewPrivateKey(tc.Parameters, seed)
+			require.NoError(t, err, `mldsa.NewPrivateKey should succeed`)
+
+			key, err := jwk.FromRaw(raw)
+			require.NoError(t, err, `jwk.FromRaw should succeed`)
+			require.Equal(t, jwa.AKP, key.KeyType(), `key type should be AKP`)
+			require.Equal(t, tc.Algorithm.String(), key.Algorithm().String(), `alg should match`)
+
+			privkey, ok := key.(jwk.AKPPrivateKey)
+			require.True(t, ok, `key should be a jwk.AKPPrivateKey (%T)`, key)
+			require.Equal(t, seed, privkey.Priv(), `priv should be the seed`)
+			require.Equal(t, raw.PublicKey().Bytes(), privkey.Pub(), `pub should be the public key encoding`)
+
+			t.Run("Raw", func(t *testing.T) {
+				var rawPriv *mldsa.PrivateKey
+				require.NoError(t, key.Raw(&rawPriv), `key.Raw(**mldsa.PrivateKey) should succeed`)
+				require.True(t, raw.Equal(rawPriv), `raw keys should match`)
+
+				var iface interface{}
+				require.NoError(t, key.Raw(&iface), `key.Raw(*interface{}) should succeed`)
+				require.True(t, raw.Equal(iface.(crypto.PrivateKey)), `raw keys should match`)
+
+				pubkey, err := jwk.PublicRawKeyOf(raw)
+				require.NoError(t, err, `jwk.PublicRawKeyOf should succeed`)
+				require.True(t, raw.PublicKey().Equal(pubkey), `raw public keys should match`)
+			})
+			t.Run("JSON", func(t *testing.T) {
+				buf, err := json.Marshal(key)
+				require.NoError(t, err, `json.Marshal should succeed`)
+
+				parsed, err := jwk.ParseKey(buf)
+				require.NoError(t, err, `jwk.ParseKey should succeed`)
+				_, ok := parsed.(jwk.AKPPrivateKey)
+				require.True(t, ok, `parsed key should be a jwk.AKPPrivateKey (%T)`, parsed)
+				requireSameAKPKey(t, key, parsed)
+
+				pubkey, err := jwk.PublicKeyOf(key)
+				require.NoError(t, err, `jwk.PublicKeyOf should succeed`)
+				_, ok = pubkey.(jwk.AKPPublicKey)
+				require.True(t, ok, `public key should be a jwk.AKPPublicKey (%T)`, pubkey)
+				_, ok = pubkey.Get(jwk.AKPPrivKey)
+				require.False(t, ok, `public key should not have priv`)
+
+				buf, err = json.Marshal(pubkey)
+				require.NoError(t, err, `json.Marshal should succeed`)
+				parsed, err = jwk.ParseKey(buf)
+				require.NoError(t, err, `jwk.ParseKey should succeed`)
+				_, ok = parsed.(jwk.AKPPublicKey)
+				require.True(t, ok, `parsed key should be a jwk.AKPPublicKey (%T)`, parsed)
+
+				var rawPub *mldsa.PublicKey
+				require.NoError(t, parsed.Raw(&rawPub), `key.Raw(**mldsa.PublicKey) should succeed`)
+				require.True(t, raw.PublicKey().Equal(rawPub), `raw public keys should match`)
+			})
+			t.Run("Thumbprint", func(t *testing.T) {
+				expected := sha256.Sum256([]byte(`{"alg":"` + tc.Algorithm.String() + `","kty":"AKP","pub":"` + base64.EncodeToString(raw.PublicKey().Bytes()) + `"}`))
+
+				tp, err := key.Thumbprint(crypto.SHA256)
+				require.NoError(t, err, `key.Thumbprint should succeed`)
+				require.Equal(t, expected[:], tp, `thumbprints should match`)
+
+				pubkey, err := jwk.PublicKeyOf(key)
+				require.NoError(t, err, `jwk.PublicKeyOf should succeed`)
+				tp, err = pubkey.Thumbprint(crypto.SHA256)
+				require.NoError(t, err, `key.Thumbprint should succeed`)
+				require.Equal(t, expected[:], tp, `thumbprints should match`)
+			})
+			t.Run("PEM", func(t *testing.T) {
+				pubkey, err := jwk.PublicKeyOf(key)
+				require.NoError(t, err, `jwk.PublicKeyOf should succeed`)
+				for _, k := range []jwk.Key{key, pubkey} {
+					pem, err := jwk.EncodePEM(k)
+					require.NoError(t, err, `jwk.EncodePEM should succeed`)
+
+					parsed, err := jwk.ParseKey(pem, jwk.WithPEM(true))
+					require.NoError(t, err, `jwk.ParseKey should succeed`)
+					requireSameAKPKey(t, k, parsed)
+				}
+			})
+			t.Run("Generate", func(t *testing.T) {
+				generated, err := jwk.Generate(jwa.AKP, jwk.WithAlgorithm(tc.Algorithm))
+				require.NoError(t, err, `jwk.Generate should succeed`)
+				var rawPriv *mldsa.PrivateKey
+				require.NoError(t, generated.Raw(&rawPriv), `key.Raw(**mldsa.PrivateKey) should succeed`)
+				require.Equal(t, tc.Parameters, rawPriv.PublicKey().Parameters(), `parameters should match`)
+			})
+		})
+	}
+
+	t.Run("Mismatched pub", func(t *testing.T) {
+		t.Parallel()
+		raw, err := mldsa.GenerateKey(mldsa.MLDSA44())
+		require.NoError(t, err, `mldsa.GenerateKey should succeed`)
+		other, err := mldsa.GenerateKey(mldsa.MLDSA44())
+		require.NoError(t, err, `mldsa.GenerateKey should succeed`)
+
+		key, err := jwk.FromRaw(raw)
+		require.NoError(t, err, `jwk.FromRaw should succeed`)
+		require.NoError(t, key.Set(jwk.AKPPubKey, other.PublicKey().Bytes()), `key.Set should succeed`)
+
+		var rawPriv *mldsa.PrivateKey
+		require.Error(t, key.Raw(&rawPriv), `key.Raw should fail`)
+	})
+	t.Run("Missing alg", func(t *testing.T) {
+		t.Parallel()
+		raw, err := mldsa.GenerateKey(mldsa.MLDSA44())
+		require.NoError(t, err, `mldsa.GenerateKey should succeed`)
+
+		key, err := jwk.FromRaw(raw)
+		require.NoError(t, err, `jwk.FromRaw should succeed`)
+		require.NoError(t, key.Remove(jwk.AlgorithmKey), `key.Remove should succeed`)
+
+		var rawPriv *mldsa.PrivateKey
+		require.Error(t, key.Raw(&rawPriv), `key.Raw should fail`)
+		_, err = key.Thumbprint(crypto.SHA256)
+		require.Error(t, err, `key.Thumbprint should fail`)
+	})
+	t.Run("Generate without alg", func(t *testing.T) {
+		t.Parallel()
+		_, err := jwk.Generate(jwa.AKP)
+		require.Error(t, err, `jwk.Generate should fail`)
+	})
+}
@@ -0,0 +1,105 @@
+//go:build go1.24
+// +build go1.24
+
+package jwk
+
+import (
+	"crypto/mlkem"
+	"fmt"
+	"io"
+
+	"github.com/lestrrat-go/jwx/v2/jwa"
+)
+
+func init() {
+	mlkem768 := newMLKEM768Algorithm()
+	mlkem1024 := newMLKEM1024Algorithm()
+	registerAKPAlgorithm(jwa.MLKEM768, mlkem768)
+	registerAKPAlgorithm(jwa.MLKEM1024, mlkem1024)
+
+	// The key wrapping variants use the same raw keys. They are registered
+	// so that keys with these "alg" values can be parsed, but jwk.FromRaw()
+	// always uses the direct key agreement algorithm names.
+	registerAKPAlgorithm(jwa.MLKEM768_A192KW, &akpAlgorithm{
+		publicKey:  mlkem768.publicKey,
+		privateKey: mlkem768.privateKey,
+		generate:   mlkem768.generate,
+	})
+	registerAKPAlgorithm(jwa.MLKEM1024_A256KW, &akpAlgorithm{
+		publicKey:  mlkem1024.publicKey,
+		privateKey: mlkem1024.privateKey,
+		generate:   mlkem1024.generate,
+	})
+}
+
+// newMLKEM768Algorithm creates the AKP conversion routines for ML-KEM-768.
+// "pub" holds the encoded encapsulation key, and "priv" holds the 64 byte
+// seed from which the decapsulation key is derived.
+func newMLKEM768Algorithm() *akpAlgorithm {
+	return &akpAlgorithm{
+		fromRaw: func(rawKey interface{}) ([]byte, []byte, bool) {
+			switch rawKey := rawKey.(type) {
+			case *mlkem.DecapsulationKey768:
+				return rawKey.EncapsulationKey().Bytes(), rawKey.Bytes(), true
+			case *mlkem.EncapsulationKey768:
+				return rawKey.Bytes(), nil, true
+			default:
+				return nil, nil, false
+			}
+		},
+		publicKey: func(pub []byte) (interface{}, error) {
+			v, err := mlkem.NewEncapsulationKey768(pub)
+			if err != nil {
+				return nil, fmt.Errorf(`failed to create ML-KEM-768 encapsulation key: %w`, err)
+			}
+			return v, nil
+		},
+		privateKey: func(priv []byte) (interface{}, []byte, error) {
+			v, err := mlkem.NewDecapsulationKey768(priv)
+			if err != nil {
+				return nil, nil, fmt.Errorf(`failed to create ML-KEM-768 decapsulation key: %w`, err)
+			}
+			return v, v.EncapsulationKey().Bytes(), nil
+		},
+		generate: func(io.Reader) (interface{}, error) {
+			// crypto/mlkem always uses crypto/rand
+			return mlkem.GenerateKey768()
+		},
+	}
+}
+
+// newMLKEM1024Algorithm creates the AKP conversion routines for ML-KEM-1024.
+// "pub" holds the encoded encapsulation key, and "priv" holds the 64 byte
+// seed from which the decapsulation key is derived.
+func newMLKEM1024Algorithm() *akpAlgorithm {
+	return &akpAlgorithm{
+		fromRaw: func(rawKey interface{}) ([]byte, []byte, bool) {
+			switch rawKey := rawKey.(type) {
+			case *mlkem.DecapsulationKey1024:
+				return rawKey.EncapsulationKey().Bytes(), rawKey.Bytes(), true
+			case *mlkem.EncapsulationKey1024:
+				return rawKey.Bytes(), nil, true
+			default:
+				return nil, nil, false
+			}
+		},
+		publicKey: func(pub []byte) (interface{}, error) {
+			v, err := mlkem.NewEncapsulationKey1024(pub)
+			if err != nil {
+				return nil, fmt.Errorf(`failed to create ML-KEM-1024 encapsulation key: %w`, err)
+			}
+			return v, nil
+		},
+		privateKey: func(priv []byte) (interface{}, []byte, error) {
+			v, err := mlkem.NewDecapsulationKey1024(priv)
+			if err != nil {
+				return nil, nil, fmt.Errorf(`failed to create ML-KEM-1024 decapsulation key: %w`, err)
+			}
+			return v, v.EncapsulationKey().Bytes(), nil
+		},
+		generate: func(io.Reader) (interface{}, error) {
+			// crypto/mlkem always uses crypto/rand
+			return mlkem.GenerateKey1024()
+		},
+	}
+}
@@ -0,0 +1,64 @@
+//go:build go1.24
+// +build go1.24
+
+package jwk_test
+
+import (
+	"crypto/mlkem"
+	"encoding/json"
+	"testing"
+
+	"github.com/lestrrat-go/jwx/v2/jwa"
+	"github.com/lestrrat-go/jwx/v2/jwk"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAKP_MLKEM(t *testing.T) {
+	t.Parallel()
+
+	t.Run("ML-KEM-768", func(t *testing.T) {
+		t.Parallel()
+		raw, err := mlkem.GenerateKey768()
+		require.NoError(t, err, `mlkem.GenerateKey768 should succeed`)
+
+		key, err := jwk.FromRaw(raw)
+		require.NoError(t, err, `jwk.FromRaw should succeed`)
+		require.Equal(t, jwa.AKP, key.KeyType(), `key type should be AKP`)
+		require.Equal(t, jwa.MLKEM768.String(), key.Algorithm().String(), `alg should match`)
+
+		buf, err := json.Marshal(key)
+		require.NoError(t, err, `json.Marshal should succeed`)
+		parsed, err := jwk.ParseKey(buf)
+		require.NoError(t, err, `jwk.ParseKey should succeed`)
+
+		var dk *mlkem.DecapsulationKey768
+		require.NoError(t, parsed.Raw(&dk), `key.Raw should succeed`)
+		require.Equal(t, raw.Bytes(), dk.Bytes(), `decapsulation keys should match`)
+
+		pubkey, err := jwk.PublicKeyOf(parsed)
+		require.NoError(t, err, `jwk.PublicKeyOf should succeed`)
+		var ek *mlkem.EncapsulationKey768
+		require.NoError(t, pubkey.Raw(&ek), `key.Raw should succeed`)
+		require.Equal(t, raw.EncapsulationKey().Bytes(), ek.Bytes(), `encapsulation keys should match`)
+
+		// Keys for the key wrapping variant use the same raw keys
+		require.NoError(t, parsed.Set(jwk.AlgorithmKey, jwa.MLKEM768_A192KW), `key.Set should succeed`)
+		dk = nil
+		require.NoError(t, parsed.Raw(&dk), `key.Raw should succeed`)
+		require.Equal(t, raw.Bytes(), dk.Bytes(), `decapsulation keys should match`)
+	})
+	t.Run("ML-KEM-1024", func(t *testing.T) {
+		t.Parallel()
+		key, err := jwk.Generate(jwa.AKP, jwk.WithAlgorithm(jwa.MLKEM1024))
+		require.NoError(t, err, `jwk.Generate should succeed`)
+
+		var dk *mlkem.DecapsulationKey1024
+		require.NoError(t, key.Raw(&dk), `key.Raw should succeed`)
+
+		pubkey, err := jwk.PublicRawKeyOf(dk)
+		require.NoError(t, err, `jwk.PublicRawKeyOf should succeed`)
+		ek, ok := pubkey.(*mlkem.EncapsulationKey1024)
+		require.True(t, ok, `public key should be *mlkem.EncapsulationKey1024 (%T)`, pubkey)
+		require.Equal(t, dk.EncapsulationKey().Bytes(), ek.Bytes(), `encapsulation keys should match`)
+	})
+}
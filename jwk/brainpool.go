@@ -0,0 +1,24 @@
+//go:build jwx_brainpool
+// +build jwx_brainpool
+
+package jwk
+
+import (
+	"encoding/asn1"
+
+	"github.com/lestrrat-go/jwx/v2/internal/brainpool"
+	"github.com/lestrrat-go/jwx/v2/internal/ecutil"
+	"github.com/lestrrat-go/jwx/v2/jwa"
+)
+
+func init() {
+	ecutil.RegisterCurve(brainpool.P256r1(), jwa.BrainpoolP256r1)
+	ecutil.RegisterCurve(brainpool.P384r1(), jwa.BrainpoolP384r1)
+	ecutil.RegisterCurve(brainpool.P512r1(), jwa.BrainpoolP512r1)
+
+	brainpoolCurves = append(brainpoolCurves,
+		brainpoolCurve{crv: brainpool.P256r1(), oid: asn1.ObjectIdentifier{1, 3, 36, 3, 3, 2, 8, 1, 1, 7}},
+		brainpoolCurve{crv: brainpool.P384r1(), oid: asn1.ObjectIdentifier{1, 3, 36, 3, 3, 2, 8, 1, 1, 11}},
+		brainpoolCurve{crv: brainpool.P512r1(), oid: asn1.ObjectIdentifier{1, 3, 36, 3, 3, 2, 8, 1, 1, 13}},
+	)
+}
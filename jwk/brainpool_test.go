@@ -0,0 +1,81 @@
+//go:build jwx_brainpool
+// +build jwx_brainpool
+
+package jwk_test
+
+import (
+	"crypto/ecdsa"
+	"encoding/json"
+	"testing"
+
+	"github.com/lestrrat-go/jwx/v2/jwa"
+	"github.com/lestrrat-go/jwx/v2/jwk"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBrainpool(t *testing.T) {
+	t.Parallel()
+	for _, crv := range []jwa.EllipticCurveAlgorithm{jwa.BrainpoolP256r1, jwa.BrainpoolP384r1, jwa.BrainpoolP512r1} {
+		crv := crv
+		t.Run(crv.String(), func(t *testing.T) {
+			t.Parallel()
+			curve, ok := jwk.CurveForAlgorithm(crv)
+			require.True(t, ok, `jwk.CurveForAlgorithm should succeed`)
+			require.Contains(t, jwk.AvailableCurves(), curve, `jwk.AvailableCurves should contain the curve`)
+
+			key, err := jwk.Generate(jwa.EC, jwk.WithCurve(crv))
+			require.NoError(t, err, `jwk.Generate should succeed`)
+			require.Equal(t, crv, key.(jwk.ECDSAPrivateKey).Crv(), `crv should match`)
+
+			var raw ecdsa.PrivateKey
+			require.NoError(t, key.Raw(&raw), `key.Raw should succeed`)
+			require.Equal(t, curve, raw.Curve, `curve should match`)
+
+			pubkey, err := key.PublicKey()
+			require.NoError(t, err, `key.PublicKey should succeed`)
+
+			t.Run("JSON", func(t *testing.T) {
+				buf, err := json.Marshal(pubkey)
+				require.NoError(t, err, `json.Marshal should succeed`)
+
+				parsed, err := jwk.ParseKey(buf)
+				require.NoError(t, err, `jwk.ParseKey should succeed`)
+
+				var rawpub ecdsa.PublicKey
+				require.NoError(t, parsed.Raw(&rawpub), `parsed.Raw should succeed`)
+				require.True(t, raw.PublicKey.Equal(&rawpub), `public keys should match`)
+			})
+			t.Run("PEM", func(t *testing.T) {
+				for _, src := range []interface{}{&raw, &raw.PublicKey, key, pubkey} {
+					pemBytes, err := jwk.EncodePEM(src)
+					require.NoError(t, err, `jwk.EncodePEM should succeed`)
+
+					decoded, _, err := jwk.DecodePEM(pemBytes)
+					require.NoError(t, err, `jwk.DecodePEM should succeed`)
+
+					switch decoded := decoded.(type) {
+					case *ecdsa.PrivateKey:
+						require.True(t, raw.Equal(decoded), `private keys should match`)
+					case *ecdsa.PublicKey:
+						require.True(t, raw.PublicKey.Equal(decoded), `public keys should match`)
+					default:
+						require.Fail(t, `unexpected key type`, `%T`, decoded)
+					}
+				}
+
+				// PKCS8 and PKIX
+				for _, src := range []jwk.Key{key, pubkey} {
+					pemBytes, err := jwk.Pem(src)
+					require.NoError(t, err, `jwk.Pem should succeed`)
+
+					parsed, err := jwk.ParseKey(pemBytes, jwk.WithPEM(true))
+					require.NoError(t, err, `jwk.ParseKey should succeed`)
+
+					rawpub, err := jwk.PublicRawKeyOf(parsed)
+					require.NoError(t, err, `jwk.PublicRawKeyOf should succeed`)
+					require.True(t, raw.PublicKey.Equal(rawpub), `public keys should match`)
+				}
+			})
+		})
+	}
+}
@@ -0,0 +1,157 @@
+package jwk
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/x509"
+	"encoding/asn1"
+	"fmt"
+	"math/big"
+)
+
+// The x509 package in the standard library only handles the NIST curves
+// for EC keys, so the Brainpool curves need to be encoded/decoded by
+// ourselves. The structures below follow RFC 5480 and RFC 5915, and
+// the curve OIDs are taken from RFC 5639.
+
+var oidPublicKeyECDSA = asn1.ObjectIdentifier{1, 2, 840, 10045, 2, 1}
+
+// brainpoolCurves is only populated when compiled with the jwx_brainpool
+// build tag (see brainpool.go)
+var brainpoolCurves []brainpoolCurve
+
+type brainpoolCurve struct {
+	crv elliptic.Curve
+	oid asn1.ObjectIdentifier
+}
+
+const ecPrivKeyVersion = 1
+
+type ecPrivateKey struct {
+	Version       int
+	PrivateKey    []byte
+	NamedCurveOID asn1.ObjectIdentifier `asn1:"optional,explicit,tag:0"`
+	PublicKey     asn1.BitString        `asn1:"optional,explicit,tag:1"`
+}
+
+func oidFromBrainpoolCurve(crv elliptic.Curve) (asn1.ObjectIdentifier, bool) {
+	for _, bc := range brainpoolCurves {
+		if bc.crv == crv {
+			return bc.oid, true
+		}
+	}
+	return nil, false
+}
+
+func brainpoolCurveFromOID(oid asn1.ObjectIdentifier) (elliptic.Curve, bool) {
+	for _, bc := range brainpoolCurves {
+		if bc.oid.Equal(oid) {
+			return bc.crv, true
+		}
+	}
+	return nil, false
+}
+
+func ecCoordinateSize(crv elliptic.Curve) int {
+	return (crv.Params().BitSize + 7) / 8
+}
+
+// marshalECPoint encodes the point in uncompressed form
+func marshalECPoint(crv elliptic.Curve, x, y *big.Int) []byte {
+	size := ecCoordinateSize(crv)
+	buf := make([]byte, 1+2*size)
+	buf[0] = 4 // uncompressed point
+	x.FillBytes(buf[1 : 1+size])
+	y.FillBytes(buf[1+size:])
+	return buf
+}
+
+func unmarshalECPoint(crv elliptic.Curve, data []byte) (*big.Int, *big.Int, error) {
+	size := ecCoordinateSize(crv)
+	if len(data) != 1+2*size || data[0] != 4 {
+		return nil, nil, fmt.Errorf(`invalid EC point encoding`)
+	}
+	x := new(big.Int).SetBytes(data[1 : 1+size])
+	y := new(big.Int).SetBytes(data[1+size:])
+	if !crv.IsOnCurve(x, y) {
+		return nil, nil, fmt.Errorf(`EC point is not on curve %s`, crv.Params().Name)
+	}
+	return x, y, nil
+}
+
+func ecAlgorithmParameters(oid asn1.ObjectIdentifier) (asn1.RawValue, error) {
+	marshaled, err := asn1.Marshal(oid)
+	if err != nil {
+		return asn1.RawValue{}, fmt.Errorf(`failed to marshal curve OID: %w`, err)
+	}
+	return asn1.RawValue{FullBytes: marshaled}, nil
+}
+
+func brainpoolCurveFromParameters(params asn1.RawValue) (elliptic.Curve, error) {
+	var oid asn1.ObjectIdentifier
+	if rest, err := asn1.Unmarshal(params.FullBytes, &oid); err != nil || len(rest) > 0 {
+		return nil, fmt.Errorf(`failed to parse EC parameters`)
+	}
+	crv, ok := brainpoolCurveFromOID(oid)
+	if !ok {
+		return nil, fmt.Errorf(`unsupported elliptic curve %s`, oid)
+	}
+	return crv, nil
+}
+
+// marshalECPrivateKey is a wrapper around x509.MarshalECPrivateKey that
+// also handles the Brainpool curves
+func marshalECPrivateKey(key *ecdsa.PrivateKey) ([]byte, error) {
+	oid, ok := oidFromBrainpoolCurve(key.Curve)
+	if !ok {
+		return x509.MarshalECPrivateKey(key)
+	}
+	return marshalSEC1PrivateKey(key, oid)
+}
+
+func marshalSEC1PrivateKey(key *ecdsa.PrivateKey, oid asn1.ObjectIdentifier) ([]byte, error) {
+	privkey := make([]byte, (key.Curve.Params().N.BitLen()+7)/8)
+	key.D.FillBytes(privkey)
+	return asn1.Marshal(ecPrivateKey{
+		Version:       ecPrivKeyVersion,
+		PrivateKey:    privkey,
+		NamedCurveOID: oid,
+		PublicKey:     asn1.BitString{Bytes: marshalECPoint(key.Curve, key.X, key.Y)},
+	})
+}
+
+// parseECPrivateKey is a wrapper around x509.ParseECPrivateKey that
+// also handles the Brainpool curves
+func parseECPrivateKey(der []byte) (*ecdsa.PrivateKey, error) {
+	key, err := x509.ParseECPrivateKey(der)
+	if err == nil {
+		return key, nil
+	}
+
+	var privkey ecPrivateKey
+	if rest, uerr := asn1.Unmarshal(der, &privkey); uerr != nil || len(rest) > 0 {
+		return nil, err
+	}
+	crv, ok := brainpoolCurveFromOID(privkey.NamedCurveOID)
+	if !ok {
+		return nil, err
+	}
+	return parseSEC1PrivateKey(crv, privkey)
+}
+
+func parseSEC1PrivateKey(crv elliptic.Curve, privkey ecPrivateKey) (*ecdsa.PrivateKey, error) {
+	if privkey.Version != ecPrivKeyVersion {
+		return nil, fmt.Errorf(`unknown EC private key version %d`, privkey.Version)
+	}
+
+	d := new(big.Int).SetBytes(privkey.PrivateKey)
+	if d.Sign() <= 0 || d.Cmp(crv.Params().N) >= 0 {
+		return nil, fmt.Errorf(`invalid EC private key value`)
+	}
+
+	x, y := crv.ScalarBaseMult(d.Bytes())
+	return &ecdsa.PrivateKey{
+		PublicKey: ecdsa.PublicKey{Curve: crv, X: x, Y: y},
+		D:         d,
+	}, nil
+}
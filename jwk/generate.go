@@ -0,0 +1,163 @@
+package jwk
+
+import (
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"fmt"
+	"io"
+
+	"github.com/cloudflare/circl/sign/ed448"
+	"github.com/lestrrat-go/jwx/v2/jwa"
+	"github.com/lestrrat-go/jwx/v2/x25519"
+	"github.com/lestrrat-go/jwx/v2/x448"
+)
+
+const (
+	defaultRSAKeySize       = 2048
+	defaultSymmetricKeySize = 32
+)
+
+// Generate creates a new private key of the given key type, and returns
+// it as a jwk.Key. For symmetric (`jwa.OctetSeq`) keys, a random octet
+// sequence is generated.
+//
+// The size of the generated key can be controlled via `jwk.WithKeySize()`
+// for RSA and oct keys, and the curve can be controlled via `jwk.WithCurve()`
+// for EC and OKP keys. AKP keys require the algorithm to be specified via
+// `jwk.WithAlgorithm()`.
+//
+// Common fields such as `kid`, `alg`, `use`, and `key_ops` can be
+// assigned to the generated key at the same time by specifying
+// `jwk.WithKeyID()`, `jwk.WithAlgorithm()`, `jwk.WithKeyUsage()`, and
+// `jwk.WithKeyOperations()`, respectively.
+//
+//	key, err := jwk.Generate(jwa.EC, jwk.WithCurve(jwa.P384), jwk.WithKeyID(`my-key`))
+func Generate(kty jwa.KeyType, options ...GenerateOption) (Key, error) {
+	var keysize int
+	var crv jwa.EllipticCurveAlgorithm
+	var alg jwa.KeyAlgorithm
+	var fields []*HeaderPair
+
+	//nolint:forcetypeassert
+	for _, option := range options {
+		switch option.Ident() {
+		case identKeySize{}:
+			keysize = option.Value().(int)
+		case identCurve{}:
+			crv = option.Value().(jwa.EllipticCurveAlgorithm)
+		case identKeyID{}:
+			fields = append(fields, &HeaderPair{Key: KeyIDKey, Value: option.Value()})
+		case identAlgorithm{}:
+			alg = option.Value().(jwa.KeyAlgorithm)
+			fields = append(fields, &HeaderPair{Key: AlgorithmKey, Value: alg})
+		case identKeyUsage{}:
+			fields = append(fields, &HeaderPair{Key: KeyUsageKey, Value: option.Value()})
+		case identKeyOperations{}:
+			fields = append(fields, &HeaderPair{Key: KeyOpsKey, Value: option.Value()})
+		}
+	}
+
+	raw, err := generateRawKey(rand.Reader, kty, keysize, crv, alg)
+	if err != nil {
+		return nil, fmt.Errorf(`jwk.Generate: %w`, err)
+	}
+
+	key, err := FromRaw(raw)
+	if err != nil {
+		return nil, fmt.Errorf(`jwk.Generate: failed to create jwk.Key from %T: %w`, raw, err)
+	}
+
+	for _, field := range fields {
+		//nolint:forcetypeassert
+		name := field.Key.(string)
+		if err := key.Set(name, field.Value); err != nil {
+			return nil, fmt.Errorf(`jwk.Generate: failed to set %q: %w`, name, err)
+		}
+	}
+	return key, nil
+}
+
+func generateRawKey(rdr io.Reader, kty jwa.KeyType, keysize int, crv jwa.EllipticCurveAlgorithm, alg jwa.KeyAlgorithm) (interface{}, error) {
+	switch kty {
+	case jwa.RSA:
+		if keysize <= 0 {
+			keysize = defaultRSAKeySize
+		}
+		v, err := rsa.GenerateKey(rdr, keysize)
+		if err != nil {
+			return nil, fmt.Errorf(`failed to generate RSA private key: %w`, err)
+		}
+		return v, nil
+	case jwa.EC:
+		if crv == "" {
+			crv = jwa.P256
+		}
+		ec, ok := CurveForAlgorithm(crv)
+		if !ok {
+			return nil, fmt.Errorf(`invalid elliptic curve for EC key: %s`, crv)
+		}
+		v, err := ecdsa.GenerateKey(ec, rdr)
+		if err != nil {
+			return nil, fmt.Errorf(`failed to generate ECDSA private key: %w`, err)
+		}
+		return v, nil
+	case jwa.OKP:
+		if crv == "" {
+			crv = jwa.Ed25519
+		}
+		switch crv {
+		case jwa.Ed25519:
+			_, v, err := ed25519.GenerateKey(rdr)
+			if err != nil {
+				return nil, fmt.Errorf(`failed to generate ed25519 private key: %w`, err)
+			}
+			return v, nil
+		case jwa.Ed448:
+			_, v, err := ed448.GenerateKey(rdr)
+			if err != nil {
+				return nil, fmt.Errorf(`failed to generate ed448 private key: %w`, err)
+			}
+			return v, nil
+		case jwa.X25519:
+			_, v, err := x25519.GenerateKey(rdr)
+			if err != nil {
+				return nil, fmt.Errorf(`failed to generate x25519 private key: %w`, err)
+			}
+			return v, nil
+		case jwa.X448:
+			_, v, err := x448.GenerateKey(rdr)
+			if err != nil {
+				return nil, fmt.Errorf(`failed to generate x448 private key: %w`, err)
+			}
+			return v, nil
+		default:
+			return nil, fmt.Errorf(`invalid elliptic curve for OKP key: %s`, crv)
+		}
+	case jwa.AKP:
+		if alg == nil {
+			return nil, fmt.Errorf(`algorithm must be specified for AKP key`)
+		}
+		h, err := lookupAKPAlgorithm(alg)
+		if err != nil {
+			return nil, err
+		}
+		v, err := h.generate(rdr)
+		if err != nil {
+			return nil, fmt.Errorf(`failed to generate %s private key: %w`, alg, err)
+		}
+		return v, nil
+	case jwa.OctetSeq:
+		if keysize <= 0 {
+			keysize = defaultSymmetricKeySize
+		}
+		octets := make([]byte, keysize)
+		if _, err := io.ReadFull(rdr, octets); err != nil {
+			return nil, fmt.Errorf(`failed to generate symmetric key: %w`, err)
+		}
+		return octets, nil
+	default:
+		return nil, fmt.Errorf(`invalid key type %q`, kty)
+	}
+}
@@ -16,11 +16,13 @@ import (
 	"io"
 	"math/big"
 
+	"github.com/cloudflare/circl/sign/ed448"
 	"github.com/lestrrat-go/jwx/v2/internal/base64"
 	"github.com/lestrrat-go/jwx/v2/internal/ecutil"
 	"github.com/lestrrat-go/jwx/v2/internal/json"
 	"github.com/lestrrat-go/jwx/v2/jwa"
 	"github.com/lestrrat-go/jwx/v2/x25519"
+	"github.com/lestrrat-go/jwx/v2/x448"
 )
 
 var registry = json.NewRegistry()
@@ -40,6 +42,11 @@ func bigIntToBytes(n *big.Int) ([]byte, error) {
 //   - "crypto/rsa".PrivateKey and "crypto/rsa".PublicKey creates an RSA based key
 //   - "crypto/ecdsa".PrivateKey and "crypto/ecdsa".PublicKey creates an EC based key
 //   - "crypto/ed25519".PrivateKey and "crypto/ed25519".PublicKey creates an OKP based key
+//   - "github.com/cloudflare/circl/sign/ed448".PrivateKey and "github.com/cloudflare/circl/sign/ed448".PublicKey creates an OKP based key
+//   - "github.com/lestrrat-go/jwx/v2/x25519".PrivateKey and "github.com/lestrrat-go/jwx/v2/x25519".PublicKey creates an OKP based key
+//   - "github.com/lestrrat-go/jwx/v2/x448".PrivateKey and "github.com/lestrrat-go/jwx/v2/x448".PublicKey creates an OKP based key
+//   - "crypto/mldsa".PrivateKey and "crypto/mldsa".PublicKey creates an AKP based key (requires Go 1.27 or later)
+//   - "crypto/mlkem".DecapsulationKey768/1024 and "crypto/mlkem".EncapsulationKey768/1024 creates an AKP based key (requires Go 1.24 or later)
 //   - []byte creates a symmetric key
 func FromRaw(key interface{}) (Key, error) {
 	if key == nil {
@@ -97,6 +104,18 @@ func FromRaw(key interface{}) (Key, error) {
 			return nil, fmt.Errorf(`failed to initialize %T from %T: %w`, k, rawKey, err)
 		}
 		return k, nil
+	case ed448.PrivateKey:
+		k := newOKPPrivateKey()
+		if err := k.FromRaw(rawKey); err != nil {
+			return nil, fmt.Errorf(`failed to initialize %T from %T: %w`, k, rawKey, err)
+		}
+		return k, nil
+	case ed448.PublicKey:
+		k := newOKPPublicKey()
+		if err := k.FromRaw(rawKey); err != nil {
+			return nil, fmt.Errorf(`failed to initialize %T from %T: %w`, k, rawKey, err)
+		}
+		return k, nil
 	case x25519.PrivateKey:
 		k := newOKPPrivateKey()
 		if err := k.FromRaw(rawKey); err != nil {
@@ -109,6 +128,18 @@ func FromRaw(key interface{}) (Key, error) {
 			return nil, fmt.Errorf(`failed to initialize %T from %T: %w`, k, rawKey, err)
 		}
 		return k, nil
+	case x448.PrivateKey:
+		k := newOKPPrivateKey()
+		if err := k.FromRaw(rawKey); err != nil {
+			return nil, fmt.Errorf(`failed to initialize %T from %T: %w`, k, rawKey, err)
+		}
+		return k, nil
+	case x448.PublicKey:
+		k := newOKPPublicKey()
+		if err := k.FromRaw(rawKey); err != nil {
+			return nil, fmt.Errorf(`failed to initialize %T from %T: %w`, k, rawKey, err)
+		}
+		return k, nil
 	case []byte:
 		k := newSymmetricKey()
 		if err := k.FromRaw(rawKey); err != nil {
@@ -116,6 +147,22 @@ func FromRaw(key interface{}) (Key, error) {
 		}
 		return k, nil
 	default:
+		// AKP keys are handled separately, as the raw key types that
+		// are supported depend on the build
+		if _, _, priv, ok := akpFromRaw(rawKey); ok {
+			if priv != nil {
+				k := newAKPPrivateKey()
+				if err := k.FromRaw(rawKey); err != nil {
+					return nil, fmt.Errorf(`failed to initialize %T from %T: %w`, k, rawKey, err)
+				}
+				return k, nil
+			}
+			k := newAKPPublicKey()
+			if err := k.FromRaw(rawKey); err != nil {
+				return nil, fmt.Errorf(`failed to initialize %T from %T: %w`, k, rawKey, err)
+			}
+			return k, nil
+		}
 		return nil, fmt.Errorf(`invalid key type '%T' for jwk.New`, key)
 	}
 }
@@ -222,13 +269,28 @@ func PublicRawKeyOf(v interface{}) (interface{}, error) {
 		return x.Public(), nil
 	case ed25519.PublicKey:
 		return x, nil
+	case ed448.PrivateKey:
+		return x.Public(), nil
+	case ed448.PublicKey:
+		return x, nil
 	case x25519.PrivateKey:
 		return x.Public(), nil
 	case x25519.PublicKey:
 		return x, nil
+	case x448.PrivateKey:
+		return x.Public(), nil
+	case x448.PublicKey:
+		return x, nil
 	case []byte:
 		return x, nil
 	default:
+		if _, _, _, ok := akpFromRaw(x); ok {
+			jk, err := FromRaw(x)
+			if err != nil {
+				return nil, fmt.Errorf(`failed to convert key into JWK: %w`, err)
+			}
+			return PublicRawKeyOf(jk)
+		}
 		return nil, fmt.Errorf(`invalid key type passed to PublicKeyOf (%T)`, v)
 	}
 }
@@ -268,24 +330,38 @@ func EncodeX509(v interface{}) (string, []byte, error) {
 	case *rsa.PrivateKey:
 		return pmRSAPrivateKey, x509.MarshalPKCS1PrivateKey(v), nil
 	case *ecdsa.PrivateKey:
-		marshaled, err := x509.MarshalECPrivateKey(v)
+		marshaled, err := marshalECPrivateKey(v)
 		if err != nil {
 			return "", nil, err
 		}
 		return pmECPrivateKey, marshaled, nil
-	case ed25519.PrivateKey:
-		marshaled, err := x509.MarshalPKCS8PrivateKey(v)
+	case ed25519.PrivateKey, ed448.PrivateKey:
+		marshaled, err := marshalPKCS8PrivateKey(v)
 		if err != nil {
 			return "", nil, err
 		}
 		return pmPrivateKey, marshaled, nil
-	case *rsa.PublicKey, *ecdsa.PublicKey, ed25519.PublicKey:
-		marshaled, err := x509.MarshalPKIXPublicKey(v)
+	case *rsa.PublicKey, *ecdsa.PublicKey, ed25519.PublicKey, ed448.PublicKey:
+		marshaled, err := marshalPKIXPublicKey(v)
 		if err != nil {
 			return "", nil, err
 		}
 		return pmPublicKey, marshaled, nil
 	default:
+		if _, _, priv, ok := akpFromRaw(v); ok {
+			if priv != nil {
+				marshaled, err := marshalPKCS8PrivateKey(v)
+				if err != nil {
+					return "", nil, err
+				}
+				return pmPrivateKey, marshaled, nil
+			}
+			marshaled, err := marshalPKIXPublicKey(v)
+			if err != nil {
+				return "", nil, err
+			}
+			return pmPublicKey, marshaled, nil
+		}
 		return "", nil, fmt.Errorf(`unsupported type %T for ASN.1 DER encoding`, v)
 	}
 }
@@ -332,20 +408,20 @@ func DecodePEM(src []byte) (interface{}, []byte, error) {
 		}
 		return key, rest, nil
 	case pmECPrivateKey:
-		key, err := x509.ParseECPrivateKey(block.Bytes)
+		key, err := parseECPrivateKey(block.Bytes)
 		if err != nil {
 			return nil, nil, fmt.Errorf(`failed to parse EC private key: %w`, err)
 		}
 		return key, rest, nil
 	case pmPublicKey:
 		// XXX *could* return dsa.PublicKey
-		key, err := x509.ParsePKIXPublicKey(block.Bytes)
+		key, err := parsePKIXPublicKey(block.Bytes)
 		if err != nil {
 			return nil, nil, fmt.Errorf(`failed to parse PKIX public key: %w`, err)
 		}
 		return key, rest, nil
 	case pmPrivateKey:
-		key, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+		key, err := parsePKCS8PrivateKey(block.Bytes)
 		if err != nil {
 			return nil, nil, fmt.Errorf(`failed to parse PKCS8 private key: %w`, err)
 		}
@@ -431,8 +507,9 @@ func ParseKey(data []byte, options ...ParseOption) (Key, error) {
 	}
 
 	var hint struct {
-		Kty string          `json:"kty"`
-		D   json.RawMessage `json:"d"`
+		Kty  string          `json:"kty"`
+		D    json.RawMessage `json:"d"`
+		Priv json.RawMessage `json:"priv"`
 	}
 
 	if err := json.Unmarshal(data, &hint); err != nil {
@@ -461,6 +538,12 @@ func ParseKey(data []byte, options ...ParseOption) (Key, error) {
 		} else {
 			key = newOKPPublicKey()
 		}
+	case jwa.AKP:
+		if len(hint.Priv) > 0 {
+			key = newAKPPrivateKey()
+		} else {
+			key = newAKPPublicKey()
+		}
 	default:
 		return nil, fmt.Errorf(`invalid key type from JSON (%s)`, hint.Kty)
 	}
@@ -618,6 +701,10 @@ func cloneKey(src Key) (Key, error) {
 		dst = newOKPPrivateKey()
 	case OKPPublicKey:
 		dst = newOKPPublicKey()
+	case AKPPrivateKey:
+		dst = newAKPPrivateKey()
+	case AKPPublicKey:
+		dst = newAKPPublicKey()
 	case SymmetricKey:
 		dst = newSymmetricKey()
 	default:
@@ -640,8 +727,8 @@ func cloneKey(src Key) (Key, error) {
 //
 // # Argument must be of type jwk.Key or jwk.Set
 //
-// Currently only EC (including Ed25519) and RSA keys (and jwk.Set
-// comprised of these key types) are supported.
+// Currently only EC, OKP (Ed25519 and Ed448), AKP (ML-DSA), and RSA keys
+// (and jwk.Set comprised of these key types) are supported.
 func Pem(v interface{}) ([]byte, error) {
 	var set Set
 	switch v := v.(type) {
@@ -674,22 +761,22 @@ func Pem(v interface{}) ([]byte, error) {
 
 func asnEncode(key Key) (string, []byte, error) {
 	switch key := key.(type) {
-	case RSAPrivateKey, ECDSAPrivateKey, OKPPrivateKey:
+	case RSAPrivateKey, ECDSAPrivateKey, OKPPrivateKey, AKPPrivateKey:
 		var rawkey interface{}
 		if err := key.Raw(&rawkey); err != nil {
 			return "", nil, fmt.Errorf(`failed to get raw key from jwk.Key: %w`, err)
 		}
-		buf, err := x509.MarshalPKCS8PrivateKey(rawkey)
+		buf, err := marshalPKCS8PrivateKey(rawkey)
 		if err != nil {
 			return "", nil, fmt.Errorf(`failed to marshal PKCS8: %w`, err)
 		}
 		return pmPrivateKey, buf, nil
-	case RSAPublicKey, ECDSAPublicKey, OKPPublicKey:
+	case RSAPublicKey, ECDSAPublicKey, OKPPublicKey, AKPPublicKey:
 		var rawkey interface{}
 		if err := key.Raw(&rawkey); err != nil {
 			return "", nil, fmt.Errorf(`failed to get raw key from jwk.Key: %w`, err)
 		}
-		buf, err := x509.MarshalPKIXPublicKey(rawkey)
+		buf, err := marshalPKIXPublicKey(rawkey)
 		if err != nil {
 			return "", nil, fmt.Errorf(`failed to marshal PKIX: %w`, err)
 		}
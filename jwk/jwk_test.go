@@ -19,17 +19,20 @@ import (
 	"testing"
 	"time"
 
+	"github.com/cloudflare/circl/sign/ed448"
 	"github.com/lestrrat-go/jwx/v2/cert"
 	"github.com/lestrrat-go/jwx/v2/internal/ecutil"
 	"github.com/lestrrat-go/jwx/v2/internal/jose"
 	"github.com/lestrrat-go/jwx/v2/internal/json"
 	"github.com/lestrrat-go/jwx/v2/internal/jwxtest"
+	"github.com/lestrrat-go/jwx/v2/jwe"
 	"github.com/lestrrat-go/jwx/v2/jws"
 
 	"github.com/lestrrat-go/jwx/v2/internal/base64"
 	"github.com/lestrrat-go/jwx/v2/jwa"
 	"github.com/lestrrat-go/jwx/v2/jwk"
 	"github.com/lestrrat-go/jwx/v2/x25519"
+	"github.com/lestrrat-go/jwx/v2/x448"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -150,8 +153,12 @@ func expectedRawKeyType(key jwk.Key) interface{} {
 		switch key.Crv() {
 		case jwa.Ed25519:
 			return ed25519.PrivateKey(nil)
+		case jwa.Ed448:
+			return ed448.PrivateKey(nil)
 		case jwa.X25519:
 			return x25519.PrivateKey(nil)
+		case jwa.X448:
+			return x448.PrivateKey(nil)
 		default:
 			panic("unknown curve type for OKPPrivateKey:" + key.Crv())
 		}
@@ -159,8 +166,12 @@ func expectedRawKeyType(key jwk.Key) interface{} {
 		switch key.Crv() {
 		case jwa.Ed25519:
 			return ed25519.PublicKey(nil)
+		case jwa.Ed448:
+			return ed448.PublicKey(nil)
 		case jwa.X25519:
 			return x25519.PublicKey(nil)
+		case jwa.X448:
+			return x448.PublicKey(nil)
 		default:
 			panic("unknown curve type for OKPPublicKey:" + key.Crv())
 		}
@@ -215,8 +226,12 @@ func VerifyKey(t *testing.T, def map[string]keyDef) {
 	})
 	t.Run("Roundtrip", func(t *testing.T) {
 		var supportsPEM bool
-		switch key.KeyType() {
-		case jwa.OKP, jwa.OctetSeq:
+		switch key := key.(type) {
+		case jwk.SymmetricKey:
+		case jwk.OKPPrivateKey:
+			supportsPEM = key.Crv() == jwa.Ed448
+		case jwk.OKPPublicKey:
+			supportsPEM = key.Crv() == jwa.Ed448
 		default:
 			supportsPEM = true
 		}
@@ -423,12 +438,24 @@ func TestParse(t *testing.T) {
 							return
 						}
 						crawkey = rawkey
+					case jwa.Ed448:
+						var rawkey ed448.PrivateKey
+						if !assert.NoError(t, key.Raw(&rawkey), `key.Raw(&ed448.PrivateKey) should succeed`) {
+							return
+						}
+						crawkey = rawkey
 					case jwa.X25519:
 						var rawkey x25519.PrivateKey
 						if !assert.NoError(t, key.Raw(&rawkey), `key.Raw(&x25519.PrivateKey) should succeed`) {
 							return
 						}
 						crawkey = rawkey
+					case jwa.X448:
+						var rawkey x448.PrivateKey
+						if !assert.NoError(t, key.Raw(&rawkey), `key.Raw(&x448.PrivateKey) should succeed`) {
+							return
+						}
+						crawkey = rawkey
 					default:
 						t.Errorf(`invalid curve %s`, k.Crv())
 					}
@@ -443,12 +470,24 @@ func TestParse(t *testing.T) {
 							return
 						}
 						crawkey = rawkey
+					case jwa.Ed448:
+						var rawkey ed448.PublicKey
+						if !assert.NoError(t, key.Raw(&rawkey), `key.Raw(&ed448.PublicKey) should succeed`) {
+							return
+						}
+						crawkey = rawkey
 					case jwa.X25519:
 						var rawkey x25519.PublicKey
 						if !assert.NoError(t, key.Raw(&rawkey), `key.Raw(&x25519.PublicKey) should succeed`) {
 							return
 						}
 						crawkey = rawkey
+					case jwa.X448:
+						var rawkey x448.PublicKey
+						if !assert.NoError(t, key.Raw(&rawkey), `key.Raw(&x448.PublicKey) should succeed`) {
+							return
+						}
+						crawkey = rawkey
 					default:
 						t.Errorf(`invalid curve %s`, k.Crv())
 					}
@@ -803,6 +842,7 @@ func TestAssignKeyID(t *testing.T) {
 		jwxtest.GenerateEcdsaPublicJwk,
 		jwxtest.GenerateSymmetricJwk,
 		jwxtest.GenerateEd25519Jwk,
+		jwxtest.GenerateEd448Jwk,
 	}
 
 	for _, generator := range generators {
@@ -844,11 +884,21 @@ func TestPublicKeyOf(t *testing.T) {
 		return
 	}
 
+	ed448key, err := jwxtest.GenerateEd448Key()
+	if !assert.NoError(t, err, `generating raw Ed448 key should succeed`) {
+		return
+	}
+
 	x25519key, err := jwxtest.GenerateX25519Key()
 	if !assert.NoError(t, err, `generating raw X25519 key should succeed`) {
 		return
 	}
 
+	x448key, err := jwxtest.GenerateX448Key()
+	if !assert.NoError(t, err, `generating raw X448 key should succeed`) {
+		return
+	}
+
 	keys := []struct {
 		Key           interface{}
 		PublicKeyType reflect.Type
@@ -897,6 +947,14 @@ func TestPublicKeyOf(t *testing.T) {
 			Key:           ed25519key.Public(),
 			PublicKeyType: reflect.TypeOf(ed25519key.Public()),
 		},
+		{
+			Key:           ed448key,
+			PublicKeyType: reflect.TypeOf(ed448key.Public()),
+		},
+		{
+			Key:           ed448key.Public(),
+			PublicKeyType: reflect.TypeOf(ed448key.Public()),
+		},
 		{
 			Key:           x25519key,
 			PublicKeyType: reflect.TypeOf(x25519key.Public()),
@@ -905,6 +963,14 @@ func TestPublicKeyOf(t *testing.T) {
 			Key:           x25519key.Public(),
 			PublicKeyType: reflect.TypeOf(x25519key.Public()),
 		},
+		{
+			Key:           x448key,
+			PublicKeyType: reflect.TypeOf(x448key.Public()),
+		},
+		{
+			Key:           x448key.Public(),
+			PublicKeyType: reflect.TypeOf(x448key.Public()),
+		},
 	}
 
 	for _, key := range keys {
@@ -1362,6 +1428,48 @@ func TestOKP(t *testing.T) {
 			})
 		})
 	})
+	t.Run("Ed448", func(t *testing.T) {
+		t.Parallel()
+		// Test vectors from RFC 8032 section 7.4 (-----Blank)
+		t.Run("PrivateKey", func(t *testing.T) {
+			t.Parallel()
+			VerifyKey(t, map[string]keyDef{
+				jwk.KeyTypeKey: {
+					Method: "KeyType",
+					Value:  jwa.OKP,
+				},
+				jwk.OKPDKey: expectBase64(keyDef{
+					Method: "D",
+					Value:  "bIKlYsuAjRDWMr6JyFE-v2ySnzTd-oyfY8mWDvbjSKNSjIo_zC8ETjmj_FuUSS-PAy51SaIAmPlb",
+				}),
+				jwk.OKPXKey: expectBase64(keyDef{
+					Method: "X",
+					Value:  "X9dEm1m0Yf0s54fsYWrUah2hNCSFpw4fig6nXYDpZ3jt8SR2m0bHBhvWeD3x5Q9s0foavq_oJWGA",
+				}),
+				jwk.OKPCrvKey: {
+					Method: "Crv",
+					Value:  jwa.Ed448,
+				},
+			})
+		})
+		t.Run("PublicKey", func(t *testing.T) {
+			t.Parallel()
+			VerifyKey(t, map[string]keyDef{
+				jwk.KeyTypeKey: {
+					Method: "KeyType",
+					Value:  jwa.OKP,
+				},
+				jwk.OKPXKey: expectBase64(keyDef{
+					Method: "X",
+					Value:  "X9dEm1m0Yf0s54fsYWrUah2hNCSFpw4fig6nXYDpZ3jt8SR2m0bHBhvWeD3x5Q9s0foavq_oJWGA",
+				}),
+				jwk.OKPCrvKey: {
+					Method: "Crv",
+					Value:  jwa.Ed448,
+				},
+			})
+		})
+	})
 	t.Run("X25519", func(t *testing.T) {
 		t.Parallel()
 		t.Run("PublicKey", func(t *testing.T) {
@@ -1382,6 +1490,47 @@ func TestOKP(t *testing.T) {
 			})
 		})
 	})
+	t.Run("X448", func(t *testing.T) {
+		t.Parallel()
+		t.Run("PrivateKey", func(t *testing.T) {
+			t.Parallel()
+			VerifyKey(t, map[string]keyDef{
+				jwk.KeyTypeKey: {
+					Method: "KeyType",
+					Value:  jwa.OKP,
+				},
+				jwk.OKPDKey: expectBase64(keyDef{
+					Method: "D",
+					Value:  "mo9JJdFRn1d1z0awS1gA1O6e6LrovFVl1JjCjdnJuvV0qUGXRIlzkQBjgqbxJ6sdmsLYwKWYcms",
+				}),
+				jwk.OKPXKey: expectBase64(keyDef{
+					Method: "X",
+					Value:  "mwj3zDG34-Z9ItWuoSEHSic70rg94Jxj-qc9LCLF2bvINmRyQdlT1AxbEtqIEg1TF3-A5TLEH6A",
+				}),
+				jwk.OKPCrvKey: {
+					Method: "Crv",
+					Value:  jwa.X448,
+				},
+			})
+		})
+		t.Run("PublicKey", func(t *testing.T) {
+			t.Parallel()
+			VerifyKey(t, map[string]keyDef{
+				jwk.KeyTypeKey: {
+					Method: "KeyType",
+					Value:  jwa.OKP,
+				},
+				jwk.OKPXKey: expectBase64(keyDef{
+					Method: "X",
+					Value:  "mwj3zDG34-Z9ItWuoSEHSic70rg94Jxj-qc9LCLF2bvINmRyQdlT1AxbEtqIEg1TF3-A5TLEH6A",
+				}),
+				jwk.OKPCrvKey: {
+					Method: "Crv",
+					Value:  jwa.X448,
+				},
+			})
+		})
+	})
 }
 
 func TestCustomField(t *testing.T) {
@@ -2176,3 +2325,211 @@ func TestGH928(t *testing.T) {
 	goleak.VerifyNone(t)
 }
 */
+
+func TestGenerate(t *testing.T) {
+	testcases := []struct {
+		Name     string
+		KeyType  jwa.KeyType
+		Options  []jwk.GenerateOption
+		Expected interface{}
+		Error    bool
+	}{
+		{Name: `RSA (default)`, KeyType: jwa.RSA, Expected: &rsa.PrivateKey{}},
+		{Name: `RSA (3072)`, KeyType: jwa.RSA, Options: []jwk.GenerateOption{jwk.WithKeySize(3072)}, Expected: &rsa.PrivateKey{}},
+		{Name: `EC (default)`, KeyType: jwa.EC, Expected: &ecdsa.PrivateKey{}},
+		{Name: `EC (P-521)`, KeyType: jwa.EC, Options: []jwk.GenerateOption{jwk.WithCurve(jwa.P521)}, Expected: &ecdsa.PrivateKey{}},
+		{Name: `EC (Ed25519)`, KeyType: jwa.EC, Options: []jwk.GenerateOption{jwk.WithCurve(jwa.Ed25519)}, Error: true},
+		{Name: `OKP (default)`, KeyType: jwa.OKP, Expected: ed25519.PrivateKey(nil)},
+		{Name: `OKP (Ed448)`, KeyType: jwa.OKP, Options: []jwk.GenerateOption{jwk.WithCurve(jwa.Ed448)}, Expected: ed448.PrivateKey(nil)},
+		{Name: `OKP (X25519)`, KeyType: jwa.OKP, Options: []jwk.GenerateOption{jwk.WithCurve(jwa.X25519)}, Expected: x25519.PrivateKey(nil)},
+		{Name: `OKP (X448)`, KeyType: jwa.OKP, Options: []jwk.GenerateOption{jwk.WithCurve(jwa.X448)}, Expected: x448.PrivateKey(nil)},
+		{Name: `OKP (P-256)`, KeyType: jwa.OKP, Options: []jwk.GenerateOption{jwk.WithCurve(jwa.P256)}, Error: true},
+		{Name: `oct (default)`, KeyType: jwa.OctetSeq, Expected: []byte(nil)},
+		{Name: `oct (64)`, KeyType: jwa.OctetSeq, Options: []jwk.GenerateOption{jwk.WithKeySize(64)}, Expected: []byte(nil)},
+		{Name: `invalid`, KeyType: jwa.InvalidKeyType, Error: true},
+	}
+
+	for _, tc := range testcases {
+		tc := tc
+		t.Run(tc.Name, func(t *testing.T) {
+			key, err := jwk.Generate(tc.KeyType, tc.Options...)
+			if tc.Error {
+				require.Error(t, err, `jwk.Generate should fail`)
+				return
+			}
+			require.NoError(t, err, `jwk.Generate should succeed`)
+			require.Equal(t, tc.KeyType, key.KeyType(), `key types should match`)
+
+			var raw interface{}
+			require.NoError(t, key.Raw(&raw), `key.Raw should succeed`)
+			require.IsType(t, tc.Expected, raw, `raw key types should match`)
+
+			switch raw := raw.(type) {
+			case *rsa.PrivateKey:
+				expected := 2048
+				if tc.Options != nil {
+					expected = 3072
+				}
+				require.Equal(t, expected, raw.N.BitLen(), `key size should match`)
+			case *ecdsa.PrivateKey:
+				expected := jwa.P256
+				if tc.Options != nil {
+					expected = jwa.P521
+				}
+				crv, ok := ecutil.AlgorithmForCurve(raw.Curve)
+				require.True(t, ok, `curve should be known`)
+				require.Equal(t, expected, crv, `curves should match`)
+			case []byte:
+				expected := 32
+				if tc.Options != nil {
+					expected = 64
+				}
+				require.Len(t, raw, expected, `key size should match`)
+			}
+		})
+	}
+
+	t.Run(`With fields`, func(t *testing.T) {
+		key, err := jwk.Generate(jwa.EC,
+			jwk.WithKeyID(`my-key`),
+			jwk.WithAlgorithm(jwa.ES256),
+			jwk.WithKeyUsage(jwk.ForSignature),
+			jwk.WithKeyOperations(jwk.KeyOperationList{jwk.KeyOpSign, jwk.KeyOpVerify}),
+		)
+		require.NoError(t, err, `jwk.Generate should succeed`)
+		require.Equal(t, `my-key`, key.KeyID(), `kid should match`)
+		require.Equal(t, jwa.ES256.String(), key.Algorithm().String(), `alg should match`)
+		require.Equal(t, jwk.ForSignature.String(), key.KeyUsage(), `use should match`)
+		require.Equal(t, jwk.KeyOperationList{jwk.KeyOpSign, jwk.KeyOpVerify}, key.KeyOps(), `key_ops should match`)
+
+		signed, err := jws.Sign([]byte(`Lorem ipsum`), jws.WithKey(key.Algorithm(), key))
+		require.NoError(t, err, `jws.Sign should succeed`)
+
+		pubkey, err := key.PublicKey()
+		require.NoError(t, err, `key.PublicKey should succeed`)
+		_, err = jws.Verify(signed, jws.WithKey(key.Algorithm(), pubkey))
+		require.NoError(t, err, `jws.Verify should succeed`)
+	})
+}
+
+// opaqueSigner hides the private key behind crypto.Signer and
+// crypto.Decrypter, like keys stored in a KMS would
+type opaqueSigner struct {
+	key crypto.Signer
+}
+
+func (s *opaqueSigner) Public() crypto.PublicKey {
+	return s.key.Public()
+}
+
+func (s *opaqueSigner) Sign(rand io.Reader, digest []byte, opts crypto.SignerOpts) ([]byte, error) {
+	return s.key.Sign(rand, digest, opts)
+}
+
+func (s *opaqueSigner) Decrypt(rand io.Reader, msg []byte, opts crypto.DecrypterOpts) ([]byte, error) {
+	decrypter, ok := s.key.(crypto.Decrypter)
+	if !ok {
+		return nil, fmt.Errorf(`%T is not a crypto.Decrypter`, s.key)
+	}
+	return decrypter.Decrypt(rand, msg, opts)
+}
+
+func TestFromOpaque(t *testing.T) {
+	rsaKey, err := jwxtest.GenerateRsaKey()
+	require.NoError(t, err, `jwxtest.GenerateRsaKey should succeed`)
+	ecKey, err := jwxtest.GenerateEcdsaKey(jwa.P256)
+	require.NoError(t, err, `jwxtest.GenerateEcdsaKey should succeed`)
+
+	_, err = jwk.FromOpaque(rsaKey.PublicKey)
+	require.Error(t, err, `jwk.FromOpaque should fail for public keys`)
+
+	rsaOpaque, err := jwk.FromOpaque(&opaqueSigner{key: rsaKey})
+	require.NoError(t, err, `jwk.FromOpaque should succeed`)
+	require.NoError(t, rsaOpaque.Set(jwk.KeyIDKey, `rsa-kms`), `Set should succeed`)
+	require.NoError(t, rsaOpaque.Set(jwk.KeyUsageKey, jwk.ForSignature), `Set should succeed`)
+	require.Equal(t, jwa.RSA, rsaOpaque.KeyType(), `key type should be RSA`)
+
+	ecOpaque, err := jwk.FromOpaque(&opaqueSigner{key: ecKey})
+	require.NoError(t, err, `jwk.FromOpaque should succeed`)
+	require.NoError(t, ecOpaque.Set(jwk.KeyIDKey, `ec-kms`), `Set should succeed`)
+	require.NoError(t, ecOpaque.Set(jwk.AlgorithmKey, jwa.ES256), `Set should succeed`)
+	require.Equal(t, jwa.EC, ecOpaque.KeyType(), `key type should be EC`)
+
+	set := jwk.NewSet()
+	require.NoError(t, set.AddKey(rsaOpaque), `AddKey should succeed`)
+	require.NoError(t, set.AddKey(ecOpaque), `AddKey should succeed`)
+
+	t.Run("Serialization", func(t *testing.T) {
+		buf, err := json.Marshal(set)
+		require.NoError(t, err, `json.Marshal should succeed`)
+		require.NotContains(t, string(buf), `"d"`, `private parameters should not be serialized`)
+
+		parsed, err := jwk.Parse(buf)
+		require.NoError(t, err, `jwk.Parse should succeed`)
+		require.Equal(t, 2, parsed.Len(), `parsed set should contain 2 keys`)
+
+		expected, err := jwk.PublicKeyOf(rsaOpaque)
+		require.NoError(t, err, `jwk.PublicKeyOf should succeed`)
+		_, ok := expected.(jwk.RSAPublicKey)
+		require.True(t, ok, `public key should be a jwk.RSAPublicKey`)
+
+		parsedKey, ok := parsed.LookupKeyID(`rsa-kms`)
+		require.True(t, ok, `key should be found in the parsed set`)
+		expectedTP, err := expected.Thumbprint(crypto.SHA256)
+		require.NoError(t, err, `Thumbprint should succeed`)
+		parsedTP, err := parsedKey.Thumbprint(crypto.SHA256)
+		require.NoError(t, err, `Thumbprint should succeed`)
+		require.Equal(t, expectedTP, parsedTP, `parsed key should match the public key`)
+
+		cloned, err := rsaOpaque.Clone()
+		require.NoError(t, err, `Clone should succeed`)
+		_, ok = cloned.(crypto.Signer)
+		require.True(t, ok, `cloned key should be a crypto.Signer`)
+		require.Equal(t, `rsa-kms`, cloned.KeyID(), `cloned key should retain the key ID`)
+	})
+	t.Run("jws", func(t *testing.T) {
+		pubset, err := jwk.PublicSetOf(set)
+		require.NoError(t, err, `jwk.PublicSetOf should succeed`)
+
+		for _, tc := range []struct {
+			kid string
+			alg jwa.SignatureAlgorithm
+		}{
+			{kid: `rsa-kms`, alg: jwa.PS256},
+			{kid: `ec-kms`, alg: jwa.ES256},
+		} {
+			key, ok := set.LookupKeyID(tc.kid)
+			require.True(t, ok, `key should be found in the set`)
+
+			signed, err := jws.Sign([]byte(`Lorem ipsum`), jws.WithKey(tc.alg, key))
+			require.NoError(t, err, `jws.Sign should succeed`)
+
+			msg, err := jws.Parse(signed)
+			require.NoError(t, err, `jws.Parse should succeed`)
+			require.Equal(t, tc.kid, msg.Signatures()[0].ProtectedHeaders().KeyID(), `kid should be set`)
+
+			_, err = jws.Verify(signed, jws.WithKeySet(pubset, jws.WithInferAlgorithmFromKey(true)))
+			require.NoError(t, err, `jws.Verify should succeed`)
+		}
+	})
+	t.Run("jwe", func(t *testing.T) {
+		payload := []byte(`Lorem ipsum`)
+		for _, alg := range []jwa.KeyEncryptionAlgorithm{jwa.RSA1_5, jwa.RSA_OAEP, jwa.RSA_OAEP_256} {
+			encrypted, err := jwe.Encrypt(payload, jwe.WithKey(alg, &rsaKey.PublicKey))
+			require.NoError(t, err, `jwe.Encrypt should succeed`)
+
+			decrypted, err := jwe.Decrypt(encrypted, jwe.WithKey(alg, rsaOpaque))
+			require.NoError(t, err, `jwe.Decrypt should succeed (%s)`, alg)
+			require.Equal(t, payload, decrypted, `payload should match`)
+		}
+
+		// Keys that are not crypto.Decrypters cannot be used for decryption
+		signerOnly, err := jwk.FromOpaque(struct{ crypto.Signer }{rsaKey})
+		require.NoError(t, err, `jwk.FromOpaque should succeed`)
+
+		encrypted, err := jwe.Encrypt(payload, jwe.WithKey(jwa.RSA_OAEP, &rsaKey.PublicKey))
+		require.NoError(t, err, `jwe.Encrypt should succeed`)
+		_, err = jwe.Decrypt(encrypted, jwe.WithKey(jwa.RSA_OAEP, signerOnly))
+		require.Error(t, err, `jwe.Decrypt should fail`)
+	})
+}
@@ -0,0 +1,180 @@
+package jwk
+
+import (
+	"crypto/ecdsa"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"fmt"
+
+	"github.com/cloudflare/circl/sign/ed448"
+)
+
+// The x509 package in the standard library does not handle some of the
+// OKP keys that we support, so we need to take care of the ASN.1 encoding
+// ourselves. The structures below follow RFC 8410.
+//
+// The same goes for EC keys using the Brainpool curves (see ecdsa_x509.go)
+
+var oidEd448 = asn1.ObjectIdentifier{1, 3, 101, 113}
+
+type okpPublicKeyInfo struct {
+	Algorithm pkix.AlgorithmIdentifier
+	PublicKey asn1.BitString
+}
+
+type okpPrivateKeyInfo struct {
+	Version    int
+	Algorithm  pkix.AlgorithmIdentifier
+	PrivateKey []byte
+	// optional attributes and public key may follow
+	Rest []asn1.RawValue `asn1:"optional"`
+}
+
+// marshalPKIXPublicKey is a wrapper around x509.MarshalPKIXPublicKey that
+// also handles OKP keys that x509 does not know about
+func marshalPKIXPublicKey(v interface{}) ([]byte, error) {
+	var oid asn1.ObjectIdentifier
+	var raw []byte
+	var params asn1.RawValue
+	switch v := v.(type) {
+	case ed448.PublicKey:
+		oid = oidEd448
+		raw = v
+	case *ecdsa.PublicKey:
+		crvOID, ok := oidFromBrainpoolCurve(v.Curve)
+		if !ok {
+			return x509.MarshalPKIXPublicKey(v)
+		}
+		p, err := ecAlgorithmParameters(crvOID)
+		if err != nil {
+			return nil, err
+		}
+		oid = oidPublicKeyECDSA
+		params = p
+		raw = marshalECPoint(v.Curve, v.X, v.Y)
+	default:
+		return x509.MarshalPKIXPublicKey(v)
+	}
+
+	return asn1.Marshal(okpPublicKeyInfo{
+		Algorithm: pkix.AlgorithmIdentifier{Algorithm: oid, Parameters: params},
+		PublicKey: asn1.BitString{Bytes: raw, BitLength: 8 * len(raw)},
+	})
+}
+
+// marshalPKCS8PrivateKey is a wrapper around x509.MarshalPKCS8PrivateKey that
+// also handles OKP keys that x509 does not know about
+func marshalPKCS8PrivateKey(v interface{}) ([]byte, error) {
+	var oid asn1.ObjectIdentifier
+	var seed []byte
+	switch v := v.(type) {
+	case ed448.PrivateKey:
+		oid = oidEd448
+		seed = v.Seed()
+	case *ecdsa.PrivateKey:
+		crvOID, ok := oidFromBrainpoolCurve(v.Curve)
+		if !ok {
+			return x509.MarshalPKCS8PrivateKey(v)
+		}
+		params, err := ecAlgorithmParameters(crvOID)
+		if err != nil {
+			return nil, err
+		}
+		// As with x509.MarshalPKCS8PrivateKey, the curve OID is omitted
+		// from the inner ECPrivateKey, as it is stored in the parameters
+		privkey, err := marshalSEC1PrivateKey(v, nil)
+		if err != nil {
+			return nil, fmt.Errorf(`failed to marshal EC private key: %w`, err)
+		}
+		return asn1.Marshal(okpPrivateKeyInfo{
+			Algorithm:  pkix.AlgorithmIdentifier{Algorithm: oidPublicKeyECDSA, Parameters: params},
+			PrivateKey: privkey,
+		})
+	default:
+		return x509.MarshalPKCS8PrivateKey(v)
+	}
+
+	// The private key is wrapped in an OCTET STRING (CurvePrivateKey)
+	// which is in turn stored in the PrivateKey OCTET STRING
+	wrapped, err := asn1.Marshal(seed)
+	if err != nil {
+		return nil, fmt.Errorf(`failed to marshal private key: %w`, err)
+	}
+	return asn1.Marshal(okpPrivateKeyInfo{
+		Algorithm:  pkix.AlgorithmIdentifier{Algorithm: oid},
+		PrivateKey: wrapped,
+	})
+}
+
+// parsePKIXPublicKey is a wrapper around x509.ParsePKIXPublicKey that
+// also handles OKP keys that x509 does not know about
+func parsePKIXPublicKey(der []byte) (interface{}, error) {
+	key, err := x509.ParsePKIXPublicKey(der)
+	if err == nil {
+		return key, nil
+	}
+
+	var info okpPublicKeyInfo
+	if rest, uerr := asn1.Unmarshal(der, &info); uerr != nil || len(rest) > 0 {
+		return nil, err
+	}
+
+	switch {
+	case info.Algorithm.Algorithm.Equal(oidEd448):
+		if l := len(info.PublicKey.Bytes); l != ed448.PublicKeySize {
+			return nil, fmt.Errorf(`invalid ed448 public key size (%d)`, l)
+		}
+		return ed448.PublicKey(info.PublicKey.Bytes), nil
+	case info.Algorithm.Algorithm.Equal(oidPublicKeyECDSA):
+		crv, cerr := brainpoolCurveFromParameters(info.Algorithm.Parameters)
+		if cerr != nil {
+			return nil, err
+		}
+		x, y, perr := unmarshalECPoint(crv, info.PublicKey.Bytes)
+		if perr != nil {
+			return nil, fmt.Errorf(`failed to parse EC public key: %w`, perr)
+		}
+		return &ecdsa.PublicKey{Curve: crv, X: x, Y: y}, nil
+	default:
+		return nil, err
+	}
+}
+
+// parsePKCS8PrivateKey is a wrapper around x509.ParsePKCS8PrivateKey that
+// also handles OKP keys that x509 does not know about
+func parsePKCS8PrivateKey(der []byte) (interface{}, error) {
+	key, err := x509.ParsePKCS8PrivateKey(der)
+	if err == nil {
+		return key, nil
+	}
+
+	var info okpPrivateKeyInfo
+	if rest, uerr := asn1.Unmarshal(der, &info); uerr != nil || len(rest) > 0 {
+		return nil, err
+	}
+
+	var seed []byte
+	switch {
+	case info.Algorithm.Algorithm.Equal(oidEd448):
+		if _, uerr := asn1.Unmarshal(info.PrivateKey, &seed); uerr != nil {
+			return nil, fmt.Errorf(`failed to unmarshal ed448 private key: %w`, uerr)
+		}
+		if l := len(seed); l != ed448.SeedSize {
+			return nil, fmt.Errorf(`invalid ed448 private key size (%d)`, l)
+		}
+		return ed448.NewKeyFromSeed(seed), nil
+	case info.Algorithm.Algorithm.Equal(oidPublicKeyECDSA):
+		crv, cerr := brainpoolCurveFromParameters(info.Algorithm.Parameters)
+		if cerr != nil {
+			return nil, err
+		}
+		var privkey ecPrivateKey
+		if _, uerr := asn1.Unmarshal(info.PrivateKey, &privkey); uerr != nil {
+			return nil, fmt.Errorf(`failed to unmarshal EC private key: %w`, uerr)
+		}
+		return parseSEC1PrivateKey(crv, privkey)
+	default:
+		return nil, err
+	}
+}
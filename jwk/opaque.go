@@ -0,0 +1,106 @@
+package jwk
+
+import (
+	"crypto"
+	"fmt"
+	"io"
+
+	"github.com/lestrrat-go/blackmagic"
+	"github.com/lestrrat-go/jwx/v2/internal/json"
+)
+
+// opaqueKey is a jwk.Key whose private part is held by a crypto.Signer
+// and/or crypto.Decrypter. All fields are stored in the corresponding
+// public key, which is what gets serialized.
+type opaqueKey struct {
+	Key
+	opaque publicKeyer // crypto.Signer and/or crypto.Decrypter
+}
+
+type publicKeyer interface {
+	Public() crypto.PublicKey
+}
+
+// FromOpaque creates a jwk.Key from a `crypto.Signer` and/or `crypto.Decrypter`
+// whose private key is not directly accessible, such as keys stored
+// in a KMS or an HSM.
+//
+// The type of the key (RSA/EC/OKP) is determined from the return value of
+// its `Public()` method, and fields such as `kid`, `alg` and `use` can be
+// set on the key as usual. When the key is serialized to JSON, only the
+// public portion is included, so it can be published as a regular JWK.
+//
+// The returned key implements both `crypto.Signer` and `crypto.Decrypter`,
+// which delegate to the given key (an error is returned if the given key
+// does not implement the respective interface). As a result it can be used
+// for signing with `jws.Sign()` (RSA, ECDSA, and EdDSA), and for decrypting
+// RSA1_5 and RSA-OAEP encrypted keys with `jwe.Decrypt()`. `Raw()` assigns
+// the given key itself.
+func FromOpaque(key interface{}) (Key, error) {
+	if key == nil {
+		return nil, fmt.Errorf(`jwk.FromOpaque requires a non-nil key`)
+	}
+
+	var opaque publicKeyer
+	switch key := key.(type) {
+	case crypto.Signer:
+		opaque = key
+	case crypto.Decrypter:
+		opaque = key
+	default:
+		return nil, fmt.Errorf(`jwk.FromOpaque requires a crypto.Signer or crypto.Decrypter (got %T)`, key)
+	}
+
+	pubkey := opaque.Public()
+	pub, err := FromRaw(pubkey)
+	if err != nil {
+		return nil, fmt.Errorf(`failed to create jwk.Key from public key %T: %w`, pubkey, err)
+	}
+
+	return &opaqueKey{
+		Key:    pub,
+		opaque: opaque,
+	}, nil
+}
+
+// Public implements crypto.Signer and crypto.Decrypter
+func (k *opaqueKey) Public() crypto.PublicKey {
+	return k.opaque.Public()
+}
+
+// Sign implements crypto.Signer
+func (k *opaqueKey) Sign(rand io.Reader, digest []byte, opts crypto.SignerOpts) ([]byte, error) {
+	signer, ok := k.opaque.(crypto.Signer)
+	if !ok {
+		return nil, fmt.Errorf(`key %T is not a crypto.Signer`, k.opaque)
+	}
+	return signer.Sign(rand, digest, opts)
+}
+
+// Decrypt implements crypto.Decrypter
+func (k *opaqueKey) Decrypt(rand io.Reader, msg []byte, opts crypto.DecrypterOpts) ([]byte, error) {
+	decrypter, ok := k.opaque.(crypto.Decrypter)
+	if !ok {
+		return nil, fmt.Errorf(`key %T is not a crypto.Decrypter`, k.opaque)
+	}
+	return decrypter.Decrypt(rand, msg, opts)
+}
+
+func (k *opaqueKey) Raw(v interface{}) error {
+	return blackmagic.AssignIfCompatible(v, k.opaque)
+}
+
+func (k *opaqueKey) Clone() (Key, error) {
+	pub, err := k.Key.Clone()
+	if err != nil {
+		return nil, fmt.Errorf(`failed to clone public key: %w`, err)
+	}
+	return &opaqueKey{
+		Key:    pub,
+		opaque: k.opaque,
+	}, nil
+}
+
+func (k *opaqueKey) MarshalJSON() ([]byte, error) {
+	return json.Marshal(k.Key)
+}
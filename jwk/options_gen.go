@@ -8,6 +8,7 @@ import (
 	"time"
 
 	"github.com/lestrrat-go/jwx/v2/internal/json"
+	"github.com/lestrrat-go/jwx/v2/jwa"
 	"github.com/lestrrat-go/option"
 )
 
@@ -57,6 +58,18 @@ func (*fetchOption) parseOption() {}
 
 func (*fetchOption) registerOption() {}
 
+// GenerateOption is a type of `Option` that can be passed to `jwk.Generate`
+type GenerateOption interface {
+	Option
+	generateOption()
+}
+
+type generateOption struct {
+	Option
+}
+
+func (*generateOption) generateOption() {}
+
 // ParseOption is a type of Option that can be passed to `jwk.Parse()`
 // ParseOption also implmentsthe `ReadFileOption` and `CacheOption`,
 // and thus safely be passed to `jwk.ReadFile` and `(*jwk.Cache).Configure()`
@@ -101,11 +114,17 @@ type registerOption struct {
 
 func (*registerOption) registerOption() {}
 
+type identAlgorithm struct{}
+type identCurve struct{}
 type identErrSink struct{}
 type identFS struct{}
 type identFetchWhitelist struct{}
 type identHTTPClient struct{}
 type identIgnoreParseError struct{}
+type identKeyID struct{}
+type identKeyOperations struct{}
+type identKeySize struct{}
+type identKeyUsage struct{}
 type identLocalRegistry struct{}
 type identMinRefreshInterval struct{}
 type identPEM struct{}
@@ -114,6 +133,14 @@ type identRefreshInterval struct{}
 type identRefreshWindow struct{}
 type identThumbprintHash struct{}
 
+func (identAlgorithm) String() string {
+	return "WithAlgorithm"
+}
+
+func (identCurve) String() string {
+	return "WithCurve"
+}
+
 func (identErrSink) String() string {
 	return "WithErrSink"
 }
@@ -134,6 +161,22 @@ func (identIgnoreParseError) String() string {
 	return "WithIgnoreParseError"
 }
 
+func (identKeyID) String() string {
+	return "WithKeyID"
+}
+
+func (identKeyOperations) String() string {
+	return "WithKeyOperations"
+}
+
+func (identKeySize) String() string {
+	return "WithKeySize"
+}
+
+func (identKeyUsage) String() string {
+	return "WithKeyUsage"
+}
+
 func (identLocalRegistry) String() string {
 	return "withLocalRegistry"
 }
@@ -162,6 +205,19 @@ func (identThumbprintHash) String() string {
 	return "WithThumbprintHash"
 }
 
+// WithAlgorithm specifies the value of the `alg` field to be assigned
+// to the key generated by `jwk.Generate()`
+func WithAlgorithm(v jwa.KeyAlgorithm) GenerateOption {
+	return &generateOption{option.New(identAlgorithm{}, v)}
+}
+
+// WithCurve specifies the curve to be used by `jwk.Generate()` when
+// generating EC or OKP keys. If unspecified, `jwa.P256` is used for EC keys,
+// and `jwa.Ed25519` is used for OKP keys.
+func WithCurve(v jwa.EllipticCurveAlgorithm) GenerateOption {
+	return &generateOption{option.New(identCurve{}, v)}
+}
+
 // WithErrSink specifies the `httprc.ErrSink` object that handles errors
 // that occurred during the cache's execution.
 //
@@ -211,6 +267,33 @@ func WithIgnoreParseError(v bool) ParseOption {
 	return &parseOption{option.New(identIgnoreParseError{}, v)}
 }
 
+// WithKeyID specifies the value of the `kid` field to be assigned
+// to the key generated by `jwk.Generate()`
+func WithKeyID(v string) GenerateOption {
+	return &generateOption{option.New(identKeyID{}, v)}
+}
+
+// WithKeyOperations specifies the value of the `key_ops` field to be assigned
+// to the key generated by `jwk.Generate()`
+func WithKeyOperations(v KeyOperationList) GenerateOption {
+	return &generateOption{option.New(identKeyOperations{}, v)}
+}
+
+// WithKeySize specifies the size of the key to be generated by `jwk.Generate()`.
+//
+// For RSA keys, the value is the size of the modulus in bits (default: 2048).
+// For symmetric (oct) keys, the value is the size of the key in bytes (default: 32).
+// The value is ignored for other key types.
+func WithKeySize(v int) GenerateOption {
+	return &generateOption{option.New(identKeySize{}, v)}
+}
+
+// WithKeyUsage specifies the value of the `use` field to be assigned
+// to the key generated by `jwk.Generate()`
+func WithKeyUsage(v KeyUsageType) GenerateOption {
+	return &generateOption{option.New(identKeyUsage{}, v)}
+}
+
 // This option is only available for internal code. Users don't get to play with it
 func withLocalRegistry(v *json.Registry) ParseOption {
 	return &parseOption{option.New(identLocalRegistry{}, v)}
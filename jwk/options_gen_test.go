@@ -9,11 +9,17 @@ import (
 )
 
 func TestOptionIdent(t *testing.T) {
+	require.Equal(t, "WithAlgorithm", identAlgorithm{}.String())
+	require.Equal(t, "WithCurve", identCurve{}.String())
 	require.Equal(t, "WithErrSink", identErrSink{}.String())
 	require.Equal(t, "WithFS", identFS{}.String())
 	require.Equal(t, "WithFetchWhitelist", identFetchWhitelist{}.String())
 	require.Equal(t, "WithHTTPClient", identHTTPClient{}.String())
 	require.Equal(t, "WithIgnoreParseError", identIgnoreParseError{}.String())
+	require.Equal(t, "WithKeyID", identKeyID{}.String())
+	require.Equal(t, "WithKeyOperations", identKeyOperations{}.String())
+	require.Equal(t, "WithKeySize", identKeySize{}.String())
+	require.Equal(t, "WithKeyUsage", identKeyUsage{}.String())
 	require.Equal(t, "withLocalRegistry", identLocalRegistry{}.String())
 	require.Equal(t, "WithMinRefreshInterval", identMinRefreshInterval{}.String())
 	require.Equal(t, "WithPEM", identPEM{}.String())
@@ -0,0 +1,14 @@
+//go:build jwx_brainpool
+// +build jwx_brainpool
+
+package jws
+
+import (
+	"github.com/lestrrat-go/jwx/v2/jwa"
+)
+
+func init() {
+	addAlgorithmForKeyType(jwa.EC, jwa.ESB256)
+	addAlgorithmForKeyType(jwa.EC, jwa.ESB384)
+	addAlgorithmForKeyType(jwa.EC, jwa.ESB512)
+}
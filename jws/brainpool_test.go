@@ -0,0 +1,44 @@
+//go:build jwx_brainpool
+// +build jwx_brainpool
+
+package jws_test
+
+import (
+	"testing"
+
+	"github.com/lestrrat-go/jwx/v2/internal/jwxtest"
+	"github.com/lestrrat-go/jwx/v2/jwa"
+	"github.com/lestrrat-go/jwx/v2/jwk"
+	"github.com/stretchr/testify/require"
+)
+
+func init() {
+	hasBrainpool = true
+}
+
+func TestESBJWK(t *testing.T) {
+	t.Parallel()
+	payload := []byte("Hello, World!")
+	curves := map[jwa.SignatureAlgorithm]jwa.EllipticCurveAlgorithm{
+		jwa.ESB256: jwa.BrainpoolP256r1,
+		jwa.ESB384: jwa.BrainpoolP384r1,
+		jwa.ESB512: jwa.BrainpoolP512r1,
+	}
+	for alg, crv := range curves {
+		alg := alg
+		crv := crv
+		t.Run(alg.String(), func(t *testing.T) {
+			t.Parallel()
+			key, err := jwxtest.GenerateEcdsaKey(crv)
+			require.NoError(t, err, `jwxtest.GenerateEcdsaKey should succeed`)
+			jwkKey, err := jwk.FromRaw(key.PublicKey)
+			require.NoError(t, err, `jwk.FromRaw should succeed`)
+			keys := map[string]interface{}{
+				"Verify(ecdsa.PublicKey)":  key.PublicKey,
+				"Verify(*ecdsa.PublicKey)": &key.PublicKey,
+				"Verify(jwk.Key)":          jwkKey,
+			}
+			testRoundtrip(t, payload, alg, key, keys)
+		})
+	}
+}
@@ -0,0 +1,71 @@
+package jws
+
+import (
+	"crypto"
+	"crypto/sha1"
+	"crypto/sha256"
+	"crypto/x509"
+	"fmt"
+
+	"github.com/lestrrat-go/jwx/v2/cert"
+	"github.com/lestrrat-go/jwx/v2/internal/base64"
+	"github.com/lestrrat-go/jwx/v2/jwk"
+)
+
+// setHeaders populates the "x5c", "x5t", and "x5t#S256" fields of
+// the given headers using the certificates
+func (c *withCertificate) setHeaders(hdrs Headers) error {
+	var chain cert.Chain
+	for i, crt := range append([]*x509.Certificate{c.leaf}, c.intermediates...) {
+		if crt == nil {
+			return fmt.Errorf(`certificate #%d is nil`, i)
+		}
+		encoded, err := cert.EncodeBase64(crt.Raw)
+		if err != nil {
+			return fmt.Errorf(`failed to encode certificate #%d: %w`, i, err)
+		}
+		if err := chain.Add(encoded); err != nil {
+			return fmt.Errorf(`failed to add certificate #%d: %w`, i, err)
+		}
+	}
+
+	if err := hdrs.Set(X509CertChainKey, &chain); err != nil {
+		return fmt.Errorf(`failed to set "x5c": %w`, err)
+	}
+
+	x5t := sha1.Sum(c.leaf.Raw)
+	if err := hdrs.Set(X509CertThumbprintKey, base64.EncodeToString(x5t[:])); err != nil {
+		return fmt.Errorf(`failed to set "x5t": %w`, err)
+	}
+
+	x5tS256 := sha256.Sum256(c.leaf.Raw)
+	if err := hdrs.Set(X509CertThumbprintS256Key, base64.EncodeToString(x5tS256[:])); err != nil {
+		return fmt.Errorf(`failed to set "x5t#S256": %w`, err)
+	}
+	return nil
+}
+
+// checkCertificateKey checks that the public key in the certificate
+// corresponds to the given signing key
+func checkCertificateKey(leaf *x509.Certificate, key interface{}) error {
+	if leaf == nil {
+		return fmt.Errorf(`certificate must not be nil`)
+	}
+
+	var pubkey interface{}
+	if signer, ok := key.(interface{ Public() crypto.PublicKey }); ok {
+		pubkey = signer.Public()
+	} else {
+		v, err := jwk.PublicRawKeyOf(key)
+		if err != nil {
+			return fmt.Errorf(`failed to obtain public key from signing key %T: %w`, key, err)
+		}
+		pubkey = v
+	}
+
+	leafKey, ok := leaf.PublicKey.(interface{ Equal(crypto.PublicKey) bool })
+	if !ok || !leafKey.Equal(pubkey) {
+		return fmt.Errorf(`public key in certificate does not match the signing key`)
+	}
+	return nil
+}
@@ -0,0 +1,77 @@
+package jws
+
+import (
+	"fmt"
+	"sync"
+)
+
+var muCriticalHeaders sync.RWMutex
+var criticalHeaders = map[string]struct{}{
+	"b64": {}, // RFC 7797
+}
+
+// RegisterCriticalHeader registers the name of a header parameter
+// that is understood and processed by the application. This function
+// has a global effect.
+//
+// As required by RFC 7515 Section 4.1.11, `jws.Verify()` refuses to
+// verify signatures whose "crit" header lists a name that is not
+// understood. By default only "b64" (RFC 7797) is understood. Names
+// may also be specified per call using `jws.WithCriticalHeaders()`.
+//
+// Note that registering a name only declares that the application
+// will take care of processing the header parameter: the library does
+// not do anything else with it.
+func RegisterCriticalHeader(name string) {
+	muCriticalHeaders.Lock()
+	defer muCriticalHeaders.Unlock()
+	criticalHeaders[name] = struct{}{}
+}
+
+func isCriticalHeaderUnderstood(name string, extra []string) bool {
+	for _, v := range extra {
+		if v == name {
+			return true
+		}
+	}
+
+	muCriticalHeaders.RLock()
+	defer muCriticalHeaders.RUnlock()
+	_, ok := criticalHeaders[name]
+	return ok
+}
+
+// checkCritical validates the "crit" header parameter of the given
+// signature as described in RFC 7515 Section 4.1.11. extra contains
+// the names that were specified via `jws.WithCriticalHeaders()`
+func (s *Signature) checkCritical(extra []string) error {
+	if hdrs := s.headers; hdrs != nil {
+		if _, ok := hdrs.Get(CriticalKey); ok {
+			return fmt.Errorf(`"crit" must be included in the protected header`)
+		}
+	}
+
+	protected := s.protected
+	if protected == nil {
+		return nil
+	}
+
+	if _, ok := protected.Get(CriticalKey); !ok {
+		return nil
+	}
+
+	crit := protected.Critical()
+	if len(crit) == 0 {
+		return fmt.Errorf(`"crit" must not be empty`)
+	}
+
+	for _, name := range crit {
+		if _, ok := protected.Get(name); !ok {
+			return fmt.Errorf(`critical header %q is not present in the protected header`, name)
+		}
+		if !isCriticalHeaderUnderstood(name, extra) {
+			return fmt.Errorf(`critical header %q is not understood`, name)
+		}
+	}
+	return nil
+}
@@ -3,17 +3,21 @@ package jws
 import (
 	"crypto"
 	"crypto/ecdsa"
+	"crypto/elliptic"
 	"crypto/rand"
 	"encoding/asn1"
 	"fmt"
+	"hash"
 	"math/big"
 
+	"github.com/lestrrat-go/jwx/v2/internal/ecutil"
 	"github.com/lestrrat-go/jwx/v2/internal/keyconv"
 	"github.com/lestrrat-go/jwx/v2/internal/pool"
 	"github.com/lestrrat-go/jwx/v2/jwa"
 )
 
 var ecdsaSigners map[jwa.SignatureAlgorithm]*ecdsaSigner
+var deterministicECDSASigners map[jwa.SignatureAlgorithm]*ecdsaSigner
 var ecdsaVerifiers map[jwa.SignatureAlgorithm]*ecdsaVerifier
 
 func init() {
@@ -22,8 +26,12 @@ func init() {
 		jwa.ES384:  crypto.SHA384,
 		jwa.ES512:  crypto.SHA512,
 		jwa.ES256K: crypto.SHA256,
+		jwa.ESB256: crypto.SHA256,
+		jwa.ESB384: crypto.SHA384,
+		jwa.ESB512: crypto.SHA512,
 	}
 	ecdsaSigners = make(map[jwa.SignatureAlgorithm]*ecdsaSigner)
+	deterministicECDSASigners = make(map[jwa.SignatureAlgorithm]*ecdsaSigner)
 	ecdsaVerifiers = make(map[jwa.SignatureAlgorithm]*ecdsaVerifier)
 
 	for alg, hash := range algs {
@@ -31,6 +39,11 @@ func init() {
 			alg:  alg,
 			hash: hash,
 		}
+		deterministicECDSASigners[alg] = &ecdsaSigner{
+			alg:           alg,
+			hash:          hash,
+			deterministic: true,
+		}
 		ecdsaVerifiers[alg] = &ecdsaVerifier{
 			alg:  alg,
 			hash: hash,
@@ -42,10 +55,58 @@ func newECDSASigner(alg jwa.SignatureAlgorithm) Signer {
 	return ecdsaSigners[alg]
 }
 
+// NewDeterministicECDSASigner creates a signer for ES256, ES384, ES512,
+// ES256K, ESB256, ESB384, or ESB512 that computes signatures using deterministic nonces as described
+// in RFC 6979, instead of using a random source. Signing the same payload
+// with the same key always produces the same signature.
+//
+// The key must be an ECDSA private key (either raw or a `jwk.Key`).
+// Opaque `crypto.Signer` implementations cannot be used, as the
+// nonce is derived from the private key.
+//
+// See also `jws.WithDeterministicSignatures()`.
+func NewDeterministicECDSASigner(alg jwa.SignatureAlgorithm) (Signer, error) {
+	signer, ok := deterministicECDSASigners[alg]
+	if !ok {
+		return nil, fmt.Errorf(`unsupported algorithm for deterministic ECDSA signatures: %s`, alg)
+	}
+	return signer, nil
+}
+
+// brainpoolAlgorithmCurves maps the fully specified Brainpool algorithms
+// (RFC 9864) to the only curve that they may be used with
+var brainpoolAlgorithmCurves = map[jwa.SignatureAlgorithm]jwa.EllipticCurveAlgorithm{
+	jwa.ESB256: jwa.BrainpoolP256r1,
+	jwa.ESB384: jwa.BrainpoolP384r1,
+	jwa.ESB512: jwa.BrainpoolP512r1,
+}
+
+// checkECDSACurve makes sure that the curve can be used with the algorithm.
+// RFC 7518 section 3.4 only defines the ES* algorithms for the NIST curves
+// (and RFC 8812 for secp256k1), so the Brainpool curves are rejected for
+// them. Keys using the Brainpool curves must instead use the ESB*
+// algorithms from RFC 9864, each of which is bound to a single curve.
+func checkECDSACurve(alg jwa.SignatureAlgorithm, crv elliptic.Curve) error {
+	name := jwa.EllipticCurveAlgorithm(crv.Params().Name)
+	if expected, ok := brainpoolAlgorithmCurves[alg]; ok {
+		if name != expected {
+			return fmt.Errorf(`curve %s cannot be used with %s (expected %s)`, name, alg, expected)
+		}
+		return nil
+	}
+
+	switch name {
+	case jwa.BrainpoolP256r1, jwa.BrainpoolP384r1, jwa.BrainpoolP512r1:
+		return fmt.Errorf(`curve %s cannot be used with %s (use ESB256, ESB384, or ESB512 instead)`, name, alg)
+	}
+	return nil
+}
+
 // ecdsaSigners are immutable.
 type ecdsaSigner struct {
-	alg  jwa.SignatureAlgorithm
-	hash crypto.Hash
+	alg           jwa.SignatureAlgorithm
+	hash          crypto.Hash
+	deterministic bool // true if RFC 6979 nonces should be used
 }
 
 func (es ecdsaSigner) Algorithm() jwa.SignatureAlgorithm {
@@ -53,14 +114,21 @@ func (es ecdsaSigner) Algorithm() jwa.SignatureAlgorithm {
 }
 
 func (es *ecdsaSigner) Sign(payload []byte, key interface{}) ([]byte, error) {
-	if key == nil {
-		return nil, fmt.Errorf(`missing private key while signing payload`)
-	}
-
 	h := es.hash.New()
 	if _, err := h.Write(payload); err != nil {
 		return nil, fmt.Errorf(`failed to write payload using ecdsa: %w`, err)
 	}
+	return es.signDigest(h.Sum(nil), key)
+}
+
+func (es *ecdsaSigner) newHash(interface{}) (hash.Hash, error) {
+	return es.hash.New(), nil
+}
+
+func (es *ecdsaSigner) signDigest(digest []byte, key interface{}) ([]byte, error) {
+	if key == nil {
+		return nil, fmt.Errorf(`missing private key while signing payload`)
+	}
 
 	signer, ok := key.(crypto.Signer)
 	if ok {
@@ -75,7 +143,10 @@ func (es *ecdsaSigner) Sign(payload []byte, key interface{}) ([]byte, error) {
 	var r, s *big.Int
 	var curveBits int
 	if ok {
-		signed, err := signer.Sign(rand.Reader, h.Sum(nil), es.hash)
+		if es.deterministic {
+			return nil, fmt.Errorf(`deterministic ECDSA signatures require an ecdsa.PrivateKey, got %T`, key)
+		}
+		signed, err := signer.Sign(rand.Reader, digest, es.hash)
 		if err != nil {
 			return nil, err
 		}
@@ -100,6 +171,9 @@ func (es *ecdsaSigner) Sign(payload []byte, key interface{}) ([]byte, error) {
 		if !ok {
 			return nil, fmt.Errorf(`expected *ecdsa.PublicKey, got %T`, pubkey)
 		}
+		if err := checkECDSACurve(es.alg, pubkey.Curve); err != nil {
+			return nil, err
+		}
 		curveBits = pubkey.Curve.Params().BitSize
 
 		r = p.R
@@ -109,8 +183,17 @@ func (es *ecdsaSigner) Sign(payload []byte, key interface{}) ([]byte, error) {
 		if err := keyconv.ECDSAPrivateKey(&privkey, key); err != nil {
 			return nil, fmt.Errorf(`failed to retrieve ecdsa.PrivateKey out of %T: %w`, key, err)
 		}
+		if err := checkECDSACurve(es.alg, privkey.Curve); err != nil {
+			return nil, err
+		}
 		curveBits = privkey.Curve.Params().BitSize
-		rtmp, stmp, err := ecdsa.Sign(rand.Reader, &privkey, h.Sum(nil))
+		var rtmp, stmp *big.Int
+		var err error
+		if es.deterministic {
+			rtmp, stmp, err = ecutil.SignDeterministic(&privkey, es.hash, digest)
+		} else {
+			rtmp, stmp, err = ecdsa.Sign(rand.Reader, &privkey, digest)
+		}
 		if err != nil {
 			return nil, fmt.Errorf(`failed to sign payload using ecdsa: %w`, err)
 		}
@@ -151,6 +234,18 @@ func (v ecdsaVerifier) Algorithm() jwa.SignatureAlgorithm {
 }
 
 func (v *ecdsaVerifier) Verify(payload []byte, signature []byte, key interface{}) error {
+	h := v.hash.New()
+	if _, err := h.Write(payload); err != nil {
+		return fmt.Errorf(`failed to write payload using ecdsa: %w`, err)
+	}
+	return v.verifyDigest(h.Sum(nil), signature, key)
+}
+
+func (v *ecdsaVerifier) newHash(interface{}) (hash.Hash, error) {
+	return v.hash.New(), nil
+}
+
+func (v *ecdsaVerifier) verifyDigest(digest []byte, signature []byte, key interface{}) error {
 	if key == nil {
 		return fmt.Errorf(`missing public key while verifying payload`)
 	}
@@ -172,6 +267,10 @@ func (v *ecdsaVerifier) Verify(payload []byte, signature []byte, key interface{}
 		}
 	}
 
+	if err := checkECDSACurve(v.alg, pubkey.Curve); err != nil {
+		return err
+	}
+
 	if !pubkey.Curve.IsOnCurve(pubkey.X, pubkey.Y) {
 		return fmt.Errorf(`public key used does not contain a point (X,Y) on the curve`)
 	}
@@ -185,12 +284,7 @@ func (v *ecdsaVerifier) Verify(payload []byte, signature []byte, key interface{}
 	r.SetBytes(signature[:n])
 	s.SetBytes(signature[n:])
 
-	h := v.hash.New()
-	if _, err := h.Write(payload); err != nil {
-		return fmt.Errorf(`failed to write payload using ecdsa: %w`, err)
-	}
-
-	if !ecdsa.Verify(&pubkey, h.Sum(nil), r, s) {
+	if !ecdsa.Verify(&pubkey, digest, r, s) {
 		return fmt.Errorf(`failed to verify signature using ecdsa`)
 	}
 	return nil
@@ -6,8 +6,10 @@ import (
 	"crypto/rand"
 	"fmt"
 
+	"github.com/cloudflare/circl/sign/ed448"
 	"github.com/lestrrat-go/jwx/v2/internal/keyconv"
 	"github.com/lestrrat-go/jwx/v2/jwa"
+	"github.com/lestrrat-go/jwx/v2/jwk"
 )
 
 type eddsaSigner struct{}
@@ -20,22 +22,46 @@ func (s eddsaSigner) Algorithm() jwa.SignatureAlgorithm {
 	return jwa.EdDSA
 }
 
+// isEd448Key returns true if the given key is known to be an Ed448 key.
+// The EdDSA algorithm covers both Ed25519 and Ed448, so we need to
+// look at the key to figure out which one to use.
+func isEd448Key(key interface{}) bool {
+	switch key := key.(type) {
+	case ed448.PrivateKey, *ed448.PrivateKey, ed448.PublicKey, *ed448.PublicKey:
+		return true
+	case jwk.OKPPrivateKey:
+		return key.Crv() == jwa.Ed448
+	case jwk.OKPPublicKey:
+		return key.Crv() == jwa.Ed448
+	default:
+		return false
+	}
+}
+
 func (s eddsaSigner) Sign(payload []byte, key interface{}) ([]byte, error) {
 	if key == nil {
 		return nil, fmt.Errorf(`missing private key while signing payload`)
 	}
 
-	// The ed25519.PrivateKey object implements crypto.Signer, so we should
-	// simply accept a crypto.Signer here.
+	// The ed25519.PrivateKey and ed448.PrivateKey objects implement
+	// crypto.Signer, so we should simply accept a crypto.Signer here.
 	signer, ok := key.(crypto.Signer)
 	if !ok {
 		// This fallback exists for cases when jwk.Key was passed, or
 		// users gave us a pointer instead of non-pointer, etc.
-		var privkey ed25519.PrivateKey
-		if err := keyconv.Ed25519PrivateKey(&privkey, key); err != nil {
-			return nil, fmt.Errorf(`failed to retrieve ed25519.PrivateKey out of %T: %w`, key, err)
+		if isEd448Key(key) {
+			var privkey ed448.PrivateKey
+			if err := keyconv.Ed448PrivateKey(&privkey, key); err != nil {
+				return nil, fmt.Errorf(`failed to retrieve ed448.PrivateKey out of %T: %w`, key, err)
+			}
+			signer = privkey
+		} else {
+			var privkey ed25519.PrivateKey
+			if err := keyconv.Ed25519PrivateKey(&privkey, key); err != nil {
+				return nil, fmt.Errorf(`failed to retrieve ed25519.PrivateKey out of %T: %w`, key, err)
+			}
+			signer = privkey
 		}
-		signer = privkey
 	}
 	return signer.Sign(rand.Reader, payload, crypto.Hash(0))
 }
@@ -51,21 +77,34 @@ func (v eddsaVerifier) Verify(payload, signature []byte, key interface{}) (err e
 		return fmt.Errorf(`missing public key while verifying payload`)
 	}
 
-	var pubkey ed25519.PublicKey
-	signer, ok := key.(crypto.Signer)
-	if ok {
-		v := signer.Public()
-		pubkey, ok = v.(ed25519.PublicKey)
-		if !ok {
-			return fmt.Errorf(`expected crypto.Signer.Public() to return ed25519.PublicKey, but got %T`, v)
+	var pubkey interface{}
+	if signer, ok := key.(crypto.Signer); ok {
+		pubkey = signer.Public()
+	} else if isEd448Key(key) {
+		var ed448key ed448.PublicKey
+		if err := keyconv.Ed448PublicKey(&ed448key, key); err != nil {
+			return fmt.Errorf(`failed to retrieve ed448.PublicKey out of %T: %w`, key, err)
 		}
+		pubkey = ed448key
 	} else {
-		if err := keyconv.Ed25519PublicKey(&pubkey, key); err != nil {
+		var ed25519key ed25519.PublicKey
+		if err := keyconv.Ed25519PublicKey(&ed25519key, key); err != nil {
 			return fmt.Errorf(`failed to retrieve ed25519.PublicKey out of %T: %w`, key, err)
 		}
+		pubkey = ed25519key
+	}
+
+	var verified bool
+	switch pubkey := pubkey.(type) {
+	case ed25519.PublicKey:
+		verified = ed25519.Verify(pubkey, payload, signature)
+	case ed448.PublicKey:
+		verified = ed448.Verify(pubkey, payload, signature, "")
+	default:
+		return fmt.Errorf(`expected crypto.Signer.Public() to return ed25519.PublicKey or ed448.PublicKey, but got %T`, pubkey)
 	}
 
-	if !ed25519.Verify(pubkey, payload, signature) {
+	if !verified {
 		return fmt.Errorf(`failed to match EdDSA signature`)
 	}
 
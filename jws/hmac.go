@@ -12,6 +12,7 @@ import (
 )
 
 var hmacSignFuncs = map[jwa.SignatureAlgorithm]hmacSignFunc{}
+var hmacHashFuncs = map[jwa.SignatureAlgorithm]func() hash.Hash{}
 
 func init() {
 	algs := map[jwa.SignatureAlgorithm]func() hash.Hash{
@@ -22,12 +23,14 @@ func init() {
 
 	for alg, h := range algs {
 		hmacSignFuncs[alg] = makeHMACSignFunc(h)
+		hmacHashFuncs[alg] = h
 	}
 }
 
 func newHMACSigner(alg jwa.SignatureAlgorithm) Signer {
 	return &HMACSigner{
 		alg:  alg,
+		hash: hmacHashFuncs[alg],
 		sign: hmacSignFuncs[alg], // we know this will succeed
 	}
 }
@@ -47,6 +50,14 @@ func (s HMACSigner) Algorithm() jwa.SignatureAlgorithm {
 }
 
 func (s HMACSigner) Sign(payload []byte, key interface{}) ([]byte, error) {
+	hmackey, err := hmacKey(key)
+	if err != nil {
+		return nil, err
+	}
+	return s.sign(payload, hmackey)
+}
+
+func hmacKey(key interface{}) ([]byte, error) {
 	var hmackey []byte
 	if err := keyconv.ByteSliceKey(&hmackey, key); err != nil {
 		return nil, fmt.Errorf(`invalid key type %T. []byte is required: %w`, key, err)
@@ -55,8 +66,21 @@ func (s HMACSigner) Sign(payload []byte, key interface{}) ([]byte, error) {
 	if len(hmackey) == 0 {
 		return nil, fmt.Errorf(`missing key while signing payload`)
 	}
+	return hmackey, nil
+}
 
-	return s.sign(payload, hmackey)
+// newHash returns the keyed hash for HMAC. As the MAC is computed
+// by the hash itself, signDigest simply returns the digest
+func (s HMACSigner) newHash(key interface{}) (hash.Hash, error) {
+	hmackey, err := hmacKey(key)
+	if err != nil {
+		return nil, err
+	}
+	return hmac.New(s.hash, hmackey), nil
+}
+
+func (s HMACSigner) signDigest(digest []byte, _ interface{}) ([]byte, error) {
+	return digest, nil
 }
 
 func newHMACVerifier(alg jwa.SignatureAlgorithm) Verifier {
@@ -75,3 +99,18 @@ func (v HMACVerifier) Verify(payload, signature []byte, key interface{}) (err er
 	}
 	return nil
 }
+
+func (v HMACVerifier) newHash(key interface{}) (hash.Hash, error) {
+	ds, ok := v.signer.(digestSigner)
+	if !ok {
+		return nil, fmt.Errorf(`signer %T does not support streaming`, v.signer)
+	}
+	return ds.newHash(key)
+}
+
+func (v HMACVerifier) verifyDigest(digest, signature []byte, _ interface{}) error {
+	if !hmac.Equal(signature, digest) {
+		return fmt.Errorf(`failed to match hmac signature`)
+	}
+	return nil
+}
@@ -1,6 +1,9 @@
 package jws
 
 import (
+	"context"
+	"hash"
+
 	"github.com/lestrrat-go/iter/mapiter"
 	"github.com/lestrrat-go/jwx/v2/internal/iter"
 	"github.com/lestrrat-go/jwx/v2/jwa"
@@ -18,10 +21,9 @@ type DecodeCtx interface {
 // signed payload with. You should only use this when you want to actually
 // programmatically view the contents of the full JWS payload.
 //
-// As of this version, there is one big incompatibility when using Message
-// objects to convert between compact and JSON representations.
-// The protected header is sometimes encoded differently from the original
-// message and the JSON serialization that we use in Go.
+// When a message is parsed, the original encoding of the protected
+// headers is retained for each signature. Protected headers may be
+// encoded differently from what we would produce when marshaling them.
 //
 // For example, the protected header `eyJ0eXAiOiJKV1QiLA0KICJhbGciOiJIUzI1NiJ9`
 // decodes to
@@ -34,19 +36,16 @@ type DecodeCtx interface {
 //
 //	{"typ":"JWT","alg":"HS256"}
 //
-// Notice that serialization lacks a line break and a space between `"JWT",`
-// and `"alg"`. This causes a problem when verifying the signatures AFTER
-// a compact JWS message has been unmarshaled into a jws.Message.
-//
-// jws.Verify() doesn't go through this step, and therefore this does not
-// manifest itself. However, you may see this discrepancy when you manually
-// go through these conversions, and/or use the `jwx` tool like so:
+// Because the signature is computed over the original bytes, the retained
+// encoding is used when the message is serialized again using
+// `json.Marshal()` or `jws.Compact()`, so that the result can still be
+// verified, e.g.
 //
 //	jwx jws parse message.jws | jwx jws verify --key somekey.jwk --stdin
 //
-// In this scenario, the first `jwx jws parse` outputs a parsed jws.Message
-// which is marshaled into JSON. At this point the message's protected
-// headers and the signatures don't match.
+// Note that the original encoding is only used as long as the protected
+// headers have not been modified. Once they are modified (or replaced via
+// `SetProtectedHeaders()`), they are marshaled from scratch.
 //
 // To sign and verify, use the appropriate `Sign()` and `Verify()` functions.
 type Message struct {
@@ -62,6 +61,13 @@ type Signature struct {
 	protected Headers // Protected Headers
 	signature []byte  // Signature
 	detached  bool
+
+	// rawProtected stores the original (base64 encoded) protected headers
+	// of a parsed message, and protectedSnapshot stores their JSON
+	// representation at the time of parsing, so that we can tell if
+	// they have been modified since
+	rawProtected      []byte
+	protectedSnapshot []byte
 }
 
 type Visitor = iter.MapVisitor
@@ -82,11 +88,35 @@ type Signer interface {
 	Algorithm() jwa.SignatureAlgorithm
 }
 
+// ContextSigner is implemented by keys that compute signatures by
+// themselves, for example by delegating to a remote KMS or HSM. When a
+// key passed to `jws.WithKey()` implements this interface, `jws.Sign()`
+// uses it instead of the built-in Signer for the algorithm, regardless
+// of the algorithm. The context.Context specified using
+// `jws.WithContext()` is passed along, so that remote calls can be
+// cancelled or traced.
+//
+// The signature must be returned in the format required by the
+// algorithm as specified in RFC 7518. For example, ECDSA signatures
+// must be the concatenation of R and S, not an ASN.1 DER structure.
+//
+// If the `jwx.Policy` in effect restricts the RSA key size or the curves,
+// the key must also implement `Public() crypto.PublicKey` so that it can
+// be checked, otherwise `jws.Sign()` fails. As the signature is computed
+// by the key, `jws.WithDeterministicSignatures()` can not be used with
+// the ECDSA algorithms.
+type ContextSigner interface {
+	// SignContext creates a signature for the given signing input
+	// (the encoded protected header and payload) using `alg`.
+	SignContext(ctx context.Context, alg jwa.SignatureAlgorithm, signingInput []byte) ([]byte, error)
+}
+
 type hmacSignFunc func([]byte, []byte) ([]byte, error)
 
 // HMACSigner uses crypto/hmac to sign the payloads.
 type HMACSigner struct {
 	alg  jwa.SignatureAlgorithm
+	hash func() hash.Hash
 	sign hmacSignFunc
 }
 
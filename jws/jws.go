@@ -36,22 +36,26 @@ import (
 	"unicode"
 	"unicode/utf8"
 
+	"github.com/cloudflare/circl/sign/ed448"
 	"github.com/lestrrat-go/blackmagic"
+	"github.com/lestrrat-go/jwx/v2"
 	"github.com/lestrrat-go/jwx/v2/internal/base64"
 	"github.com/lestrrat-go/jwx/v2/internal/json"
 	"github.com/lestrrat-go/jwx/v2/internal/pool"
 	"github.com/lestrrat-go/jwx/v2/jwa"
 	"github.com/lestrrat-go/jwx/v2/jwk"
 	"github.com/lestrrat-go/jwx/v2/x25519"
+	"github.com/lestrrat-go/jwx/v2/x448"
 )
 
 var registry = json.NewRegistry()
 
 type payloadSigner struct {
-	signer    Signer
-	key       interface{}
-	protected Headers
-	public    Headers
+	signer      Signer
+	key         interface{}
+	protected   Headers
+	public      Headers
+	certificate *withCertificate
 }
 
 func (s *payloadSigner) Sign(payload []byte) ([]byte, error) {
@@ -145,15 +149,63 @@ var _ = fmtMax
 // Look for options that return `jws.SignOption` or `jws.SignVerifyOption`
 // for a complete list of options that can be passed to this function.
 func Sign(payload []byte, options ...SignOption) ([]byte, error) {
+	sc, err := makeSignContext(payload, options)
+	if err != nil {
+		return nil, fmt.Errorf(`jws.Sign: %w`, err)
+	}
+
+	// Create a Message object with all the bits and bobs, and we'll
+	// serialize it in the end
+	var result Message
+
+	result.payload = sc.payload
+
+	result.signatures = make([]*Signature, 0, len(sc.signers))
+	for i, signer := range sc.signers {
+		sig, err := signer.newSignature(sc.detached)
+		if err != nil {
+			return nil, err
+		}
+		_, _, err = sig.sign(sc.ctx, sc.payload, signer.signer, signer.key)
+		if err != nil {
+			return nil, fmt.Errorf(`failed to generate signature for signer #%d (alg=%s): %w`, i, signer.Algorithm(), err)
+		}
+
+		result.signatures = append(result.signatures, sig)
+	}
+
+	return sc.serialize(&result)
+}
+
+// signContext holds the values that were extracted from the options
+// passed to `jws.Sign()` and `jws.SignReader()`
+type signContext struct {
+	ctx      context.Context
+	format   int
+	signers  []*payloadSigner
+	detached bool
+	payload  []byte
+}
+
+func makeSignContext(payload []byte, options []SignOption) (*signContext, error) {
 	format := fmtCompact
 	var signers []*payloadSigner
 	var detached bool
 	var noneSignature *payloadSigner
+	var deterministic bool
+	policy := jwx.DefaultPolicy()
+	ctx := context.Background()
 	for _, option := range options {
 		//nolint:forcetypeassert
 		switch option.Ident() {
+		case identPolicy{}:
+			policy = option.Value().(*jwx.Policy)
+		case identContext{}:
+			ctx = option.Value().(context.Context)
 		case identSerialization{}:
 			format = option.Value().(int)
+		case identDeterministicSignatures{}:
+			deterministic = option.Value().(bool)
 		case identInsecureNoSignature{}:
 			data := option.Value().(*withInsecureNoSignature)
 			// only the last one is used (we overwrite previous values)
@@ -166,35 +218,65 @@ func Sign(payload []byte, options ...SignOption) ([]byte, error) {
 
 			alg, ok := data.alg.(jwa.SignatureAlgorithm)
 			if !ok {
-				return nil, fmt.Errorf(`jws.Sign: expected algorithm to be of type jwa.SignatureAlgorithm but got (%[1]q, %[1]T)`, data.alg)
+				return nil, fmt.Errorf(`expected algorithm to be of type jwa.SignatureAlgorithm but got (%[1]q, %[1]T)`, data.alg)
 			}
 
 			// No, we don't accept "none" here.
 			if alg == jwa.NoSignature {
-				return nil, fmt.Errorf(`jws.Sign: "none" (jwa.NoSignature) cannot be used with jws.WithKey`)
+				return nil, fmt.Errorf(`"none" (jwa.NoSignature) cannot be used with jws.WithKey`)
 			}
 
 			signer, err := makeSigner(alg, data.key, data.public, data.protected)
 			if err != nil {
-				return nil, fmt.Errorf(`jws.Sign: failed to create signer: %w`, err)
+				return nil, fmt.Errorf(`failed to create signer: %w`, err)
+			}
+
+			if data.certificate != nil {
+				if err := checkCertificateKey(data.certificate.leaf, data.key); err != nil {
+					return nil, fmt.Errorf(`invalid certificate for signer (alg=%s): %w`, alg, err)
+				}
+				signer.certificate = data.certificate
 			}
 			signers = append(signers, signer)
 		case identDetachedPayload{}:
 			detached = true
 			if payload != nil {
-				return nil, fmt.Errorf(`jws.Sign: payload must be nil when jws.WithDetachedPayload() is specified`)
+				return nil, fmt.Errorf(`payload must be nil when jws.WithDetachedPayload() is specified`)
 			}
 			payload = option.Value().([]byte)
 		}
 	}
 
+	if deterministic {
+		for _, signer := range signers {
+			if v, ok := deterministicECDSASigners[signer.Algorithm()]; ok {
+				// Keys that compute signatures by themselves never reach
+				// the deterministic signer, so refuse them instead of
+				// silently producing randomized signatures
+				if _, ok := signer.key.(ContextSigner); ok {
+					return nil, fmt.Errorf(`jws.WithDeterministicSignatures() cannot be used with keys implementing jws.ContextSigner (alg=%s)`, signer.Algorithm())
+				}
+				signer.signer = v
+			}
+		}
+	}
+
+	for _, signer := range signers {
+		if err := policy.CheckSignature(signer.Algorithm(), signer.key); err != nil {
+			return nil, err
+		}
+	}
+
 	if noneSignature != nil {
+		if err := policy.CheckSignature(jwa.NoSignature, nil); err != nil {
+			return nil, err
+		}
 		signers = append(signers, noneSignature)
 	}
 
 	lsigner := len(signers)
 	if lsigner == 0 {
-		return nil, fmt.Errorf(`jws.Sign: no signers available. Specify an alogirthm and akey using jws.WithKey()`)
+		return nil, fmt.Errorf(`no signers available. Specify an alogirthm and akey using jws.WithKey()`)
 	}
 
 	// Design note: while we could have easily set format = fmtJSON when
@@ -206,48 +288,53 @@ func Sign(payload []byte, options ...SignOption) ([]byte, error) {
 	// Therefore, instead of making implicit format conversions, we force the
 	// user to spell it out as `jws.Sign(..., jws.WithJSON(), jws.WithKey(...), jws.WithKey(...))`
 	if format == fmtCompact && lsigner != 1 {
-		return nil, fmt.Errorf(`jws.Sign: cannot have multiple signers (keys) specified for compact serialization. Use only one jws.WithKey()`)
+		return nil, fmt.Errorf(`cannot have multiple signers (keys) specified for compact serialization. Use only one jws.WithKey()`)
 	}
 
-	// Create a Message object with all the bits and bobs, and we'll
-	// serialize it in the end
-	var result Message
-
-	result.payload = payload
+	return &signContext{
+		ctx:      ctx,
+		format:   format,
+		signers:  signers,
+		detached: detached,
+		payload:  payload,
+	}, nil
+}
 
-	result.signatures = make([]*Signature, 0, len(signers))
-	for i, signer := range signers {
-		protected := signer.ProtectedHeader()
-		if protected == nil {
-			protected = NewHeaders()
-		}
+// newSignature creates a Signature object with the headers
+// from the signer populated
+func (s *payloadSigner) newSignature(detached bool) (*Signature, error) {
+	protected := s.ProtectedHeader()
+	if protected == nil {
+		protected = NewHeaders()
+	}
 
-		if err := protected.Set(AlgorithmKey, signer.Algorithm()); err != nil {
-			return nil, fmt.Errorf(`failed to set "alg" header: %w`, err)
-		}
+	if err := protected.Set(AlgorithmKey, s.Algorithm()); err != nil {
+		return nil, fmt.Errorf(`failed to set "alg" header: %w`, err)
+	}
 
-		if key, ok := signer.key.(jwk.Key); ok {
-			if kid := key.KeyID(); kid != "" {
-				if err := protected.Set(KeyIDKey, kid); err != nil {
-					return nil, fmt.Errorf(`failed to set "kid" header: %w`, err)
-				}
+	if key, ok := s.key.(jwk.Key); ok {
+		if kid := key.KeyID(); kid != "" {
+			if err := protected.Set(KeyIDKey, kid); err != nil {
+				return nil, fmt.Errorf(`failed to set "kid" header: %w`, err)
 			}
 		}
-		sig := &Signature{
-			headers:   signer.PublicHeader(),
-			protected: protected,
-			// cheat. FIXXXXXXMEEEEEE
-			detached: detached,
-		}
-		_, _, err := sig.Sign(payload, signer.signer, signer.key)
-		if err != nil {
-			return nil, fmt.Errorf(`failed to generate signature for signer #%d (alg=%s): %w`, i, signer.Algorithm(), err)
-		}
+	}
 
-		result.signatures = append(result.signatures, sig)
+	if c := s.certificate; c != nil {
+		if err := c.setHeaders(protected); err != nil {
+			return nil, fmt.Errorf(`failed to set certificate headers: %w`, err)
+		}
 	}
+	return &Signature{
+		headers:   s.PublicHeader(),
+		protected: protected,
+		// cheat. FIXXXXXXMEEEEEE
+		detached: detached,
+	}, nil
+}
 
-	switch format {
+func (sc *signContext) serialize(result *Message) ([]byte, error) {
+	switch sc.format {
 	case fmtJSON:
 		return json.Marshal(result)
 	case fmtJSONPretty:
@@ -256,10 +343,10 @@ func Sign(payload []byte, options ...SignOption) ([]byte, error) {
 		// Take the only signature object, and convert it into a Compact
 		// serialization format
 		var compactOpts []CompactOption
-		if detached {
-			compactOpts = append(compactOpts, WithDetached(detached))
+		if sc.detached {
+			compactOpts = append(compactOpts, WithDetached(sc.detached))
 		}
-		return Compact(&result, compactOpts...)
+		return Compact(result, compactOpts...)
 	default:
 		return nil, fmt.Errorf(`jws.Sign: invalid serialization format`)
 	}
@@ -290,12 +377,18 @@ func Verify(buf []byte, options ...VerifyOption) ([]byte, error) {
 	var detachedPayload []byte
 	var keyProviders []KeyProvider
 	var keyUsed interface{}
+	var understood []string
+	policy := jwx.DefaultPolicy()
 
 	ctx := context.Background()
 
 	//nolint:forcetypeassert
 	for _, option := range options {
 		switch option.Ident() {
+		case identPolicy{}:
+			policy = option.Value().(*jwx.Policy)
+		case identCriticalHeaders{}:
+			understood = append(understood, option.Value().([]string)...)
 		case identMessage{}:
 			dst = option.Value().(*Message)
 		case identDetachedPayload{}:
@@ -350,23 +443,19 @@ func Verify(buf []byte, options ...VerifyOption) ([]byte, error) {
 	verifyBuf := pool.GetBytesBuffer()
 	defer pool.ReleaseBytesBuffer(verifyBuf)
 
+	// policyErr and critErr are reported if no key could verify the message
+	var policyErr, critErr error
 	for i, sig := range msg.signatures {
-		verifyBuf.Reset()
-
-		var encodedProtectedHeader string
-		if rbp, ok := sig.protected.(interface{ rawBuffer() []byte }); ok {
-			if raw := rbp.rawBuffer(); raw != nil {
-				encodedProtectedHeader = base64.EncodeToString(raw)
-			}
+		if err := sig.checkCritical(understood); err != nil {
+			critErr = fmt.Errorf(`signature #%d: %w`, i+1, err)
+			continue
 		}
 
-		if encodedProtectedHeader == "" {
-			protected, err := json.Marshal(sig.protected)
-			if err != nil {
-				return nil, fmt.Errorf(`failed to marshal "protected" for signature #%d: %w`, i+1, err)
-			}
+		verifyBuf.Reset()
 
-			encodedProtectedHeader = base64.EncodeToString(protected)
+		encodedProtectedHeader, err := sig.encodedProtectedHeader()
+		if err != nil {
+			return nil, fmt.Errorf(`failed to marshal "protected" for signature #%d: %w`, i+1, err)
 		}
 
 		verifyBuf.WriteString(encodedProtectedHeader)
@@ -386,6 +475,11 @@ func Verify(buf []byte, options ...VerifyOption) ([]byte, error) {
 				//nolint:forcetypeassert
 				alg := pair.alg.(jwa.SignatureAlgorithm)
 				key := pair.key
+				if err := policy.CheckSignature(alg, key); err != nil {
+					policyErr = err
+					continue
+				}
+
 				verifier, err := NewVerifier(alg)
 				if err != nil {
 					return nil, fmt.Errorf(`failed to create verifier for algorithm %q: %w`, alg, err)
@@ -409,9 +503,44 @@ func Verify(buf []byte, options ...VerifyOption) ([]byte, error) {
 			}
 		}
 	}
+	if critErr != nil {
+		return nil, fmt.Errorf(`could not verify message using any of the signatures or keys: %w`, critErr)
+	}
+	if policyErr != nil {
+		return nil, fmt.Errorf(`could not verify message using any of the signatures or keys: %w`, policyErr)
+	}
 	return nil, fmt.Errorf(`could not verify message using any of the signatures or keys`)
 }
 
+// encodedProtectedHeader returns the base64 encoded protected header
+// that was used to compute the signature. If the signature was parsed
+// and the protected headers have not been modified since, the original
+// encoding is used.
+func (s *Signature) encodedProtectedHeader() (string, error) {
+	protected, err := json.Marshal(s.protected)
+	if err != nil {
+		return "", err
+	}
+
+	if s.rawProtected != nil && bytes.Equal(protected, s.protectedSnapshot) {
+		return string(s.rawProtected), nil
+	}
+	return base64.EncodeToString(protected), nil
+}
+
+// setRawProtected records the protected headers as they appeared in
+// the parsed message, so that the message can be re-serialized without
+// altering the bytes that the signature was computed over
+func (s *Signature) setRawProtected(raw []byte) error {
+	snapshot, err := json.Marshal(s.protected)
+	if err != nil {
+		return fmt.Errorf(`failed to marshal protected headers: %w`, err)
+	}
+	s.rawProtected = append([]byte(nil), raw...)
+	s.protectedSnapshot = snapshot
+	return nil
+}
+
 // get the value of b64 header field.
 // If the field does not exist, returns true (default)
 // Otherwise return the value specified by the header field.
@@ -661,12 +790,17 @@ func parse(protected, payload, signature []byte) (*Message, error) {
 		return nil, fmt.Errorf(`failed to decode signature: %w`, err)
 	}
 
-	var msg Message
-	msg.payload = decodedPayload
-	msg.signatures = append(msg.signatures, &Signature{
+	sig := &Signature{
 		protected: hdr,
 		signature: decodedSignature,
-	})
+	}
+	if err := sig.setRawProtected(protected); err != nil {
+		return nil, err
+	}
+
+	var msg Message
+	msg.payload = decodedPayload
+	msg.signatures = append(msg.signatures, sig)
 	msg.b64 = b64
 	return &msg, nil
 }
@@ -699,6 +833,7 @@ var keyTypeToAlgorithms = make(map[jwa.KeyType][]jwa.SignatureAlgorithm)
 func init() {
 	rawKeyToKeyType[reflect.TypeOf([]byte(nil))] = jwa.OctetSeq
 	rawKeyToKeyType[reflect.TypeOf(ed25519.PublicKey(nil))] = jwa.OKP
+	rawKeyToKeyType[reflect.TypeOf(ed448.PublicKey(nil))] = jwa.OKP
 	rawKeyToKeyType[reflect.TypeOf(rsa.PublicKey{})] = jwa.RSA
 	rawKeyToKeyType[reflect.TypeOf((*rsa.PublicKey)(nil))] = jwa.RSA
 	rawKeyToKeyType[reflect.TypeOf(ecdsa.PublicKey{})] = jwa.EC
@@ -733,12 +868,16 @@ func AlgorithmsForKey(key interface{}) ([]jwa.SignatureAlgorithm, error) {
 		kty = jwa.RSA
 	case ecdsa.PublicKey, *ecdsa.PublicKey, ecdsa.PrivateKey, *ecdsa.PrivateKey:
 		kty = jwa.EC
-	case ed25519.PublicKey, ed25519.PrivateKey, x25519.PublicKey, x25519.PrivateKey:
+	case ed25519.PublicKey, ed25519.PrivateKey, ed448.PublicKey, ed448.PrivateKey, x25519.PublicKey, x25519.PrivateKey, x448.PublicKey, x448.PrivateKey:
 		kty = jwa.OKP
 	case []byte:
 		kty = jwa.OctetSeq
 	default:
-		return nil, fmt.Errorf(`invalid key %T`, key)
+		v, ok := rawKeyToKeyType[reflect.TypeOf(key)]
+		if !ok {
+			return nil, fmt.Errorf(`invalid key %T`, key)
+		}
+		kty = v
 	}
 
 	algs, ok := keyTypeToAlgorithms[kty]
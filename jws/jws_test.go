@@ -7,9 +7,14 @@ import (
 	"crypto"
 	"crypto/ecdsa"
 	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
 	"crypto/rsa"
+	"crypto/sha1"
 	"crypto/sha256"
 	"crypto/sha512"
+	"crypto/x509"
+	"crypto/x509/pkix"
 	"encoding/asn1"
 	"errors"
 	"fmt"
@@ -24,12 +29,16 @@ import (
 	"time"
 
 	"github.com/lestrrat-go/httprc"
+	"github.com/lestrrat-go/jwx/v2"
+	"github.com/lestrrat-go/jwx/v2/cert"
 	"github.com/lestrrat-go/jwx/v2/internal/base64"
+	"github.com/lestrrat-go/jwx/v2/internal/brainpool"
 	"github.com/lestrrat-go/jwx/v2/internal/json"
 	"github.com/lestrrat-go/jwx/v2/internal/jwxtest"
 	"github.com/lestrrat-go/jwx/v2/jwa"
 	"github.com/lestrrat-go/jwx/v2/jwk"
 	"github.com/lestrrat-go/jwx/v2/jws"
+	"github.com/lestrrat-go/jwx/v2/jws/jwstest"
 	"github.com/lestrrat-go/jwx/v2/jwt"
 	"github.com/lestrrat-go/jwx/v2/x25519"
 	"github.com/stretchr/testify/assert"
@@ -41,6 +50,7 @@ const exampleCompactSerialization = `eyJ0eXAiOiJKV1QiLA0KICJhbGciOiJIUzI1NiJ9.ey
 const badValue = "%badvalue%"
 
 var hasES256K bool
+var hasBrainpool bool
 
 func TestSanity(t *testing.T) {
 	t.Run("sanity: Verify with single key", func(t *testing.T) {
@@ -372,6 +382,35 @@ func TestRoundtrip(t *testing.T) {
 			})
 		}
 	})
+	t.Run("EdDSA (Ed448)", func(t *testing.T) {
+		t.Parallel()
+		key, err := jwxtest.GenerateEd448Key()
+		require.NoError(t, err, "ed448 key generated")
+		pubkey := key.Public()
+		jwkKey, err := jwk.FromRaw(pubkey)
+		require.NoError(t, err, `jwk.FromRaw should succeed`)
+		keys := map[string]interface{}{
+			"Verify(ed448.Public())": pubkey,
+			"Verify(jwk.Key)":        jwkKey,
+		}
+		testRoundtrip(t, payload, jwa.EdDSA, key, keys)
+
+		t.Run("Sign(jwk.Key)", func(t *testing.T) {
+			privJwkKey, err := jwk.FromRaw(key)
+			require.NoError(t, err, `jwk.FromRaw should succeed`)
+			signed, err := jws.Sign(payload, jws.WithKey(jwa.EdDSA, privJwkKey))
+			require.NoError(t, err, `jws.Sign should succeed`)
+
+			verified, err := jws.Verify(signed, jws.WithKey(jwa.EdDSA, jwkKey))
+			require.NoError(t, err, `jws.Verify should succeed`)
+			require.Equal(t, payload, verified, `payloads should match`)
+
+			ed25519key, err := jwxtest.GenerateEd25519Key()
+			require.NoError(t, err, "ed25519 key generated")
+			_, err = jws.Verify(signed, jws.WithKey(jwa.EdDSA, ed25519key.Public()))
+			require.Error(t, err, `jws.Verify with an Ed25519 key should fail`)
+		})
+	})
 }
 
 func TestSignMulti2(t *testing.T) {
@@ -412,6 +451,106 @@ func TestSignMulti2(t *testing.T) {
 	}
 }
 
+func TestBrainpoolECDSA(t *testing.T) {
+	t.Parallel()
+	// ES256/ES384/ES512 are only defined for the NIST curves, so keys
+	// using the Brainpool curves must be rejected
+	curves := map[jwa.SignatureAlgorithm]elliptic.Curve{
+		jwa.ES256: brainpool.P256r1(),
+		jwa.ES384: brainpool.P384r1(),
+		jwa.ES512: brainpool.P512r1(),
+	}
+	payload := []byte("Lorem ipsum")
+	for alg, crv := range curves {
+		alg := alg
+		crv := crv
+		t.Run(alg.String(), func(t *testing.T) {
+			t.Parallel()
+			key, err := ecdsa.GenerateKey(crv, rand.Reader)
+			require.NoError(t, err, `ecdsa.GenerateKey should succeed`)
+
+			_, err = jws.Sign(payload, jws.WithKey(alg, key))
+			require.Error(t, err, `jws.Sign should fail`)
+
+			signer, err := jws.NewDeterministicECDSASigner(alg)
+			require.NoError(t, err, `jws.NewDeterministicECDSASigner should succeed`)
+			_, err = signer.Sign(payload, key)
+			require.Error(t, err, `deterministic signer should fail`)
+
+			// Create the signature by hand, as jws.Sign refuses to
+			hash := map[jwa.SignatureAlgorithm]crypto.Hash{
+				jwa.ES256: crypto.SHA256,
+				jwa.ES384: crypto.SHA384,
+				jwa.ES512: crypto.SHA512,
+			}[alg].New()
+			hash.Write(payload)
+			r, s, err := ecdsa.Sign(rand.Reader, key, hash.Sum(nil))
+			require.NoError(t, err, `ecdsa.Sign should succeed`)
+			size := (crv.Params().BitSize + 7) / 8
+			signature := make([]byte, 2*size)
+			r.FillBytes(signature[:size])
+			s.FillBytes(signature[size:])
+
+			verifier, err := jws.NewVerifier(alg)
+			require.NoError(t, err, `jws.NewVerifier should succeed`)
+			require.Error(t, verifier.Verify(payload, signature, &key.PublicKey), `Verify should fail`)
+		})
+	}
+}
+
+func TestBrainpoolESB(t *testing.T) {
+	t.Parallel()
+	curves := map[jwa.SignatureAlgorithm]elliptic.Curve{
+		jwa.ESB256: brainpool.P256r1(),
+		jwa.ESB384: brainpool.P384r1(),
+		jwa.ESB512: brainpool.P512r1(),
+	}
+	payload := []byte("Lorem ipsum")
+	for alg, crv := range curves {
+		alg := alg
+		crv := crv
+		t.Run(alg.String(), func(t *testing.T) {
+			t.Parallel()
+			key, err := ecdsa.GenerateKey(crv, rand.Reader)
+			require.NoError(t, err, `ecdsa.GenerateKey should succeed`)
+
+			signed, err := jws.Sign(payload, jws.WithKey(alg, key))
+			require.NoError(t, err, `jws.Sign should succeed`)
+			verified, err := jws.Verify(signed, jws.WithKey(alg, &key.PublicKey))
+			require.NoError(t, err, `jws.Verify should succeed`)
+			require.Equal(t, payload, verified, `payloads should match`)
+
+			signed, err = jws.Sign(payload, jws.WithKey(alg, key), jws.WithDeterministicSignatures())
+			require.NoError(t, err, `jws.Sign with deterministic signatures should succeed`)
+			again, err := jws.Sign(payload, jws.WithKey(alg, key), jws.WithDeterministicSignatures())
+			require.NoError(t, err, `jws.Sign with deterministic signatures should succeed`)
+			require.Equal(t, signed, again, `deterministic signatures should match`)
+			_, err = jws.Verify(signed, jws.WithKey(alg, &key.PublicKey))
+			require.NoError(t, err, `jws.Verify should succeed`)
+		})
+	}
+
+	t.Run("Curve mismatch", func(t *testing.T) {
+		t.Parallel()
+		for _, crv := range []elliptic.Curve{brainpool.P384r1(), elliptic.P256()} {
+			key, err := ecdsa.GenerateKey(crv, rand.Reader)
+			require.NoError(t, err, `ecdsa.GenerateKey should succeed`)
+			_, err = jws.Sign(payload, jws.WithKey(jwa.ESB256, key))
+			require.Error(t, err, `jws.Sign using ESB256 with %s should fail`, crv.Params().Name)
+		}
+
+		key, err := ecdsa.GenerateKey(brainpool.P384r1(), rand.Reader)
+		require.NoError(t, err, `ecdsa.GenerateKey should succeed`)
+		signed, err := jws.Sign(payload, jws.WithKey(jwa.ESB384, key))
+		require.NoError(t, err, `jws.Sign should succeed`)
+
+		other, err := ecdsa.GenerateKey(elliptic.P384(), rand.Reader)
+		require.NoError(t, err, `ecdsa.GenerateKey should succeed`)
+		_, err = jws.Verify(signed, jws.WithKey(jwa.ESB384, &other.PublicKey))
+		require.Error(t, err, `jws.Verify using ESB384 with P-384 should fail`)
+	})
+}
+
 func TestEncode(t *testing.T) {
 	t.Parallel()
 
@@ -1518,7 +1657,13 @@ func TestGH485(t *testing.T) {
     "signatures": [{"protected": %q, "signature": %q}]
 }`, payload, protected, signature)
 
-	verified, err := jws.Verify([]byte(signed), jws.WithKey(jwa.HS256, []byte("secret")))
+	// "exp" is listed in "crit", so it must be declared as understood
+	_, err := jws.Verify([]byte(signed), jws.WithKey(jwa.HS256, []byte("secret")))
+	if !assert.Error(t, err, `jws.Verify should fail without jws.WithCriticalHeaders`) {
+		return
+	}
+
+	verified, err := jws.Verify([]byte(signed), jws.WithKey(jwa.HS256, []byte("secret")), jws.WithCriticalHeaders("exp"))
 	if !assert.NoError(t, err, `jws.Verify should succeed`) {
 		return
 	}
@@ -1527,7 +1672,7 @@ func TestGH485(t *testing.T) {
 	}
 
 	compact := strings.Join([]string{protected, payload, signature}, ".")
-	verified, err = jws.Verify([]byte(compact), jws.WithKey(jwa.HS256, []byte("secret")))
+	verified, err = jws.Verify([]byte(compact), jws.WithKey(jwa.HS256, []byte("secret")), jws.WithCriticalHeaders("exp"))
 	if !assert.NoError(t, err, `jws.Verify should succeed`) {
 		return
 	}
@@ -1884,6 +2029,11 @@ func TestAlgorithmsForKey(t *testing.T) {
 				tc.Expected = append(tc.Expected, jwa.ES256K)
 			}
 		}
+		if hasBrainpool {
+			if strings.Contains(strings.ToLower(tc.Name), `ecdsa`) {
+				tc.Expected = append(tc.Expected, jwa.ESB256, jwa.ESB384, jwa.ESB512)
+			}
+		}
 
 		sort.Slice(tc.Expected, func(i, j int) bool {
 			return tc.Expected[i].String() < tc.Expected[j].String()
@@ -1923,6 +2073,361 @@ func TestGH681(t *testing.T) {
 	}
 }
 
+func TestDeterministicSignatures(t *testing.T) {
+	t.Parallel()
+	t.Run("RFC 6979 A.2.5", func(t *testing.T) {
+		t.Parallel()
+		// P-256 key and signature of "sample" using SHA-256
+		d, ok := new(big.Int).SetString(`C9AFA9D845BA75166B5C215767B1D6934E50C3DB36E89B127B8A622B120F6721`, 16)
+		require.True(t, ok, `private key should be parsed`)
+		crv, ok := jwk.CurveForAlgorithm(jwa.P256)
+		require.True(t, ok, `jwk.CurveForAlgorithm should succeed`)
+		key := &ecdsa.PrivateKey{D: d}
+		key.Curve = crv
+		key.X, key.Y = crv.ScalarBaseMult(d.Bytes())
+
+		signer, err := jws.NewDeterministicECDSASigner(jwa.ES256)
+		require.NoError(t, err, `jws.NewDeterministicECDSASigner should succeed`)
+
+		signature, err := signer.Sign([]byte(`sample`), key)
+		require.NoError(t, err, `signer.Sign should succeed`)
+
+		expected := `EFD48B2AACB6A8FD1140DD9CD45E81D69D2C877B56AAF991C34D0EA84EAF3716` +
+			`F7CB1C942D657C41D436C7A1B6E29F65F3E900DBB9AFF4064DC4AB2F843ACDA8`
+		require.Equal(t, expected, fmt.Sprintf(`%X`, signature), `signature should match test vector`)
+	})
+	t.Run("jws.WithDeterministicSignatures", func(t *testing.T) {
+		t.Parallel()
+		payload := []byte(`Lorem ipsum`)
+		for _, tc := range []struct {
+			Alg   jwa.SignatureAlgorithm
+			Curve jwa.EllipticCurveAlgorithm
+		}{
+			{Alg: jwa.ES256, Curve: jwa.P256},
+			{Alg: jwa.ES384, Curve: jwa.P384},
+			{Alg: jwa.ES512, Curve: jwa.P521},
+		} {
+			tc := tc
+			t.Run(tc.Alg.String(), func(t *testing.T) {
+				t.Parallel()
+				raw, err := jwxtest.GenerateEcdsaKey(tc.Curve)
+				require.NoError(t, err, `jwxtest.GenerateEcdsaKey should succeed`)
+				key, err := jwk.FromRaw(raw)
+				require.NoError(t, err, `jwk.FromRaw should succeed`)
+
+				signed1, err := jws.Sign(payload, jws.WithKey(tc.Alg, key), jws.WithDeterministicSignatures())
+				require.NoError(t, err, `jws.Sign should succeed`)
+				signed2, err := jws.Sign(payload, jws.WithDeterministicSignatures(), jws.WithKey(tc.Alg, key))
+				require.NoError(t, err, `jws.Sign should succeed`)
+				require.Equal(t, signed1, signed2, `signatures should be reproducible`)
+
+				signed3, err := jws.Sign(payload, jws.WithKey(tc.Alg, key))
+				require.NoError(t, err, `jws.Sign should succeed`)
+				require.NotEqual(t, signed1, signed3, `signatures should differ without jws.WithDeterministicSignatures`)
+
+				pubkey, err := key.PublicKey()
+				require.NoError(t, err, `key.PublicKey should succeed`)
+				verified, err := jws.Verify(signed1, jws.WithKey(tc.Alg, pubkey))
+				require.NoError(t, err, `jws.Verify should succeed`)
+				require.Equal(t, payload, verified, `payload should match`)
+			})
+		}
+	})
+	t.Run("Unsupported algorithm", func(t *testing.T) {
+		t.Parallel()
+		_, err := jws.NewDeterministicECDSASigner(jwa.RS256)
+		require.Error(t, err, `jws.NewDeterministicECDSASigner should fail`)
+	})
+}
+
+func TestSignReader(t *testing.T) {
+	t.Parallel()
+	payload := []byte(strings.Repeat(`Lorem ipsum dolor sit amet. `, 4096))
+
+	rsakey, err := jwxtest.GenerateRsaKey()
+	require.NoError(t, err, `jwxtest.GenerateRsaKey should succeed`)
+	eckey, err := jwxtest.GenerateEcdsaKey(jwa.P256)
+	require.NoError(t, err, `jwxtest.GenerateEcdsaKey should succeed`)
+	hmackey := []byte(`abracadabra-abracadabra-abracadabra`)
+
+	testcases := []struct {
+		Alg     jwa.SignatureAlgorithm
+		Key     interface{}
+		Public  interface{}
+		B64     bool
+		Options []jws.SignOption
+	}{
+		{Alg: jwa.HS256, Key: hmackey, Public: hmackey},
+		{Alg: jwa.RS256, Key: rsakey, Public: &rsakey.PublicKey},
+		{Alg: jwa.PS384, Key: rsakey, Public: &rsakey.PublicKey, B64: true},
+		{Alg: jwa.ES256, Key: eckey, Public: &eckey.PublicKey},
+		{Alg: jwa.ES256, Key: eckey, Public: &eckey.PublicKey, Options: []jws.SignOption{jws.WithJSON()}},
+	}
+	for _, tc := range testcases {
+		tc := tc
+		t.Run(fmt.Sprintf("%s (b64=%t, options=%d)", tc.Alg, tc.B64, len(tc.Options)), func(t *testing.T) {
+			t.Parallel()
+			hdrs := jws.NewHeaders()
+			if !tc.B64 {
+				require.NoError(t, hdrs.Set(`b64`, false), `hdrs.Set should succeed`)
+				require.NoError(t, hdrs.Set(jws.CriticalKey, []string{`b64`}), `hdrs.Set should succeed`)
+			}
+
+			options := append([]jws.SignOption{jws.WithKey(tc.Alg, tc.Key, jws.WithProtectedHeaders(hdrs))}, tc.Options...)
+			signed, err := jws.SignReader(bytes.NewReader(payload), options...)
+			require.NoError(t, err, `jws.SignReader should succeed`)
+
+			msg, err := jws.Parse(signed)
+			require.NoError(t, err, `jws.Parse should succeed`)
+			require.Empty(t, msg.Payload(), `payload should be detached`)
+
+			require.NoError(t, jws.VerifyReader(signed, bytes.NewReader(payload), jws.WithKey(tc.Alg, tc.Public)), `jws.VerifyReader should succeed`)
+			require.Error(t, jws.VerifyReader(signed, bytes.NewReader(payload[1:]), jws.WithKey(tc.Alg, tc.Public)), `jws.VerifyReader should fail with a modified payload`)
+
+			// Interoperability with the non-streaming API
+			_, err = jws.Verify(signed, jws.WithKey(tc.Alg, tc.Public), jws.WithDetachedPayload(payload))
+			require.NoError(t, err, `jws.Verify should succeed`)
+
+			if len(tc.Options) == 0 {
+				detached, err := jws.Sign(nil, append(options, jws.WithDetachedPayload(payload))...)
+				require.NoError(t, err, `jws.Sign should succeed`)
+				require.NoError(t, jws.VerifyReader(detached, bytes.NewReader(payload), jws.WithKey(tc.Alg, tc.Public)), `jws.VerifyReader should succeed`)
+			}
+		})
+	}
+	t.Run("Unsupported algorithm", func(t *testing.T) {
+		t.Parallel()
+		key, err := jwxtest.GenerateEd25519Key()
+		require.NoError(t, err, `jwxtest.GenerateEd25519Key should succeed`)
+		_, err = jws.SignReader(bytes.NewReader(payload), jws.WithKey(jwa.EdDSA, key))
+		require.Error(t, err, `jws.SignReader should fail`)
+	})
+}
+
+func TestVerifyAll(t *testing.T) {
+	t.Parallel()
+	payload := []byte(`Lorem ipsum`)
+
+	generate := func(alg jwa.SignatureAlgorithm, kid string) (jwk.Key, jwk.Key) {
+		var raw interface{}
+		var err error
+		switch alg {
+		case jwa.RS256:
+			raw, err = jwxtest.GenerateRsaKey()
+		case jwa.ES256:
+			raw, err = jwxtest.GenerateEcdsaKey(jwa.P256)
+		}
+		require.NoError(t, err, `generating key should succeed`)
+		key, err := jwk.FromRaw(raw)
+		require.NoError(t, err, `jwk.FromRaw should succeed`)
+		require.NoError(t, key.Set(jwk.KeyIDKey, kid), `key.Set should succeed`)
+		require.NoError(t, key.Set(jwk.AlgorithmKey, alg), `key.Set should succeed`)
+		pubkey, err := key.PublicKey()
+		require.NoError(t, err, `key.PublicKey should succeed`)
+		return key, pubkey
+	}
+
+	aliceKey, alicePub := generate(jwa.RS256, `alice`)
+	bobKey, bobPub := generate(jwa.ES256, `bob`)
+	carolKey, carolPub := generate(jwa.ES256, `carol`)
+
+	signed, err := jws.Sign(payload, jws.WithJSON(),
+		jws.WithKey(jwa.RS256, aliceKey),
+		jws.WithKey(jwa.ES256, bobKey),
+		jws.WithKey(jwa.ES256, carolKey),
+	)
+	require.NoError(t, err, `jws.Sign should succeed`)
+
+	setOf := func(keys ...jwk.Key) jwk.Set {
+		set := jwk.NewSet()
+		for _, key := range keys {
+			require.NoError(t, set.AddKey(key), `set.AddKey should succeed`)
+		}
+		return set
+	}
+
+	t.Run("All signatures", func(t *testing.T) {
+		t.Parallel()
+		verified, results, err := jws.VerifyAll(signed, jws.WithKeySet(setOf(alicePub, bobPub, carolPub)))
+		require.NoError(t, err, `jws.VerifyAll should succeed`)
+		require.Equal(t, payload, verified, `payload should match`)
+		require.Len(t, results, 3, `there should be 3 results`)
+		for i, expected := range []jwk.Key{alicePub, bobPub, carolPub} {
+			require.True(t, results[i].Verified(), `signature #%d should be verified`, i)
+			require.Equal(t, expected, results[i].Key(), `key used for signature #%d should match`, i)
+			require.Equal(t, expected.Algorithm(), results[i].Algorithm(), `algorithm for signature #%d should match`, i)
+		}
+	})
+	t.Run("Missing key", func(t *testing.T) {
+		t.Parallel()
+		set := setOf(alicePub, bobPub)
+		_, results, err := jws.VerifyAll(signed, jws.WithKeySet(set))
+		require.Error(t, err, `jws.VerifyAll should fail`)
+		require.Len(t, results, 3, `results should be returned on failure`)
+		require.True(t, results[0].Verified(), `signature #0 should be verified`)
+		require.True(t, results[1].Verified(), `signature #1 should be verified`)
+		require.False(t, results[2].Verified(), `signature #2 should not be verified`)
+		require.Error(t, results[2].Err(), `signature #2 should have an error`)
+		require.Nil(t, results[2].Key(), `signature #2 should not have a key`)
+
+		verified, _, err := jws.VerifyAll(signed, jws.WithKeySet(set), jws.WithRequiredSignatures(2))
+		require.NoError(t, err, `jws.VerifyAll should succeed with 2 of 3 signatures`)
+		require.Equal(t, payload, verified, `payload should match`)
+
+		_, _, err = jws.VerifyAll(signed, jws.WithKeySet(setOf(alicePub)), jws.WithRequiredSignatures(2))
+		require.Error(t, err, `jws.VerifyAll should fail with 1 of 3 signatures`)
+
+		// the same signer is only counted once
+		duplicated, err := jws.Sign(payload, jws.WithJSON(),
+			jws.WithKey(jwa.RS256, aliceKey),
+			jws.WithKey(jwa.RS256, aliceKey),
+		)
+		require.NoError(t, err, `jws.Sign should succeed`)
+		_, results, err = jws.VerifyAll(duplicated, jws.WithKeySet(setOf(alicePub, bobPub)), jws.WithRequiredSignatures(2))
+		require.Error(t, err, `jws.VerifyAll should fail when the same signature is duplicated`)
+		require.Len(t, results, 2, `there should be 2 results`)
+		require.True(t, results[0].Verified() && results[1].Verified(), `both signatures should be verified`)
+
+		// negative values behave the same as 0: no minimum is enforced
+		verified, _, err = jws.VerifyAll(signed, jws.WithKeySet(setOf(alicePub)), jws.WithRequiredSignatures(-1))
+		require.NoError(t, err, `jws.VerifyAll should succeed with 1 of 3 signatures`)
+		require.Equal(t, payload, verified, `payload should match`)
+	})
+	t.Run("Required key sets", func(t *testing.T) {
+		t.Parallel()
+		verified, results, err := jws.VerifyAll(signed,
+			jws.WithRequiredKeySet(setOf(alicePub)),
+			jws.WithRequiredKeySet(setOf(carolPub)),
+			jws.WithRequiredSignatures(0),
+		)
+		require.NoError(t, err, `jws.VerifyAll should succeed`)
+		require.Equal(t, payload, verified, `payload should match`)
+		require.False(t, results[1].Verified(), `signature #1 should not be verified`)
+
+		_, otherPub := generate(jwa.ES256, `dave`)
+		_, _, err = jws.VerifyAll(signed,
+			jws.WithRequiredKeySet(setOf(otherPub)),
+			jws.WithKeySet(setOf(alicePub, bobPub, carolPub)),
+		)
+		require.Error(t, err, `jws.VerifyAll should fail when a required key set is not satisfied`)
+	})
+}
+
+func TestRawProtectedHeaders(t *testing.T) {
+	t.Parallel()
+
+	// The protected header in exampleCompactSerialization contains line
+	// breaks, which are lost once it is parsed into a jws.Headers object
+	key, err := jwk.ParseKey([]byte(`{"kty":"oct","k":"AyM1SysPpbyDfgZld3umj1qzKObwVMkoqQ-EstJQLr_T-1qS0gZH75aKtMN3Yj0iPS4hcgUuTwjAzZr1Z9CAow"}`))
+	require.NoError(t, err, `jwk.ParseKey should succeed`)
+
+	t.Run("Compact", func(t *testing.T) {
+		t.Parallel()
+		msg, err := jws.Parse([]byte(exampleCompactSerialization))
+		require.NoError(t, err, `jws.Parse should succeed`)
+
+		serialized, err := jws.Compact(msg)
+		require.NoError(t, err, `jws.Compact should succeed`)
+		require.Equal(t, exampleCompactSerialization, string(serialized), `serialized message should be byte-exact`)
+	})
+	t.Run("JSON", func(t *testing.T) {
+		t.Parallel()
+		msg, err := jws.Parse([]byte(exampleCompactSerialization))
+		require.NoError(t, err, `jws.Parse should succeed`)
+
+		serialized, err := json.Marshal(msg)
+		require.NoError(t, err, `json.Marshal should succeed`)
+		_, err = jws.Verify(serialized, jws.WithKey(jwa.HS256, key))
+		require.NoError(t, err, `jws.Verify should succeed`)
+
+		var parsed jws.Message
+		require.NoError(t, json.Unmarshal(serialized, &parsed), `json.Unmarshal should succeed`)
+		serialized, err = jws.Compact(&parsed)
+		require.NoError(t, err, `jws.Compact should succeed`)
+		require.Equal(t, exampleCompactSerialization, string(serialized), `serialized message should be byte-exact`)
+	})
+	t.Run("WithMessage", func(t *testing.T) {
+		t.Parallel()
+		var msg jws.Message
+		_, err := jws.Verify([]byte(exampleCompactSerialization), jws.WithKey(jwa.HS256, key), jws.WithMessage(&msg))
+		require.NoError(t, err, `jws.Verify should succeed`)
+
+		serialized, err := jws.Compact(&msg)
+		require.NoError(t, err, `jws.Compact should succeed`)
+		require.Equal(t, exampleCompactSerialization, string(serialized), `serialized message should be byte-exact`)
+	})
+	t.Run("Modified headers", func(t *testing.T) {
+		t.Parallel()
+		msg, err := jws.Parse([]byte(exampleCompactSerialization))
+		require.NoError(t, err, `jws.Parse should succeed`)
+		require.NoError(t, msg.Signatures()[0].ProtectedHeaders().Set(jws.ContentTypeKey, `example`), `Set should succeed`)
+
+		serialized, err := jws.Compact(msg)
+		require.NoError(t, err, `jws.Compact should succeed`)
+
+		parsed, err := jws.Parse(serialized)
+		require.NoError(t, err, `jws.Parse should succeed`)
+		require.Equal(t, `example`, parsed.Signatures()[0].ProtectedHeaders().ContentType(), `modified header should be serialized`)
+	})
+}
+
+func TestCriticalHeaders(t *testing.T) {
+	t.Parallel()
+	key := []byte(`abracadabra`)
+	sign := func(t *testing.T, crit []string, fields map[string]interface{}) []byte {
+		hdrs := jws.NewHeaders()
+		require.NoError(t, hdrs.Set(jws.CriticalKey, crit), `hdrs.Set should succeed`)
+		for k, v := range fields {
+			require.NoError(t, hdrs.Set(k, v), `hdrs.Set should succeed`)
+		}
+		signed, err := jws.Sign([]byte(`Lorem ipsum`), jws.WithKey(jwa.HS256, key, jws.WithProtectedHeaders(hdrs)))
+		require.NoError(t, err, `jws.Sign should succeed`)
+		return signed
+	}
+
+	t.Run("Unknown header", func(t *testing.T) {
+		t.Parallel()
+		signed := sign(t, []string{`x-unknown`}, map[string]interface{}{`x-unknown`: true})
+		_, err := jws.Verify(signed, jws.WithKey(jwa.HS256, key))
+		require.Error(t, err, `jws.Verify should fail`)
+
+		_, results, err := jws.VerifyAll(signed, jws.WithKey(jwa.HS256, key))
+		require.Error(t, err, `jws.VerifyAll should fail`)
+		require.Error(t, results[0].Err(), `signature should not be verified`)
+
+		_, err = jws.Verify(signed, jws.WithKey(jwa.HS256, key), jws.WithCriticalHeaders(`x-unknown`))
+		require.NoError(t, err, `jws.Verify should succeed with jws.WithCriticalHeaders`)
+	})
+	t.Run("Registered header", func(t *testing.T) {
+		t.Parallel()
+		jws.RegisterCriticalHeader(`x-registered-jws-test`)
+		signed := sign(t, []string{`x-registered-jws-test`}, map[string]interface{}{`x-registered-jws-test`: true})
+		_, err := jws.Verify(signed, jws.WithKey(jwa.HS256, key))
+		require.NoError(t, err, `jws.Verify should succeed`)
+	})
+	t.Run("Missing header", func(t *testing.T) {
+		t.Parallel()
+		signed := sign(t, []string{`x-missing`}, nil)
+		_, err := jws.Verify(signed, jws.WithKey(jwa.HS256, key), jws.WithCriticalHeaders(`x-missing`))
+		require.Error(t, err, `jws.Verify should fail when a critical header is not present`)
+	})
+	t.Run("Empty", func(t *testing.T) {
+		t.Parallel()
+		signed := sign(t, []string{}, nil)
+		_, err := jws.Verify(signed, jws.WithKey(jwa.HS256, key))
+		require.Error(t, err, `jws.Verify should fail when "crit" is empty`)
+	})
+	t.Run("Unprotected", func(t *testing.T) {
+		t.Parallel()
+		public := jws.NewHeaders()
+		require.NoError(t, public.Set(jws.CriticalKey, []string{`b64`}), `public.Set should succeed`)
+		signed, err := jws.Sign([]byte(`Lorem ipsum`), jws.WithJSON(), jws.WithKey(jwa.HS256, key, jws.WithPublicHeaders(public)))
+		require.NoError(t, err, `jws.Sign should succeed`)
+		_, err = jws.Verify(signed, jws.WithKey(jwa.HS256, key))
+		require.Error(t, err, `jws.Verify should fail when "crit" is not protected`)
+	})
+}
+
 func TestGH840(t *testing.T) {
 	// Go 1.19+ panics if elliptic curve operations are called against
 	// a point that's _NOT_ on the curve
@@ -2044,3 +2549,323 @@ func TestGH910(t *testing.T) {
 
 	require.Equal(t, src, string(verified), `verified payload should match`)
 }
+
+func TestContextSigner(t *testing.T) {
+	payload := []byte(`Lorem ipsum`)
+
+	ecKey, err := jwxtest.GenerateEcdsaKey(jwa.P256)
+	require.NoError(t, err, `jwxtest.GenerateEcdsaKey should succeed`)
+	hmacKey := []byte(`01234567890123456789012345678901`)
+
+	t.Run("Sign", func(t *testing.T) {
+		testcases := []struct {
+			alg      jwa.SignatureAlgorithm
+			key      interface{}
+			verifier interface{}
+		}{
+			{alg: jwa.ES256, key: ecKey, verifier: &ecKey.PublicKey},
+			{alg: jwa.HS256, key: hmacKey, verifier: hmacKey},
+		}
+		for _, tc := range testcases {
+			tc := tc
+			t.Run(tc.alg.String(), func(t *testing.T) {
+				signer := jwstest.NewSigner(tc.key)
+				signed, err := jws.Sign(payload, jws.WithKey(tc.alg, signer), jws.WithContext(context.Background()))
+				require.NoError(t, err, `jws.Sign should succeed`)
+				require.Equal(t, []jwa.SignatureAlgorithm{tc.alg}, signer.Calls(), `signer should have been called once`)
+
+				verified, err := jws.Verify(signed, jws.WithKey(tc.alg, tc.verifier))
+				require.NoError(t, err, `jws.Verify should succeed`)
+				require.Equal(t, payload, verified, `payload should match`)
+			})
+		}
+	})
+	t.Run("Cancelled context", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		_, err := jws.Sign(payload, jws.WithKey(jwa.ES256, jwstest.NewSigner(ecKey)), jws.WithContext(ctx))
+		require.True(t, errors.Is(err, context.Canceled), `jws.Sign should fail with context.Canceled`)
+	})
+	t.Run("Signer error", func(t *testing.T) {
+		signer := jwstest.NewSigner(ecKey)
+		failure := errors.New(`KMS is unavailable`)
+		signer.SetError(failure)
+
+		_, err := jws.Sign(payload, jws.WithKey(jwa.ES256, signer))
+		require.True(t, errors.Is(err, failure), `jws.Sign should fail with the error from the signer`)
+	})
+	t.Run("SignReader", func(t *testing.T) {
+		_, err := jws.SignReader(bytes.NewReader(payload), jws.WithKey(jwa.ES256, jwstest.NewSigner(ecKey)))
+		require.Error(t, err, `jws.SignReader should fail`)
+	})
+	t.Run("Policy", func(t *testing.T) {
+		// Without Public(), the curve of the key can not be checked
+		_, err := jws.Sign(payload, jws.WithKey(jwa.ES256, jwstest.NewSigner(ecKey)), jws.WithPolicy(&jwx.Policy{Curves: []jwa.EllipticCurveAlgorithm{jwa.P256}}))
+		require.True(t, errors.Is(err, jwx.ErrPolicyViolation()), `jws.Sign should fail with ErrPolicyViolation`)
+
+		_, err = jws.Sign(payload, jws.WithKey(jwa.ES256, jwstest.NewSigner(ecKey)), jws.WithPolicy(&jwx.Policy{SignatureAlgorithms: []jwa.SignatureAlgorithm{jwa.ES256}}))
+		require.NoError(t, err, `jws.Sign should succeed when the policy does not check the key strength`)
+
+		signer := &publicContextSigner{Signer: jwstest.NewSigner(ecKey), public: &ecKey.PublicKey}
+		_, err = jws.Sign(payload, jws.WithKey(jwa.ES256, signer), jws.WithPolicy(&jwx.Policy{Curves: []jwa.EllipticCurveAlgorithm{jwa.P384}}))
+		require.True(t, errors.Is(err, jwx.ErrCurveNotAllowed()), `jws.Sign should fail with ErrCurveNotAllowed`)
+
+		_, err = jws.Sign(payload, jws.WithKey(jwa.ES256, signer), jws.WithPolicy(&jwx.Policy{Curves: []jwa.EllipticCurveAlgorithm{jwa.P256}}))
+		require.NoError(t, err, `jws.Sign should succeed`)
+	})
+	t.Run("Deterministic signatures", func(t *testing.T) {
+		_, err := jws.Sign(payload, jws.WithKey(jwa.ES256, jwstest.NewSigner(ecKey)), jws.WithDeterministicSignatures())
+		require.Error(t, err, `jws.Sign should fail`)
+
+		_, err = jws.Sign(payload, jws.WithKey(jwa.HS256, jwstest.NewSigner(hmacKey)), jws.WithDeterministicSignatures())
+		require.NoError(t, err, `jws.Sign should succeed for algorithms not affected by the option`)
+	})
+}
+
+// publicContextSigner is a jws.ContextSigner that also reports its
+// public key, so that it can be checked against a jwx.Policy
+type publicContextSigner struct {
+	*jwstest.Signer
+	public crypto.PublicKey
+}
+
+func (s *publicContextSigner) Public() crypto.PublicKey {
+	return s.public
+}
+
+// issueCertificate creates a certificate for `pub` that is valid for an
+// hour before and after `now`. If `parent` is nil, the certificate is
+// self-signed
+func issueCertificate(t *testing.T, now time.Time, serial int64, pub, parentKey interface{}, parent *x509.Certificate, ca bool, usage x509.KeyUsage) *x509.Certificate {
+	t.Helper()
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(serial),
+		Subject:               pkix.Name{CommonName: fmt.Sprintf(`cert-%d`, serial)},
+		NotBefore:             now.Add(-time.Hour),
+		NotAfter:              now.Add(time.Hour),
+		KeyUsage:              usage,
+		BasicConstraintsValid: true,
+		IsCA:                  ca,
+	}
+	if parent == nil {
+		parent = template
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, parent, pub, parentKey)
+	require.NoError(t, err, `x509.CreateCertificate should succeed`)
+	c, err := x509.ParseCertificate(der)
+	require.NoError(t, err, `x509.ParseCertificate should succeed`)
+	return c
+}
+
+func TestX5CKeyProvider(t *testing.T) {
+	now := time.Now()
+	issue := func(t *testing.T, serial int64, pub, parentKey interface{}, parent *x509.Certificate, ca bool, usage x509.KeyUsage) *x509.Certificate {
+		t.Helper()
+		return issueCertificate(t, now, serial, pub, parentKey, parent, ca, usage)
+	}
+
+	rootKey, err := jwxtest.GenerateEcdsaKey(jwa.P256)
+	require.NoError(t, err, `jwxtest.GenerateEcdsaKey should succeed`)
+	intermediateKey, err := jwxtest.GenerateEcdsaKey(jwa.P256)
+	require.NoError(t, err, `jwxtest.GenerateEcdsaKey should succeed`)
+	leafKey, err := jwxtest.GenerateEcdsaKey(jwa.P256)
+	require.NoError(t, err, `jwxtest.GenerateEcdsaKey should succeed`)
+
+	root := issue(t, 1, &rootKey.PublicKey, rootKey, nil, true, x509.KeyUsageCertSign)
+	intermediate := issue(t, 2, &intermediateKey.PublicKey, rootKey, root, true, x509.KeyUsageCertSign)
+	leaf := issue(t, 3, &leafKey.PublicKey, intermediateKey, intermediate, false, x509.KeyUsageDigitalSignature)
+	encipherOnly := issue(t, 4, &leafKey.PublicKey, intermediateKey, intermediate, false, x509.KeyUsageKeyEncipherment)
+
+	roots := x509.NewCertPool()
+	roots.AddCert(root)
+
+	otherKey, err := jwxtest.GenerateEcdsaKey(jwa.P256)
+	require.NoError(t, err, `jwxtest.GenerateEcdsaKey should succeed`)
+	otherLeaf := issue(t, 6, &otherKey.PublicKey, intermediateKey, intermediate, false, x509.KeyUsageDigitalSignature)
+	otherRoots := x509.NewCertPool()
+	otherRoots.AddCert(issue(t, 5, &otherKey.PublicKey, otherKey, nil, true, x509.KeyUsageCertSign))
+
+	payload := []byte(`Lorem ipsum`)
+	sign := func(t *testing.T, certs []*x509.Certificate, thumbprint string) []byte {
+		t.Helper()
+		var chain cert.Chain
+		for _, c := range certs {
+			encoded, err := cert.EncodeBase64(c.Raw)
+			require.NoError(t, err, `cert.EncodeBase64 should succeed`)
+			require.NoError(t, chain.Add(encoded), `chain.Add should succeed`)
+		}
+
+		hdrs := jws.NewHeaders()
+		if len(certs) > 0 {
+			require.NoError(t, hdrs.Set(jws.X509CertChainKey, &chain), `hdrs.Set should succeed`)
+		}
+		if thumbprint != "" {
+			require.NoError(t, hdrs.Set(jws.X509CertThumbprintS256Key, thumbprint), `hdrs.Set should succeed`)
+		}
+		signed, err := jws.Sign(payload, jws.WithKey(jwa.ES256, leafKey, jws.WithProtectedHeaders(hdrs)))
+		require.NoError(t, err, `jws.Sign should succeed`)
+		return signed
+	}
+
+	leafThumbprint := sha256.Sum256(leaf.Raw)
+	rootThumbprint := sha256.Sum256(root.Raw)
+
+	testcases := []struct {
+		Name    string
+		Signed  []byte
+		Roots   *x509.CertPool
+		Options x509.VerifyOptions
+		Error   bool
+	}{
+		{
+			Name:   "Valid chain",
+			Signed: sign(t, []*x509.Certificate{leaf, intermediate}, ""),
+			Roots:  roots,
+		},
+		{
+			Name:    "Roots via x509.VerifyOptions",
+			Signed:  sign(t, []*x509.Certificate{leaf, intermediate}, ""),
+			Options: x509.VerifyOptions{Roots: roots},
+		},
+		{
+			Name:   "Matching x5t#S256",
+			Signed: sign(t, []*x509.Certificate{leaf, intermediate}, base64.EncodeToString(leafThumbprint[:])),
+			Roots:  roots,
+		},
+		{
+			Name:   "Mismatching x5t#S256",
+			Signed: sign(t, []*x509.Certificate{leaf, intermediate}, base64.EncodeToString(rootThumbprint[:])),
+			Roots:  roots,
+			Error:  true,
+		},
+		{
+			Name:   "Untrusted root",
+			Signed: sign(t, []*x509.Certificate{leaf, intermediate}, ""),
+			Roots:  otherRoots,
+			Error:  true,
+		},
+		{
+			Name:   "Missing intermediate",
+			Signed: sign(t, []*x509.Certificate{leaf}, ""),
+			Roots:  roots,
+			Error:  true,
+		},
+		{
+			Name:    "Expired certificate",
+			Signed:  sign(t, []*x509.Certificate{leaf, intermediate}, ""),
+			Roots:   roots,
+			Options: x509.VerifyOptions{CurrentTime: now.Add(2 * time.Hour)},
+			Error:   true,
+		},
+		{
+			Name:   "Certificate not for digital signatures",
+			Signed: sign(t, []*x509.Certificate{encipherOnly, intermediate}, ""),
+			Roots:  roots,
+			Error:  true,
+		},
+		{
+			Name:   "Signed by a different key",
+			Signed: sign(t, []*x509.Certificate{otherLeaf, intermediate}, ""),
+			Roots:  roots,
+			Error:  true,
+		},
+		{
+			Name:   "Missing x5c",
+			Signed: sign(t, nil, ""),
+			Roots:  roots,
+			Error:  true,
+		},
+	}
+
+	for _, tc := range testcases {
+		tc := tc
+		t.Run(tc.Name, func(t *testing.T) {
+			verified, err := jws.Verify(tc.Signed, jws.WithX5CKeyProvider(tc.Roots, tc.Options))
+			if tc.Error {
+				require.Error(t, err, `jws.Verify should fail`)
+				return
+			}
+			require.NoError(t, err, `jws.Verify should succeed`)
+			require.Equal(t, payload, verified, `payload should match`)
+		})
+	}
+
+	t.Run("jwt", func(t *testing.T) {
+		var chain cert.Chain
+		for _, c := range []*x509.Certificate{leaf, intermediate} {
+			encoded, err := cert.EncodeBase64(c.Raw)
+			require.NoError(t, err, `cert.EncodeBase64 should succeed`)
+			require.NoError(t, chain.Add(encoded), `chain.Add should succeed`)
+		}
+		hdrs := jws.NewHeaders()
+		require.NoError(t, hdrs.Set(jws.X509CertChainKey, &chain), `hdrs.Set should succeed`)
+
+		tok, err := jwt.NewBuilder().Subject(`x5c`).Build()
+		require.NoError(t, err, `jwt.NewBuilder should succeed`)
+		signed, err := jwt.Sign(tok, jwt.WithKey(jwa.ES256, leafKey, jws.WithProtectedHeaders(hdrs)))
+		require.NoError(t, err, `jwt.Sign should succeed`)
+
+		parsed, err := jwt.Parse(signed, jwt.WithX5CKeyProvider(roots, x509.VerifyOptions{}))
+		require.NoError(t, err, `jwt.Parse should succeed`)
+		require.Equal(t, `x5c`, parsed.Subject(), `subject should match`)
+
+		_, err = jwt.Parse(signed, jwt.WithX5CKeyProvider(otherRoots, x509.VerifyOptions{}))
+		require.Error(t, err, `jwt.Parse should fail`)
+	})
+}
+
+func TestWithCertificate(t *testing.T) {
+	now := time.Now()
+	rootKey, err := jwxtest.GenerateEcdsaKey(jwa.P256)
+	require.NoError(t, err, `jwxtest.GenerateEcdsaKey should succeed`)
+	leafKey, err := jwxtest.GenerateRsaKey()
+	require.NoError(t, err, `jwxtest.GenerateRsaKey should succeed`)
+
+	root := issueCertificate(t, now, 1, &rootKey.PublicKey, rootKey, nil, true, x509.KeyUsageCertSign)
+	leaf := issueCertificate(t, now, 2, &leafKey.PublicKey, rootKey, root, false, x509.KeyUsageDigitalSignature)
+
+	jwkKey, err := jwk.FromRaw(leafKey)
+	require.NoError(t, err, `jwk.FromRaw should succeed`)
+
+	payload := []byte(`Lorem ipsum`)
+	for _, key := range []interface{}{leafKey, jwkKey} {
+		key := key
+		t.Run(fmt.Sprintf("%T", key), func(t *testing.T) {
+			signed, err := jws.Sign(payload, jws.WithKey(jwa.RS256, key, jws.WithCertificate(leaf, root)))
+			require.NoError(t, err, `jws.Sign should succeed`)
+
+			msg, err := jws.Parse(signed)
+			require.NoError(t, err, `jws.Parse should succeed`)
+			hdrs := msg.Signatures()[0].ProtectedHeaders()
+
+			chain := hdrs.X509CertChain()
+			require.NotNil(t, chain, `"x5c" should be set`)
+			require.Equal(t, 2, chain.Len(), `"x5c" should contain 2 certificates`)
+			for i, expected := range []*x509.Certificate{leaf, root} {
+				der, _ := chain.Get(i)
+				c, err := cert.Parse(der)
+				require.NoError(t, err, `cert.Parse should succeed`)
+				require.True(t, expected.Equal(c), `certificate #%d should match`, i)
+			}
+
+			x5t := sha1.Sum(leaf.Raw) //nolint:gosec
+			require.Equal(t, base64.EncodeToString(x5t[:]), hdrs.X509CertThumbprint(), `"x5t" should match`)
+			x5tS256 := sha256.Sum256(leaf.Raw)
+			require.Equal(t, base64.EncodeToString(x5tS256[:]), hdrs.X509CertThumbprintS256(), `"x5t#S256" should match`)
+
+			roots := x509.NewCertPool()
+			roots.AddCert(root)
+			verified, err := jws.Verify(signed, jws.WithX5CKeyProvider(roots, x509.VerifyOptions{}))
+			require.NoError(t, err, `jws.Verify should succeed`)
+			require.Equal(t, payload, verified, `payload should match`)
+		})
+	}
+	t.Run("Mismatching key", func(t *testing.T) {
+		otherKey, err := jwxtest.GenerateRsaKey()
+		require.NoError(t, err, `jwxtest.GenerateRsaKey should succeed`)
+
+		_, err = jws.Sign(payload, jws.WithKey(jwa.RS256, otherKey, jws.WithCertificate(leaf)))
+		require.Error(t, err, `jws.Sign should fail`)
+	})
+}
@@ -0,0 +1,76 @@
+// Package jwstest contains utilities for testing code that uses the
+// jws package.
+package jwstest
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/lestrrat-go/jwx/v2/jwa"
+	"github.com/lestrrat-go/jwx/v2/jws"
+)
+
+// Signer is an in-memory implementation of `jws.ContextSigner`. It can
+// be used in tests in place of a signer that delegates to a remote
+// service, such as a KMS.
+//
+// Signatures are generated using the built-in signers with the key
+// that was given to `jwstest.NewSigner()`, so they can be verified
+// using the corresponding public key.
+type Signer struct {
+	mu    sync.Mutex
+	key   interface{}
+	err   error
+	calls []jwa.SignatureAlgorithm
+}
+
+var _ jws.ContextSigner = &Signer{}
+
+// NewSigner creates a new Signer that signs using `key`. `key` may be
+// anything that can be passed to `jws.WithKey()` when signing, except
+// for a `jws.ContextSigner`.
+func NewSigner(key interface{}) *Signer {
+	return &Signer{key: key}
+}
+
+// SignContext implements `jws.ContextSigner`. If the context has been
+// cancelled, or an error was set using `SetError()`, the error is
+// returned without generating a signature.
+func (s *Signer) SignContext(ctx context.Context, alg jwa.SignatureAlgorithm, signingInput []byte) ([]byte, error) {
+	s.mu.Lock()
+	s.calls = append(s.calls, alg)
+	failure := s.err
+	s.mu.Unlock()
+
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	if failure != nil {
+		return nil, failure
+	}
+
+	signer, err := jws.NewSigner(alg)
+	if err != nil {
+		return nil, fmt.Errorf(`jwstest.Signer: failed to create signer for %q: %w`, alg, err)
+	}
+	return signer.Sign(signingInput, s.key)
+}
+
+// SetError sets the error that is returned by subsequent calls to
+// SignContext. Pass nil to go back to generating signatures.
+func (s *Signer) SetError(err error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.err = err
+}
+
+// Calls returns the algorithms that SignContext was called with, in
+// the order of the calls.
+func (s *Signer) Calls() []jwa.SignatureAlgorithm {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	ret := make([]jwa.SignatureAlgorithm, len(s.calls))
+	copy(ret, s.calls)
+	return ret
+}
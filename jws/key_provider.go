@@ -1,11 +1,16 @@
 package jws
 
 import (
+	"bytes"
 	"context"
+	"crypto/sha256"
+	"crypto/x509"
 	"fmt"
 	"net/url"
 	"sync"
 
+	"github.com/lestrrat-go/jwx/v2/cert"
+	"github.com/lestrrat-go/jwx/v2/internal/base64"
 	"github.com/lestrrat-go/jwx/v2/jwa"
 	"github.com/lestrrat-go/jwx/v2/jwk"
 )
@@ -266,6 +271,75 @@ func (kp jkuProvider) FetchKeys(ctx context.Context, sink KeySink, sig *Signatur
 	return nil
 }
 
+type x5cKeyProvider struct {
+	roots   *x509.CertPool
+	options x509.VerifyOptions
+}
+
+func (kp *x5cKeyProvider) FetchKeys(_ context.Context, sink KeySink, sig *Signature, _ *Message) error {
+	hdrs := sig.ProtectedHeaders()
+	chain := hdrs.X509CertChain()
+	if chain == nil || chain.Len() == 0 {
+		return fmt.Errorf(`use of "x5c" requires that the payload contain a "x5c" field in the protected header`)
+	}
+
+	certs := make([]*x509.Certificate, chain.Len())
+	for i := 0; i < chain.Len(); i++ {
+		der, _ := chain.Get(i)
+		c, err := cert.Parse(der)
+		if err != nil {
+			return fmt.Errorf(`failed to parse certificate #%d in "x5c": %w`, i, err)
+		}
+		certs[i] = c
+	}
+	leaf := certs[0]
+
+	if v := hdrs.X509CertThumbprintS256(); v != "" {
+		thumbprint, err := base64.DecodeString(v)
+		if err != nil {
+			return fmt.Errorf(`failed to decode "x5t#S256": %w`, err)
+		}
+		computed := sha256.Sum256(leaf.Raw)
+		if !bytes.Equal(thumbprint, computed[:]) {
+			return fmt.Errorf(`"x5t#S256" does not match the certificate in "x5c"`)
+		}
+	}
+
+	if leaf.KeyUsage != 0 && leaf.KeyUsage&x509.KeyUsageDigitalSignature == 0 {
+		return fmt.Errorf(`certificate in "x5c" may not be used for digital signatures`)
+	}
+
+	options := kp.options
+	if kp.roots != nil {
+		options.Roots = kp.roots
+	}
+	if len(options.KeyUsages) == 0 {
+		options.KeyUsages = []x509.ExtKeyUsage{x509.ExtKeyUsageAny}
+	}
+	options.Intermediates = x509.NewCertPool()
+	for _, c := range certs[1:] {
+		options.Intermediates.AddCert(c)
+	}
+
+	if _, err := leaf.Verify(options); err != nil {
+		return fmt.Errorf(`failed to verify certificate chain in "x5c": %w`, err)
+	}
+
+	algs, err := AlgorithmsForKey(leaf.PublicKey)
+	if err != nil {
+		return fmt.Errorf(`failed to get a list of signature methods for certificate key %T: %w`, leaf.PublicKey, err)
+	}
+
+	hdrAlg := hdrs.Algorithm()
+	for _, alg := range algs {
+		if hdrAlg == alg {
+			sink.Key(alg, leaf.PublicKey)
+			return nil
+		}
+	}
+	return fmt.Errorf(`algorithm %q cannot be used with the key in "x5c" (%T)`, hdrAlg, leaf.PublicKey)
+}
+
 // KeyProviderFunc is a type of KeyProvider that is implemented by
 // a single function. You can use this to create ad-hoc `KeyProvider`
 // instances.
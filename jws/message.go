@@ -38,6 +38,8 @@ func (s Signature) ProtectedHeaders() Headers {
 
 func (s *Signature) SetProtectedHeaders(v Headers) *Signature {
 	s.protected = v
+	s.rawProtected = nil
+	s.protectedSnapshot = nil
 	return s
 }
 
@@ -66,7 +68,10 @@ func (s *Signature) UnmarshalJSON(data []byte) error {
 	s.headers = sup.Header
 	if buf := sup.Protected; buf != nil {
 		src := []byte(*buf)
-		if !bytes.HasPrefix(src, []byte{'{'}) {
+		raw := src
+		if bytes.HasPrefix(src, []byte{'{'}) {
+			raw = base64.Encode(src)
+		} else {
 			decoded, err := base64.Decode(src)
 			if err != nil {
 				return fmt.Errorf(`failed to base64 decode protected headers: %w`, err)
@@ -83,6 +88,10 @@ func (s *Signature) UnmarshalJSON(data []byte) error {
 		//nolint:forcetypeassert
 		prt.(*stdHeaders).SetDecodeCtx(nil)
 		s.protected = prt
+
+		if err := s.setRawProtected(raw); err != nil {
+			return err
+		}
 	}
 
 	if sup.Signature != nil {
@@ -101,32 +110,20 @@ func (s *Signature) UnmarshalJSON(data []byte) error {
 // The first return value is the raw signature in binary format.
 // The second return value s the full three-segment signature
 // (e.g. "eyXXXX.XXXXX.XXXX")
+//
+// If the key implements `jws.ContextSigner`, it is used to generate the
+// signature instead of the signer, using context.Background().
 func (s *Signature) Sign(payload []byte, signer Signer, key interface{}) ([]byte, []byte, error) {
-	ctx, cancel := context.WithCancel(context.Background())
-	defer cancel()
-
-	hdrs, err := mergeHeaders(ctx, s.headers, s.protected)
-	if err != nil {
-		return nil, nil, fmt.Errorf(`failed to merge headers: %w`, err)
-	}
-
-	if err := hdrs.Set(AlgorithmKey, signer.Algorithm()); err != nil {
-		return nil, nil, fmt.Errorf(`failed to set "alg": %w`, err)
-	}
+	return s.sign(context.Background(), payload, signer, key)
+}
 
-	// If the key is a jwk.Key instance, obtain the raw key
-	if jwkKey, ok := key.(jwk.Key); ok {
-		// If we have a key ID specified by this jwk.Key, use that in the header
-		if kid := jwkKey.KeyID(); kid != "" {
-			if err := hdrs.Set(jwk.KeyIDKey, kid); err != nil {
-				return nil, nil, fmt.Errorf(`set key ID from jwk.Key: %w`, err)
-			}
-		}
-	}
-	hdrbuf, err := json.Marshal(hdrs)
+func (s *Signature) sign(ctx context.Context, payload []byte, signer Signer, key interface{}) ([]byte, []byte, error) {
+	hdrs, hdrbuf, err := s.signingHeaders(signer, key)
 	if err != nil {
-		return nil, nil, fmt.Errorf(`failed to marshal headers: %w`, err)
+		return nil, nil, err
 	}
+	s.rawProtected = nil
+	s.protectedSnapshot = nil
 
 	buf := pool.GetBytesBuffer()
 	defer pool.ReleaseBytesBuffer(buf)
@@ -150,7 +147,12 @@ func (s *Signature) Sign(payload []byte, signer Signer, key interface{}) ([]byte
 		buf.Write(payload)
 	}
 
-	signature, err := signer.Sign(buf.Bytes(), key)
+	var signature []byte
+	if cs, ok := key.(ContextSigner); ok {
+		signature, err = cs.SignContext(ctx, signer.Algorithm(), buf.Bytes())
+	} else {
+		signature, err = signer.Sign(buf.Bytes(), key)
+	}
 	if err != nil {
 		return nil, nil, fmt.Errorf(`failed to sign payload: %w`, err)
 	}
@@ -169,6 +171,37 @@ func (s *Signature) Sign(payload []byte, signer Signer, key interface{}) ([]byte
 	return signature, ret, nil
 }
 
+// signingHeaders returns the merged headers for this signature, along
+// with their JSON representation which is used to create the signing input
+func (s *Signature) signingHeaders(signer Signer, key interface{}) (Headers, []byte, error) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	hdrs, err := mergeHeaders(ctx, s.headers, s.protected)
+	if err != nil {
+		return nil, nil, fmt.Errorf(`failed to merge headers: %w`, err)
+	}
+
+	if err := hdrs.Set(AlgorithmKey, signer.Algorithm()); err != nil {
+		return nil, nil, fmt.Errorf(`failed to set "alg": %w`, err)
+	}
+
+	// If the key is a jwk.Key instance, obtain the raw key
+	if jwkKey, ok := key.(jwk.Key); ok {
+		// If we have a key ID specified by this jwk.Key, use that in the header
+		if kid := jwkKey.KeyID(); kid != "" {
+			if err := hdrs.Set(jwk.KeyIDKey, kid); err != nil {
+				return nil, nil, fmt.Errorf(`set key ID from jwk.Key: %w`, err)
+			}
+		}
+	}
+	hdrbuf, err := json.Marshal(hdrs)
+	if err != nil {
+		return nil, nil, fmt.Errorf(`failed to marshal headers: %w`, err)
+	}
+	return hdrs, hdrbuf, nil
+}
+
 func NewMessage() *Message {
 	return &Message{}
 }
@@ -311,6 +344,9 @@ func (m *Message) UnmarshalJSON(buf []byte) error {
 			//nolint:forcetypeassert
 			prt.(*stdHeaders).SetDecodeCtx(nil)
 			sig.protected = prt
+			if err := sig.setRawProtected([]byte(*src)); err != nil {
+				return err
+			}
 		}
 
 		decoded, err := base64.DecodeString(*mup.Signature)
@@ -371,13 +407,13 @@ func (m Message) marshalFlattened() ([]byte, error) {
 	buf.WriteString(base64.EncodeToString(m.payload))
 	buf.WriteRune('"')
 
-	if protected := sig.protected; protected != nil {
-		protectedbuf, err := protected.MarshalJSON()
+	if sig.protected != nil {
+		protected, err := sig.encodedProtectedHeader()
 		if err != nil {
 			return nil, fmt.Errorf(`failed to marshal "protected" (flattened format): %w`, err)
 		}
 		buf.WriteString(`,"protected":"`)
-		buf.WriteString(base64.EncodeToString(protectedbuf))
+		buf.WriteString(protected)
 		buf.WriteRune('"')
 	}
 
@@ -415,8 +451,8 @@ func (m Message) marshalFull() ([]byte, error) {
 			wrote = true
 		}
 
-		if protected := sig.protected; protected != nil {
-			protectedbuf, err := protected.MarshalJSON()
+		if sig.protected != nil {
+			protected, err := sig.encodedProtectedHeader()
 			if err != nil {
 				return nil, fmt.Errorf(`failed to marshal "protected" for signature #%d: %w`, i+1, err)
 			}
@@ -424,7 +460,7 @@ func (m Message) marshalFull() ([]byte, error) {
 				buf.WriteRune(',')
 			}
 			buf.WriteString(`"protected":"`)
-			buf.WriteString(base64.EncodeToString(protectedbuf))
+			buf.WriteString(protected)
 			buf.WriteRune('"')
 			wrote = true
 		}
@@ -472,7 +508,7 @@ func Compact(msg *Message, options ...CompactOption) ([]byte, error) {
 	// XXX check if this is correct
 	hdrs := s.ProtectedHeaders()
 
-	hdrbuf, err := json.Marshal(hdrs)
+	protected, err := s.encodedProtectedHeader()
 	if err != nil {
 		return nil, fmt.Errorf(`jws.Compress: failed to marshal headers: %w`, err)
 	}
@@ -480,7 +516,7 @@ func Compact(msg *Message, options ...CompactOption) ([]byte, error) {
 	buf := pool.GetBytesBuffer()
 	defer pool.ReleaseBytesBuffer(buf)
 
-	buf.WriteString(base64.EncodeToString(hdrbuf))
+	buf.WriteString(protected)
 	buf.WriteByte('.')
 
 	if !detached {
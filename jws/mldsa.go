@@ -0,0 +1,100 @@
+//go:build go1.27
+// +build go1.27
+
+package jws
+
+import (
+	"crypto"
+	"crypto/mldsa"
+	"crypto/rand"
+	"fmt"
+	"reflect"
+
+	"github.com/lestrrat-go/jwx/v2/internal/keyconv"
+	"github.com/lestrrat-go/jwx/v2/jwa"
+)
+
+func init() {
+	algs := map[jwa.SignatureAlgorithm]mldsa.Parameters{
+		jwa.MLDSA44: mldsa.MLDSA44(),
+		jwa.MLDSA65: mldsa.MLDSA65(),
+		jwa.MLDSA87: mldsa.MLDSA87(),
+	}
+
+	for alg, params := range algs {
+		signer := &mldsaSigner{alg: alg, params: params}
+		verifier := &mldsaVerifier{alg: alg, params: params}
+		RegisterSigner(alg, SignerFactoryFn(func() (Signer, error) {
+			return signer, nil
+		}))
+		RegisterVerifier(alg, VerifierFactoryFn(func() (Verifier, error) {
+			return verifier, nil
+		}))
+		addAlgorithmForKeyType(jwa.AKP, alg)
+	}
+	rawKeyToKeyType[reflect.TypeOf((*mldsa.PublicKey)(nil))] = jwa.AKP
+	rawKeyToKeyType[reflect.TypeOf((*mldsa.PrivateKey)(nil))] = jwa.AKP
+}
+
+// mldsaSigners are immutable.
+type mldsaSigner struct {
+	alg    jwa.SignatureAlgorithm
+	params mldsa.Parameters
+}
+
+func (s *mldsaSigner) Algorithm() jwa.SignatureAlgorithm {
+	return s.alg
+}
+
+func (s *mldsaSigner) Sign(payload []byte, key interface{}) ([]byte, error) {
+	if key == nil {
+		return nil, fmt.Errorf(`missing private key while signing payload`)
+	}
+
+	// *mldsa.PrivateKey implements crypto.Signer, so we should simply
+	// accept a crypto.Signer here.
+	signer, ok := key.(crypto.Signer)
+	if !ok {
+		// This fallback exists for cases when jwk.Key was passed
+		var privkey *mldsa.PrivateKey
+		if err := keyconv.MLDSAPrivateKey(&privkey, key); err != nil {
+			return nil, fmt.Errorf(`failed to retrieve *mldsa.PrivateKey out of %T: %w`, key, err)
+		}
+		signer = privkey
+	}
+
+	pubkey, ok := signer.Public().(*mldsa.PublicKey)
+	if !ok {
+		return nil, fmt.Errorf(`expected crypto.Signer.Public() to return *mldsa.PublicKey, but got %T`, signer.Public())
+	}
+	if pubkey.Parameters() != s.params {
+		return nil, fmt.Errorf(`invalid key for %s: key uses parameter set %s`, s.alg, pubkey.Parameters())
+	}
+
+	return signer.Sign(rand.Reader, payload, crypto.Hash(0))
+}
+
+// mldsaVerifiers are immutable.
+type mldsaVerifier struct {
+	alg    jwa.SignatureAlgorithm
+	params mldsa.Parameters
+}
+
+func (v *mldsaVerifier) Verify(payload, signature []byte, key interface{}) error {
+	if key == nil {
+		return fmt.Errorf(`missing public key while verifying payload`)
+	}
+
+	var pubkey *mldsa.PublicKey
+	if err := keyconv.MLDSAPublicKey(&pubkey, key); err != nil {
+		return fmt.Errorf(`failed to retrieve *mldsa.PublicKey out of %T: %w`, key, err)
+	}
+	if pubkey.Parameters() != v.params {
+		return fmt.Errorf(`invalid key for %s: key uses parameter set %s`, v.alg, pubkey.Parameters())
+	}
+
+	if err := mldsa.Verify(pubkey, payload, signature, nil); err != nil {
+		return fmt.Errorf(`failed to match ML-DSA signature: %w`, err)
+	}
+	return nil
+}
@@ -0,0 +1,95 @@
+//go:build go1.27
+// +build go1.27
+
+package jws_test
+
+import (
+	"crypto/mldsa"
+	"sort"
+	"testing"
+
+	"github.com/lestrrat-go/jwx/v2/jwa"
+	"github.com/lestrrat-go/jwx/v2/jwk"
+	"github.com/lestrrat-go/jwx/v2/jws"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMLDSA(t *testing.T) {
+	t.Parallel()
+	payload := []byte("Lorem ipsum")
+
+	testcases := []struct {
+		Algorithm  jwa.SignatureAlgorithm
+		Parameters mldsa.Parameters
+	}{
+		{Algorithm: jwa.MLDSA44, Parameters: mldsa.MLDSA44()},
+		{Algorithm: jwa.MLDSA65, Parameters: mldsa.MLDSA65()},
+		{Algorithm: jwa.MLDSA87, Parameters: mldsa.MLDSA87()},
+	}
+	for _, tc := range testcases {
+		tc := tc
+		t.Run(tc.Algorithm.String(), func(t *testing.T) {
+			t.Parallel()
+			key, err := mldsa.GenerateKey(tc.Parameters)
+			require.NoError(t, err, `mldsa.GenerateKey should succeed`)
+
+			jwkKey, err := jwk.FromRaw(key.PublicKey())
+			require.NoError(t, err, `jwk.FromRaw should succeed`)
+			keys := map[string]interface{}{
+				"Verify(*mldsa.PublicKey)":  key.PublicKey(),
+				"Verify(*mldsa.PrivateKey)": key,
+				"Verify(jwk.Key)":           jwkKey,
+			}
+			testRoundtrip(t, payload, tc.Algorithm, key, keys)
+		})
+	}
+
+	t.Run("Parameter set mismatch", func(t *testing.T) {
+		t.Parallel()
+		key, err := mldsa.GenerateKey(mldsa.MLDSA44())
+		require.NoError(t, err, `mldsa.GenerateKey should succeed`)
+
+		_, err = jws.Sign(payload, jws.WithKey(jwa.MLDSA65, key))
+		require.Error(t, err, `jws.Sign should fail for ML-DSA-44 key with ML-DSA-65`)
+
+		signed, err := jws.Sign(payload, jws.WithKey(jwa.MLDSA44, key))
+		require.NoError(t, err, `jws.Sign should succeed`)
+
+		other, err := mldsa.GenerateKey(mldsa.MLDSA65())
+		require.NoError(t, err, `mldsa.GenerateKey should succeed`)
+		_, err = jws.Verify(signed, jws.WithKey(jwa.MLDSA44, other.PublicKey()))
+		require.Error(t, err, `jws.Verify should fail for ML-DSA-65 key`)
+	})
+	t.Run("AlgorithmsForKey", func(t *testing.T) {
+		t.Parallel()
+		key, err := mldsa.GenerateKey(mldsa.MLDSA44())
+		require.NoError(t, err, `mldsa.GenerateKey should succeed`)
+
+		expected := []jwa.SignatureAlgorithm{jwa.MLDSA44, jwa.MLDSA65, jwa.MLDSA87}
+		for _, v := range []interface{}{key, key.PublicKey()} {
+			algs, err := jws.AlgorithmsForKey(v)
+			require.NoError(t, err, `jws.AlgorithmsForKey should succeed`)
+			sort.Slice(algs, func(i, j int) bool {
+				return algs[i].String() < algs[j].String()
+			})
+			require.Equal(t, expected, algs, `results should match`)
+		}
+	})
+	t.Run("Infer algorithm from key", func(t *testing.T) {
+		t.Parallel()
+		key, err := mldsa.GenerateKey(mldsa.MLDSA87())
+		require.NoError(t, err, `mldsa.GenerateKey should succeed`)
+
+		signed, err := jws.Sign(payload, jws.WithKey(jwa.MLDSA87, key))
+		require.NoError(t, err, `jws.Sign should succeed`)
+
+		pubkey, err := jwk.FromRaw(key.PublicKey())
+		require.NoError(t, err, `jwk.FromRaw should succeed`)
+		set := jwk.NewSet()
+		require.NoError(t, set.AddKey(pubkey), `set.AddKey should succeed`)
+
+		verified, err := jws.Verify(signed, jws.WithKeySet(set, jws.WithInferAlgorithmFromKey(true), jws.WithRequireKid(false)))
+		require.NoError(t, err, `jws.Verify should succeed`)
+		require.Equal(t, payload, verified, `payload should match`)
+	})
+}
@@ -1,6 +1,8 @@
 package jws
 
 import (
+	"crypto/x509"
+
 	"github.com/lestrrat-go/jwx/v2/jwa"
 	"github.com/lestrrat-go/jwx/v2/jwk"
 	"github.com/lestrrat-go/option"
@@ -38,10 +40,16 @@ func WithJSON(options ...WithJSONSuboption) SignOption {
 }
 
 type withKey struct {
-	alg       jwa.KeyAlgorithm
-	key       interface{}
-	protected Headers
-	public    Headers
+	alg         jwa.KeyAlgorithm
+	key         interface{}
+	protected   Headers
+	public      Headers
+	certificate *withCertificate
+}
+
+type withCertificate struct {
+	leaf          *x509.Certificate
+	intermediates []*x509.Certificate
 }
 
 // This exist as escape hatches to modify the header values after the fact
@@ -70,6 +78,7 @@ func (w *withKey) Protected(v Headers) Headers {
 // Any of the followin is accepted for the `key` parameter:
 // * A "raw" key (e.g. rsa.PrivateKey, ecdsa.PrivateKey, etc)
 // * A crypto.Signer
+// * A jws.ContextSigner
 // * A jwk.Key
 //
 // A `crypto.Signer` is used when the private part of a key is
@@ -78,6 +87,10 @@ func (w *withKey) Protected(v Headers) Headers {
 // family of algorithms. You may consider using `github.com/jwx-go/crypto-signer`
 // if you would like to use keys stored in GCP/AWS KMS services.
 //
+// A `jws.ContextSigner` works with any algorithm, and receives the
+// context.Context specified using `jws.WithContext()`. This is the
+// preferred way to sign using remote services.
+//
 // If the key is a jwk.Key and the key contains a key ID (`kid` field),
 // then it is added to the protected header generated by the signature.
 //
@@ -95,6 +108,7 @@ func WithKey(alg jwa.KeyAlgorithm, key interface{}, options ...WithKeySuboption)
 	// Verify(). As such we don't create a KeyProvider here because
 	// if used in Sign() we would be doing something else.
 	var protected, public Headers
+	var certificate *withCertificate
 	for _, option := range options {
 		//nolint:forcetypeassert
 		switch option.Ident() {
@@ -102,19 +116,40 @@ func WithKey(alg jwa.KeyAlgorithm, key interface{}, options ...WithKeySuboption)
 			protected = option.Value().(Headers)
 		case identPublicHeaders{}:
 			public = option.Value().(Headers)
+		case identCertificate{}:
+			certificate = option.Value().(*withCertificate)
 		}
 	}
 
 	return &signVerifyOption{
 		option.New(identKey{}, &withKey{
-			alg:       alg,
-			key:       key,
-			protected: protected,
-			public:    public,
+			alg:         alg,
+			key:         key,
+			protected:   protected,
+			public:      public,
+			certificate: certificate,
 		}),
 	}
 }
 
+// WithCertificate is used with `jws.WithKey()` option when used with
+// `jws.Sign()` to specify the X.509 certificate for the signing key.
+// The `x5c` (leaf followed by the intermediates), `x5t`, and `x5t#S256`
+// fields of the protected header are populated from the certificates.
+//
+// `jws.Sign()` returns an error if the public key in the leaf certificate
+// does not match the signing key. For keys that do not expose their
+// public key (e.g. some `jws.ContextSigner` implementations), the key
+// must implement `Public() crypto.PublicKey` for the check to succeed.
+//
+// It has no effect if used when `jws.WithKey()` is passed to `jws.Verify()`
+func WithCertificate(leaf *x509.Certificate, intermediates ...*x509.Certificate) WithKeySuboption {
+	return &withKeySuboption{option.New(identCertificate{}, &withCertificate{
+		leaf:          leaf,
+		intermediates: intermediates,
+	})}
+}
+
 // WithKeySet specifies a JWKS (jwk.Set) to use for verification.
 //
 // By default both `alg` and `kid` fields in the JWS _and_ the
@@ -123,6 +158,23 @@ func WithKey(alg jwa.KeyAlgorithm, key interface{}, options ...WithKeySuboption)
 // The behavior can be tweaked by using the `jws.WithKeySetSuboption`
 // suboption types.
 func WithKeySet(set jwk.Set, options ...WithKeySetSuboption) VerifyOption {
+	return WithKeyProvider(newKeySetProvider(set, options))
+}
+
+// WithRequiredKeySet specifies a JWKS (jwk.Set) from which at least one
+// key must have successfully verified a signature for `jws.VerifyAll()`
+// to succeed. The keys in the set are also used for verification, so
+// they do not need to be specified separately via `jws.WithKeySet()`.
+//
+// The option may be specified multiple times, for example to require
+// one signature from each of several parties. Keys are matched using
+// the same rules as `jws.WithKeySet()`, and the behavior can be tweaked
+// by using the same suboptions.
+func WithRequiredKeySet(set jwk.Set, options ...WithKeySetSuboption) VerifyAllOption {
+	return &verifyAllOption{option.New(identRequiredKeySet{}, newKeySetProvider(set, options))}
+}
+
+func newKeySetProvider(set jwk.Set, options []WithKeySetSuboption) *keySetProvider {
 	requireKid := true
 	var useDefault, inferAlgorithm, multipleKeysPerKeyID bool
 	for _, option := range options {
@@ -139,13 +191,21 @@ func WithKeySet(set jwk.Set, options ...WithKeySetSuboption) VerifyOption {
 		}
 	}
 
-	return WithKeyProvider(&keySetProvider{
+	return &keySetProvider{
 		set:                  set,
 		requireKid:           requireKid,
 		useDefault:           useDefault,
 		multipleKeysPerKeyID: multipleKeysPerKeyID,
 		inferAlgorithm:       inferAlgorithm,
-	})
+	}
+}
+
+// WithCriticalHeaders specifies the names of header parameters that
+// are understood by the caller, in addition to those registered via
+// `jws.RegisterCriticalHeader()`. Signatures whose "crit" header lists
+// names that are not understood are not verified.
+func WithCriticalHeaders(names ...string) VerifyOption {
+	return &verifyOption{option.New(identCriticalHeaders{}, names)}
 }
 
 func WithVerifyAuto(f jwk.Fetcher, options ...jwk.FetchOption) VerifyOption {
@@ -163,6 +223,29 @@ func WithVerifyAuto(f jwk.Fetcher, options ...jwk.FetchOption) VerifyOption {
 	})
 }
 
+// WithX5CKeyProvider specifies that the certificate chain in the `x5c`
+// field of the protected header should be used for verification. The
+// chain is verified against `roots` using `opts`, and the signature is
+// then verified using the public key of the first (leaf) certificate.
+//
+// If `roots` is non-nil, it takes precedence over `opts.Roots`. Note that
+// if neither is specified, the system roots are used. The intermediate
+// certificates are always taken from `x5c` (`opts.Intermediates` is
+// ignored), and if `opts.KeyUsages` is empty, any extended key usage is
+// accepted instead of the server authentication default of the `x509`
+// package. The leaf certificate must also allow digital signatures, if
+// it specifies a key usage.
+//
+// If the protected header contains `x5t#S256`, it must match the
+// thumbprint of the leaf certificate. The `alg` field must be
+// compatible with the type of the leaf certificate key.
+func WithX5CKeyProvider(roots *x509.CertPool, opts x509.VerifyOptions) VerifyOption {
+	return WithKeyProvider(&x5cKeyProvider{
+		roots:   roots,
+		options: opts,
+	})
+}
+
 type withInsecureNoSignature struct {
 	protected Headers
 }
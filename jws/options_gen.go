@@ -6,6 +6,7 @@ import (
 	"context"
 	"io/fs"
 
+	"github.com/lestrrat-go/jwx/v2"
 	"github.com/lestrrat-go/option"
 )
 
@@ -64,6 +65,7 @@ type SignVerifyOption interface {
 	Option
 	signOption()
 	verifyOption()
+	verifyAllOption()
 }
 
 type signVerifyOption struct {
@@ -74,10 +76,26 @@ func (*signVerifyOption) signOption() {}
 
 func (*signVerifyOption) verifyOption() {}
 
+func (*signVerifyOption) verifyAllOption() {}
+
+// VerifyAllOption describes options that can be passed to `jws.VerifyAll`
+type VerifyAllOption interface {
+	Option
+	verifyAllOption()
+}
+
+type verifyAllOption struct {
+	Option
+}
+
+func (*verifyAllOption) verifyAllOption() {}
+
 // VerifyOption describes options that can be passed to `jws.Verify`
+// and `jws.VerifyAll`
 type VerifyOption interface {
 	Option
 	verifyOption()
+	verifyAllOption()
 }
 
 type verifyOption struct {
@@ -86,6 +104,8 @@ type verifyOption struct {
 
 func (*verifyOption) verifyOption() {}
 
+func (*verifyOption) verifyAllOption() {}
+
 // JSONSuboption describes suboptions that can be passed to `jws.WithJSON()` option
 type WithJSONSuboption interface {
 	Option
@@ -123,9 +143,12 @@ type withKeySuboption struct {
 
 func (*withKeySuboption) withKeySuboption() {}
 
+type identCertificate struct{}
 type identContext struct{}
+type identCriticalHeaders struct{}
 type identDetached struct{}
 type identDetachedPayload struct{}
+type identDeterministicSignatures struct{}
 type identFS struct{}
 type identInferAlgorithmFromKey struct{}
 type identKey struct{}
@@ -133,17 +156,28 @@ type identKeyProvider struct{}
 type identKeyUsed struct{}
 type identMessage struct{}
 type identMultipleKeysPerKeyID struct{}
+type identPolicy struct{}
 type identPretty struct{}
 type identProtectedHeaders struct{}
 type identPublicHeaders struct{}
 type identRequireKid struct{}
+type identRequiredKeySet struct{}
+type identRequiredSignatures struct{}
 type identSerialization struct{}
 type identUseDefault struct{}
 
+func (identCertificate) String() string {
+	return "WithCertificate"
+}
+
 func (identContext) String() string {
 	return "WithContext"
 }
 
+func (identCriticalHeaders) String() string {
+	return "WithCriticalHeaders"
+}
+
 func (identDetached) String() string {
 	return "WithDetached"
 }
@@ -152,6 +186,10 @@ func (identDetachedPayload) String() string {
 	return "WithDetachedPayload"
 }
 
+func (identDeterministicSignatures) String() string {
+	return "WithDeterministicSignatures"
+}
+
 func (identFS) String() string {
 	return "WithFS"
 }
@@ -180,6 +218,10 @@ func (identMultipleKeysPerKeyID) String() string {
 	return "WithMultipleKeysPerKeyID"
 }
 
+func (identPolicy) String() string {
+	return "WithPolicy"
+}
+
 func (identPretty) String() string {
 	return "WithPretty"
 }
@@ -196,6 +238,14 @@ func (identRequireKid) String() string {
 	return "WithRequireKid"
 }
 
+func (identRequiredKeySet) String() string {
+	return "WithRequiredKeySet"
+}
+
+func (identRequiredSignatures) String() string {
+	return "WithRequiredSignatures"
+}
+
 func (identSerialization) String() string {
 	return "WithSerialization"
 }
@@ -204,8 +254,11 @@ func (identUseDefault) String() string {
 	return "WithUseDefault"
 }
 
-func WithContext(v context.Context) VerifyOption {
-	return &verifyOption{option.New(identContext{}, v)}
+// WithContext specifies the context.Context object to use when
+// fetching keys in `jws.Verify()`, or when signing using keys that
+// implement `jws.ContextSigner` in `jws.Sign()`.
+func WithContext(v context.Context) SignVerifyOption {
+	return &signVerifyOption{option.New(identContext{}, v)}
 }
 
 // WithDetached specifies that the `jws.Message` should be serialized in
@@ -226,6 +279,24 @@ func WithDetachedPayload(v []byte) SignVerifyOption {
 	return &signVerifyOption{option.New(identDetachedPayload{}, v)}
 }
 
+// WithDeterministicSignatures specifies that ECDSA signatures (ES256, ES384,
+// ES512, ES256K, ESB256, ESB384, and ESB512) should be computed using deterministic nonces as
+// described in RFC 6979, instead of nonces generated from a random source.
+// Signing the same payload with the same key and headers always produces
+// the same output.
+//
+// When this option is specified, the signers for these algorithms are
+// replaced by those created by `jws.NewDeterministicECDSASigner()`, and
+// the keys must be ECDSA private keys (either raw or `jwk.Key`).
+// Signatures for other algorithms are not affected.
+//
+// Keys implementing `jws.ContextSigner` compute their signatures by
+// themselves, and therefore can not be used with this option. Attempting
+// to do so for the algorithms listed above results in an error.
+func WithDeterministicSignatures() SignOption {
+	return &signOption{option.New(identDeterministicSignatures{}, true)}
+}
+
 // WithFS specifies the source `fs.FS` object to read the file from.
 func WithFS(v fs.FS) ReadFileOption {
 	return &readFileOption{option.New(identFS{}, v)}
@@ -282,6 +353,17 @@ func WithMultipleKeysPerKeyID(v bool) WithKeySetSuboption {
 	return &withKeySetSuboption{option.New(identMultipleKeysPerKeyID{}, v)}
 }
 
+// WithPolicy specifies the `jwx.Policy` to enforce when signing or
+// verifying, in place of the policy installed via `jwx.SetDefaultPolicy()`.
+//
+// When signing, an error is returned if any of the algorithm and key
+// pairs violate the policy. When verifying, algorithm and key pairs
+// that violate the policy are not used, and if no other pair could
+// verify the message, the policy violation is reported in the error.
+func WithPolicy(v *jwx.Policy) SignVerifyOption {
+	return &signVerifyOption{option.New(identPolicy{}, v)}
+}
+
 // WithPretty specifies whether the JSON output should be formatted and
 // indented
 func WithPretty(v bool) WithJSONSuboption {
@@ -314,6 +396,20 @@ func WithRequireKid(v bool) WithKeySetSuboption {
 	return &withKeySetSuboption{option.New(identRequireKid{}, v)}
 }
 
+// WithRequiredSignatures specifies the minimum number of signatures
+// that must be successfully verified for `jws.VerifyAll()` to succeed.
+// Signatures are counted per distinct key (as determined by the JWK
+// thumbprint of its public key), so the same signer is only counted
+// once even if its signature appears multiple times in the message.
+//
+// By default all signatures in the message must be verified. If the
+// value is less than or equal to 0, no minimum is enforced other
+// than at least one signature being verified, which is useful when
+// combined with `jws.WithRequiredKeySet()`.
+func WithRequiredSignatures(v int) VerifyAllOption {
+	return &verifyAllOption{option.New(identRequiredSignatures{}, v)}
+}
+
 // WithCompact specifies that the result of `jws.Sign()` is serialized in
 // compact format.
 //
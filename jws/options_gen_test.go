@@ -9,9 +9,12 @@ import (
 )
 
 func TestOptionIdent(t *testing.T) {
+	require.Equal(t, "WithCertificate", identCertificate{}.String())
 	require.Equal(t, "WithContext", identContext{}.String())
+	require.Equal(t, "WithCriticalHeaders", identCriticalHeaders{}.String())
 	require.Equal(t, "WithDetached", identDetached{}.String())
 	require.Equal(t, "WithDetachedPayload", identDetachedPayload{}.String())
+	require.Equal(t, "WithDeterministicSignatures", identDeterministicSignatures{}.String())
 	require.Equal(t, "WithFS", identFS{}.String())
 	require.Equal(t, "WithInferAlgorithmFromKey", identInferAlgorithmFromKey{}.String())
 	require.Equal(t, "WithKey", identKey{}.String())
@@ -19,10 +22,13 @@ func TestOptionIdent(t *testing.T) {
 	require.Equal(t, "WithKeyUsed", identKeyUsed{}.String())
 	require.Equal(t, "WithMessage", identMessage{}.String())
 	require.Equal(t, "WithMultipleKeysPerKeyID", identMultipleKeysPerKeyID{}.String())
+	require.Equal(t, "WithPolicy", identPolicy{}.String())
 	require.Equal(t, "WithPretty", identPretty{}.String())
 	require.Equal(t, "WithProtectedHeaders", identProtectedHeaders{}.String())
 	require.Equal(t, "WithPublicHeaders", identPublicHeaders{}.String())
 	require.Equal(t, "WithRequireKid", identRequireKid{}.String())
+	require.Equal(t, "WithRequiredKeySet", identRequiredKeySet{}.String())
+	require.Equal(t, "WithRequiredSignatures", identRequiredSignatures{}.String())
 	require.Equal(t, "WithSerialization", identSerialization{}.String())
 	require.Equal(t, "WithUseDefault", identUseDefault{}.String())
 }
@@ -5,6 +5,7 @@ import (
 	"crypto/rand"
 	"crypto/rsa"
 	"fmt"
+	"hash"
 
 	"github.com/lestrrat-go/jwx/v2/internal/keyconv"
 	"github.com/lestrrat-go/jwx/v2/jwa"
@@ -72,6 +73,18 @@ func (rs *rsaSigner) Algorithm() jwa.SignatureAlgorithm {
 }
 
 func (rs *rsaSigner) Sign(payload []byte, key interface{}) ([]byte, error) {
+	h := rs.hash.New()
+	if _, err := h.Write(payload); err != nil {
+		return nil, fmt.Errorf(`failed to write payload to hash: %w`, err)
+	}
+	return rs.signDigest(h.Sum(nil), key)
+}
+
+func (rs *rsaSigner) newHash(interface{}) (hash.Hash, error) {
+	return rs.hash.New(), nil
+}
+
+func (rs *rsaSigner) signDigest(digest []byte, key interface{}) ([]byte, error) {
 	if key == nil {
 		return nil, fmt.Errorf(`missing private key while signing payload`)
 	}
@@ -85,17 +98,13 @@ func (rs *rsaSigner) Sign(payload []byte, key interface{}) ([]byte, error) {
 		signer = &privkey
 	}
 
-	h := rs.hash.New()
-	if _, err := h.Write(payload); err != nil {
-		return nil, fmt.Errorf(`failed to write payload to hash: %w`, err)
-	}
 	if rs.pss {
-		return signer.Sign(rand.Reader, h.Sum(nil), &rsa.PSSOptions{
+		return signer.Sign(rand.Reader, digest, &rsa.PSSOptions{
 			Hash:       rs.hash,
 			SaltLength: rsa.PSSSaltLengthEqualsHash,
 		})
 	}
-	return signer.Sign(rand.Reader, h.Sum(nil), rs.hash)
+	return signer.Sign(rand.Reader, digest, rs.hash)
 }
 
 type rsaVerifier struct {
@@ -109,6 +118,18 @@ func newRSAVerifier(alg jwa.SignatureAlgorithm) Verifier {
 }
 
 func (rv *rsaVerifier) Verify(payload, signature []byte, key interface{}) error {
+	h := rv.hash.New()
+	if _, err := h.Write(payload); err != nil {
+		return fmt.Errorf(`failed to write payload to hash: %w`, err)
+	}
+	return rv.verifyDigest(h.Sum(nil), signature, key)
+}
+
+func (rv *rsaVerifier) newHash(interface{}) (hash.Hash, error) {
+	return rv.hash.New(), nil
+}
+
+func (rv *rsaVerifier) verifyDigest(digest, signature []byte, key interface{}) error {
 	if key == nil {
 		return fmt.Errorf(`missing public key while verifying payload`)
 	}
@@ -130,13 +151,8 @@ func (rv *rsaVerifier) Verify(payload, signature []byte, key interface{}) error
 		}
 	}
 
-	h := rv.hash.New()
-	if _, err := h.Write(payload); err != nil {
-		return fmt.Errorf(`failed to write payload to hash: %w`, err)
-	}
-
 	if rv.pss {
-		return rsa.VerifyPSS(&pubkey, rv.hash, h.Sum(nil), signature, nil)
+		return rsa.VerifyPSS(&pubkey, rv.hash, digest, signature, nil)
 	}
-	return rsa.VerifyPKCS1v15(&pubkey, rv.hash, h.Sum(nil), signature)
+	return rsa.VerifyPKCS1v15(&pubkey, rv.hash, digest, signature)
 }
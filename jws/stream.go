@@ -0,0 +1,311 @@
+package jws
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"hash"
+	"io"
+	"strings"
+
+	"github.com/lestrrat-go/blackmagic"
+	"github.com/lestrrat-go/jwx/v2"
+	"github.com/lestrrat-go/jwx/v2/jwa"
+)
+
+// digestSigner is implemented by signers that compute the signature
+// from a digest of the signing input, which allows the signing input
+// to be hashed incrementally. The hash returned by newHash may depend
+// on the key (e.g. HMAC)
+type digestSigner interface {
+	newHash(key interface{}) (hash.Hash, error)
+	signDigest(digest []byte, key interface{}) ([]byte, error)
+}
+
+// digestVerifier is the counterpart of digestSigner for verifiers
+type digestVerifier interface {
+	newHash(key interface{}) (hash.Hash, error)
+	verifyDigest(digest, signature []byte, key interface{}) error
+}
+
+// copyPayload copies the payload from rdr to w, base64 encoding the
+// contents if necessary
+func copyPayload(w io.Writer, rdr io.Reader, b64 bool) error {
+	if !b64 {
+		if _, err := io.Copy(w, rdr); err != nil {
+			return fmt.Errorf(`failed to read payload: %w`, err)
+		}
+		return nil
+	}
+
+	enc := base64.NewEncoder(base64.RawURLEncoding, w)
+	if _, err := io.Copy(enc, rdr); err != nil {
+		return fmt.Errorf(`failed to read payload: %w`, err)
+	}
+	if err := enc.Close(); err != nil {
+		return fmt.Errorf(`failed to flush base64 encoder: %w`, err)
+	}
+	return nil
+}
+
+// SignReader works like `jws.Sign()`, but reads the payload from an
+// io.Reader and hashes it incrementally, without ever holding the
+// entire payload in memory. This is useful when signing large
+// artifacts.
+//
+// The payload is always treated as detached, and therefore the
+// generated message (in either compact or JSON serialization) does not
+// contain the payload. Use `jws.VerifyReader()` or `jws.Verify()` with
+// `jws.WithDetachedPayload()` to verify the result. You will most
+// likely want to set the `b64` protected header to false as well,
+// which spares the extra work of base64 encoding the payload.
+//
+// Only algorithms that sign a digest of the signing input
+// (HS256/384/512, RS256/384/512, PS256/384/512, ES256/384/512, ES256K and
+// ESB256/384/512) can be used. EdDSA, for example, must see the entire message and
+// is not supported. Keys implementing `jws.ContextSigner` must also see
+// the entire signing input, and cannot be used either. Note that
+// `jws.WithDetachedPayload()` cannot be used with this function.
+func SignReader(rdr io.Reader, options ...SignOption) ([]byte, error) {
+	sc, err := makeSignContext(nil, options)
+	if err != nil {
+		return nil, fmt.Errorf(`jws.SignReader: %w`, err)
+	}
+	if sc.detached {
+		return nil, fmt.Errorf(`jws.SignReader: jws.WithDetachedPayload() cannot be used`)
+	}
+	sc.detached = true
+
+	var result Message
+	result.signatures = make([]*Signature, 0, len(sc.signers))
+
+	type pending struct {
+		sig    *Signature
+		signer digestSigner
+		key    interface{}
+		hash   hash.Hash
+		b64    bool
+	}
+	list := make([]*pending, 0, len(sc.signers))
+	for i, signer := range sc.signers {
+		if _, ok := signer.key.(ContextSigner); ok {
+			return nil, fmt.Errorf(`jws.SignReader: keys implementing jws.ContextSigner cannot be used (signer #%d)`, i)
+		}
+
+		ds, ok := signer.signer.(digestSigner)
+		if !ok {
+			return nil, fmt.Errorf(`jws.SignReader: algorithm %q does not support streaming`, signer.Algorithm())
+		}
+
+		sig, err := signer.newSignature(true)
+		if err != nil {
+			return nil, fmt.Errorf(`jws.SignReader: %w`, err)
+		}
+
+		hdrs, hdrbuf, err := sig.signingHeaders(signer.signer, signer.key)
+		if err != nil {
+			return nil, fmt.Errorf(`jws.SignReader: failed to prepare headers for signer #%d (alg=%s): %w`, i, signer.Algorithm(), err)
+		}
+
+		h, err := ds.newHash(signer.key)
+		if err != nil {
+			return nil, fmt.Errorf(`jws.SignReader: failed to create hash for signer #%d (alg=%s): %w`, i, signer.Algorithm(), err)
+		}
+		fmt.Fprintf(h, `%s.`, base64.RawURLEncoding.EncodeToString(hdrbuf))
+
+		list = append(list, &pending{
+			sig:    sig,
+			signer: ds,
+			key:    signer.key,
+			hash:   h,
+			b64:    getB64Value(hdrs),
+		})
+		result.signatures = append(result.signatures, sig)
+	}
+
+	// RFC 7797 requires that all signatures use the same value for "b64"
+	writers := make([]io.Writer, 0, len(list))
+	for _, p := range list {
+		if p.b64 != list[0].b64 {
+			return nil, fmt.Errorf(`jws.SignReader: "b64" header must have the same value for all signatures`)
+		}
+		writers = append(writers, p.hash)
+	}
+
+	if err := copyPayload(io.MultiWriter(writers...), rdr, list[0].b64); err != nil {
+		return nil, fmt.Errorf(`jws.SignReader: %w`, err)
+	}
+
+	for i, p := range list {
+		signature, err := p.signer.signDigest(p.hash.Sum(nil), p.key)
+		if err != nil {
+			return nil, fmt.Errorf(`jws.SignReader: failed to generate signature for signer #%d (alg=%s): %w`, i, sc.signers[i].Algorithm(), err)
+		}
+		p.sig.signature = signature
+	}
+
+	return sc.serialize(&result)
+}
+
+// VerifyReader works like `jws.Verify()` on a JWS message with a
+// detached payload, except that the payload is read from an io.Reader
+// and hashed incrementally, without ever holding the entire payload
+// in memory. The message in `buf` may be in either compact or JSON
+// serialization, and must not contain a payload.
+//
+// The reader is consumed exactly once: every combination of
+// signature and key that is a candidate for verification is hashed
+// in a single pass, so the cost of verification grows with the number
+// of candidate keys. Specify the keys as precisely as possible.
+//
+// As with `jws.SignReader()`, only algorithms that sign a digest of the
+// signing input are supported. `jws.WithDetachedPayload()` cannot be
+// used with this function.
+func VerifyReader(buf []byte, rdr io.Reader, options ...VerifyOption) error {
+	var dst *Message
+	var keyProviders []KeyProvider
+	var keyUsed interface{}
+	var understood []string
+	policy := jwx.DefaultPolicy()
+
+	ctx := context.Background()
+
+	//nolint:forcetypeassert
+	for _, option := range options {
+		switch option.Ident() {
+		case identPolicy{}:
+			policy = option.Value().(*jwx.Policy)
+		case identCriticalHeaders{}:
+			understood = append(understood, option.Value().([]string)...)
+		case identMessage{}:
+			dst = option.Value().(*Message)
+		case identKey{}:
+			pair := option.Value().(*withKey)
+			alg, ok := pair.alg.(jwa.SignatureAlgorithm)
+			if !ok {
+				return fmt.Errorf(`WithKey() option must be specified using jwa.SignatureAlgorithm (got %T)`, pair.alg)
+			}
+			keyProviders = append(keyProviders, &staticKeyProvider{
+				alg: alg,
+				key: pair.key,
+			})
+		case identKeyProvider{}:
+			keyProviders = append(keyProviders, option.Value().(KeyProvider))
+		case identKeyUsed{}:
+			keyUsed = option.Value()
+		case identContext{}:
+			ctx = option.Value().(context.Context)
+		default:
+			return fmt.Errorf(`invalid jws.VerifyOption %q passed to jws.VerifyReader`, `With`+strings.TrimPrefix(fmt.Sprintf(`%T`, option.Ident()), `jws.ident`))
+		}
+	}
+
+	if len(keyProviders) < 1 {
+		return fmt.Errorf(`jws.VerifyReader: no key providers have been provided (see jws.WithKey(), jws.WithKeySet(), jws.WithVerifyAuto(), and jws.WithKeyProvider()`)
+	}
+
+	msg, err := Parse(buf)
+	if err != nil {
+		return fmt.Errorf(`failed to parse jws: %w`, err)
+	}
+	defer msg.clearRaw()
+
+	if len(msg.payload) != 0 {
+		return fmt.Errorf(`jws.VerifyReader: message must not contain a payload`)
+	}
+
+	type candidate struct {
+		sig      *Signature
+		verifier digestVerifier
+		key      interface{}
+		hash     hash.Hash
+	}
+
+	// policyErr and critErr are reported if no key could verify the message
+	var policyErr, critErr error
+	var candidates []*candidate
+	var writers []io.Writer
+	for i, sig := range msg.signatures {
+		if err := sig.checkCritical(understood); err != nil {
+			critErr = fmt.Errorf(`signature #%d: %w`, i+1, err)
+			continue
+		}
+
+		encodedProtectedHeader, err := sig.encodedProtectedHeader()
+		if err != nil {
+			return fmt.Errorf(`failed to marshal "protected" for signature #%d: %w`, i+1, err)
+		}
+
+		for i, kp := range keyProviders {
+			var sink algKeySink
+			if err := kp.FetchKeys(ctx, &sink, sig, msg); err != nil {
+				return fmt.Errorf(`key provider %d failed: %w`, i, err)
+			}
+
+			for _, pair := range sink.list {
+				//nolint:forcetypeassert
+				alg := pair.alg.(jwa.SignatureAlgorithm)
+				key := pair.key
+				if err := policy.CheckSignature(alg, key); err != nil {
+					policyErr = err
+					continue
+				}
+
+				verifier, err := NewVerifier(alg)
+				if err != nil {
+					return fmt.Errorf(`failed to create verifier for algorithm %q: %w`, alg, err)
+				}
+
+				dv, ok := verifier.(digestVerifier)
+				if !ok {
+					continue
+				}
+
+				h, err := dv.newHash(key)
+				if err != nil {
+					continue
+				}
+				fmt.Fprintf(h, `%s.`, encodedProtectedHeader)
+
+				candidates = append(candidates, &candidate{
+					sig:      sig,
+					verifier: dv,
+					key:      key,
+					hash:     h,
+				})
+				writers = append(writers, h)
+			}
+		}
+	}
+
+	if len(candidates) > 0 {
+		if err := copyPayload(io.MultiWriter(writers...), rdr, msg.b64); err != nil {
+			return fmt.Errorf(`jws.VerifyReader: %w`, err)
+		}
+
+		for _, c := range candidates {
+			if err := c.verifier.verifyDigest(c.hash.Sum(nil), c.sig.signature, c.key); err != nil {
+				continue
+			}
+
+			if keyUsed != nil {
+				if err := blackmagic.AssignIfCompatible(keyUsed, c.key); err != nil {
+					return fmt.Errorf(`failed to assign used key (%T) to %T: %w`, c.key, keyUsed, err)
+				}
+			}
+
+			if dst != nil {
+				*(dst) = *msg
+			}
+			return nil
+		}
+	}
+
+	if critErr != nil {
+		return fmt.Errorf(`could not verify message using any of the signatures or keys: %w`, critErr)
+	}
+	if policyErr != nil {
+		return fmt.Errorf(`could not verify message using any of the signatures or keys: %w`, policyErr)
+	}
+	return fmt.Errorf(`could not verify message using any of the signatures or keys`)
+}
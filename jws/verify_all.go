@@ -0,0 +1,298 @@
+package jws
+
+import (
+	"context"
+	"crypto"
+	"fmt"
+	"strings"
+
+	"github.com/lestrrat-go/jwx/v2"
+	"github.com/lestrrat-go/jwx/v2/internal/base64"
+	"github.com/lestrrat-go/jwx/v2/internal/pool"
+	"github.com/lestrrat-go/jwx/v2/jwa"
+	"github.com/lestrrat-go/jwx/v2/jwk"
+)
+
+// VerifyResult describes the outcome of verifying a single signature
+// in a JWS message using `jws.VerifyAll()`
+type VerifyResult struct {
+	signature *Signature
+	alg       jwa.SignatureAlgorithm
+	key       interface{}
+	keySet    int // index of the required key set that the key belongs to, or -1
+	err       error
+}
+
+// Signature returns the signature that this result is for
+func (r *VerifyResult) Signature() *Signature {
+	return r.signature
+}
+
+// Algorithm returns the algorithm that was used to verify the signature.
+// The value is only meaningful if the signature was verified
+func (r *VerifyResult) Algorithm() jwa.SignatureAlgorithm {
+	return r.alg
+}
+
+// Key returns the key that was used to verify the signature, or nil
+// if the signature could not be verified
+func (r *VerifyResult) Key() interface{} {
+	return r.key
+}
+
+// Err returns the reason why the signature could not be verified, or
+// nil if the signature was verified
+func (r *VerifyResult) Err() error {
+	return r.err
+}
+
+// Verified returns true if the signature was successfully verified
+func (r *VerifyResult) Verified() bool {
+	return r.err == nil
+}
+
+type requiredKeyProvider struct {
+	KeyProvider
+	keySet int
+}
+
+// VerifyAll attempts to verify every signature in the given JWS message,
+// and reports the outcome for each of them in the same order as they
+// appear in the message. This is mostly useful for JSON serialized
+// messages that have been co-signed by multiple parties.
+//
+// Keys are specified using the same options as `jws.Verify()`, as well as
+// `jws.WithRequiredKeySet()`. Unlike `jws.Verify()`, errors from key
+// providers do not abort the verification, and are instead reported as
+// the error for the signature being verified.
+//
+// By default all signatures must be verified for the call to succeed.
+// Use `jws.WithRequiredSignatures()` to require signatures from N
+// distinct keys instead, and `jws.WithRequiredKeySet()` to require that each of the
+// given key sets verified at least one signature.
+//
+// The results are returned even if the requirements are not met, so
+// that callers can inspect which signatures failed. The payload is
+// only returned on success.
+func VerifyAll(buf []byte, options ...VerifyAllOption) ([]byte, []*VerifyResult, error) {
+	var dst *Message
+	var detachedPayload []byte
+	var keyProviders []KeyProvider
+	var requiredKeySets []KeyProvider
+	var requiredSignatures int
+	requireAll := true
+	var understood []string
+	policy := jwx.DefaultPolicy()
+
+	ctx := context.Background()
+
+	//nolint:forcetypeassert
+	for _, option := range options {
+		switch option.Ident() {
+		case identPolicy{}:
+			policy = option.Value().(*jwx.Policy)
+		case identCriticalHeaders{}:
+			understood = append(understood, option.Value().([]string)...)
+		case identMessage{}:
+			dst = option.Value().(*Message)
+		case identDetachedPayload{}:
+			detachedPayload = option.Value().([]byte)
+		case identKey{}:
+			pair := option.Value().(*withKey)
+			alg, ok := pair.alg.(jwa.SignatureAlgorithm)
+			if !ok {
+				return nil, nil, fmt.Errorf(`WithKey() option must be specified using jwa.SignatureAlgorithm (got %T)`, pair.alg)
+			}
+			keyProviders = append(keyProviders, &staticKeyProvider{
+				alg: alg,
+				key: pair.key,
+			})
+		case identKeyProvider{}:
+			keyProviders = append(keyProviders, option.Value().(KeyProvider))
+		case identRequiredKeySet{}:
+			requiredKeySets = append(requiredKeySets, &requiredKeyProvider{
+				KeyProvider: option.Value().(KeyProvider),
+				keySet:      len(requiredKeySets),
+			})
+		case identRequiredSignatures{}:
+			requiredSignatures = option.Value().(int)
+			requireAll = false
+		case identContext{}:
+			ctx = option.Value().(context.Context)
+		default:
+			return nil, nil, fmt.Errorf(`invalid jws.VerifyAllOption %q passed`, `With`+strings.TrimPrefix(fmt.Sprintf(`%T`, option.Ident()), `jws.ident`))
+		}
+	}
+
+	// Keys from the required key sets are tried first, so that
+	// signatures are attributed to them whenever possible
+	keyProviders = append(requiredKeySets, keyProviders...)
+	if len(keyProviders) < 1 {
+		return nil, nil, fmt.Errorf(`jws.VerifyAll: no key providers have been provided (see jws.WithKey(), jws.WithKeySet(), jws.WithRequiredKeySet(), and jws.WithKeyProvider()`)
+	}
+
+	msg, err := Parse(buf)
+	if err != nil {
+		return nil, nil, fmt.Errorf(`failed to parse jws: %w`, err)
+	}
+	defer msg.clearRaw()
+
+	if detachedPayload != nil {
+		if len(msg.payload) != 0 {
+			return nil, nil, fmt.Errorf(`can't specify detached payload for JWS with payload`)
+		}
+
+		msg.payload = detachedPayload
+	}
+
+	// Pre-compute the base64 encoded version of payload
+	var payload string
+	if msg.b64 {
+		payload = base64.EncodeToString(msg.payload)
+	} else {
+		payload = string(msg.payload)
+	}
+
+	verifyBuf := pool.GetBytesBuffer()
+	defer pool.ReleaseBytesBuffer(verifyBuf)
+
+	results := make([]*VerifyResult, len(msg.signatures))
+	for i, sig := range msg.signatures {
+		result := &VerifyResult{
+			signature: sig,
+			keySet:    -1,
+		}
+		results[i] = result
+
+		if err := sig.checkCritical(understood); err != nil {
+			result.err = err
+			continue
+		}
+
+		encodedProtectedHeader, err := sig.encodedProtectedHeader()
+		if err != nil {
+			result.err = fmt.Errorf(`failed to marshal "protected" for signature #%d: %w`, i+1, err)
+			continue
+		}
+
+		verifyBuf.Reset()
+		verifyBuf.WriteString(encodedProtectedHeader)
+		verifyBuf.WriteByte('.')
+		verifyBuf.WriteString(payload)
+
+		result.err = verifySignature(ctx, result, verifyBuf.Bytes(), keyProviders, policy, msg)
+	}
+
+	if dst != nil {
+		*(dst) = *msg
+	}
+
+	// The threshold is applied to the number of distinct keys that verified
+	// a signature, so that a signature that has been duplicated in the
+	// message can not be used to meet the threshold
+	var verified int
+	signers := make(map[string]struct{})
+	satisfied := make([]bool, len(requiredKeySets))
+	for _, result := range results {
+		if !result.Verified() {
+			continue
+		}
+		verified++
+		if result.keySet >= 0 {
+			satisfied[result.keySet] = true
+		}
+		// keys that can not be identified are not counted (fail closed)
+		if id, err := keyIdentity(result.key); err == nil {
+			signers[id] = struct{}{}
+		}
+	}
+
+	if verified == 0 {
+		return nil, results, fmt.Errorf(`could not verify message using any of the signatures or keys`)
+	}
+
+	if requireAll {
+		if verified < len(results) {
+			return nil, results, fmt.Errorf(`only %d out of %d signatures were verified`, verified, len(results))
+		}
+	} else if len(signers) < requiredSignatures {
+		return nil, results, fmt.Errorf(`signatures from only %d distinct keys were verified (%d required)`, len(signers), requiredSignatures)
+	}
+
+	for i, ok := range satisfied {
+		if !ok {
+			return nil, results, fmt.Errorf(`no signature was verified using a key from required key set #%d`, i+1)
+		}
+	}
+
+	return msg.payload, results, nil
+}
+
+// keyIdentity returns a value that identifies the given verification key,
+// computed as the JWK thumbprint of its public key
+func keyIdentity(key interface{}) (string, error) {
+	jwkKey, ok := key.(jwk.Key)
+	if !ok {
+		v, err := jwk.FromRaw(key)
+		if err != nil {
+			return "", fmt.Errorf(`failed to convert %T to jwk.Key: %w`, key, err)
+		}
+		jwkKey = v
+	}
+	pubkey, err := jwk.PublicKeyOf(jwkKey)
+	if err != nil {
+		return "", fmt.Errorf(`failed to retrieve public key: %w`, err)
+	}
+	tp, err := pubkey.Thumbprint(crypto.SHA256)
+	if err != nil {
+		return "", fmt.Errorf(`failed to compute thumbprint: %w`, err)
+	}
+	return string(tp), nil
+}
+
+// verifySignature attempts to verify a single signature using the keys
+// from the key providers, and populates the result upon success.
+func verifySignature(ctx context.Context, result *VerifyResult, verifyBuf []byte, keyProviders []KeyProvider, policy *jwx.Policy, msg *Message) error {
+	// lastErr is reported if no key could verify the signature
+	var lastErr error
+	for i, kp := range keyProviders {
+		var sink algKeySink
+		if err := kp.FetchKeys(ctx, &sink, result.signature, msg); err != nil {
+			lastErr = fmt.Errorf(`key provider %d failed: %w`, i, err)
+			continue
+		}
+
+		for _, pair := range sink.list {
+			//nolint:forcetypeassert
+			alg := pair.alg.(jwa.SignatureAlgorithm)
+			key := pair.key
+			if err := policy.CheckSignature(alg, key); err != nil {
+				lastErr = err
+				continue
+			}
+
+			verifier, err := NewVerifier(alg)
+			if err != nil {
+				lastErr = fmt.Errorf(`failed to create verifier for algorithm %q: %w`, alg, err)
+				continue
+			}
+
+			if err := verifier.Verify(verifyBuf, result.signature.signature, key); err != nil {
+				lastErr = fmt.Errorf(`failed to verify signature using %q: %w`, alg, err)
+				continue
+			}
+
+			result.alg = alg
+			result.key = key
+			if rkp, ok := kp.(*requiredKeyProvider); ok {
+				result.keySet = rkp.keySet
+			}
+			return nil
+		}
+	}
+
+	if lastErr != nil {
+		return fmt.Errorf(`could not verify signature: %w`, lastErr)
+	}
+	return fmt.Errorf(`could not verify signature: no keys available`)
+}
@@ -13,6 +13,8 @@ import (
 
 	"github.com/lestrrat-go/jwx/v2"
 	"github.com/lestrrat-go/jwx/v2/internal/json"
+	"github.com/lestrrat-go/jwx/v2/jwa"
+	"github.com/lestrrat-go/jwx/v2/jwe"
 	"github.com/lestrrat-go/jwx/v2/jws"
 	"github.com/lestrrat-go/jwx/v2/jwt/internal/types"
 )
@@ -101,9 +103,15 @@ func ParseString(s string, options ...ParseOption) (Token, error) {
 // Parse parses the JWT token payload and creates a new `jwt.Token` object.
 // The token must be encoded in either JSON format or compact format.
 //
-// This function can only work with either raw JWT (JSON) and JWS (Compact or JSON).
-// If you need JWE support on top of it, you will need to rollout your
-// own workaround.
+// This function can work with raw JWT (JSON), JWS (Compact or JSON), and
+// JWE (Compact or JSON) enveloped tokens, including nested tokens
+// that have been signed, then encrypted.
+//
+// If the token is encrypted, you must pass the keys required to decrypt
+// it by using either `jwt.WithKey()` with a `jwa.KeyEncryptionAlgorithm`,
+// `jwt.WithDecrypt()`, or `jwt.WithKeyDecryptionProvider()`. JWE layers are
+// decrypted first, and then the signature of the JWS layer (if any)
+// is verified.
 //
 // If the token is signed and you want to verify the payload matches the signature,
 // you must pass the jwt.WithKey(alg, key) or jwt.WithKeySet(jwk.Set) option.
@@ -159,6 +167,7 @@ type parseCtx struct {
 	token            Token
 	validateOpts     []ValidateOption
 	verifyOpts       []jws.VerifyOption
+	decryptOpts      []jwe.DecryptOption
 	localReg         *json.Registry
 	pedantic         bool
 	skipVerification bool
@@ -178,6 +187,10 @@ func parseBytes(data []byte, options ...ParseOption) (Token, error) {
 	verification := true
 
 	var verifyOpts []Option
+	var decryptOpts []Option
+	var policy *jwx.Policy
+	var hasPolicy bool
+	var critical []string
 	for _, o := range options {
 		if v, ok := o.(ValidateOption); ok {
 			ctx.validateOpts = append(ctx.validateOpts, v)
@@ -186,8 +199,23 @@ func parseBytes(data []byte, options ...ParseOption) (Token, error) {
 
 		//nolint:forcetypeassert
 		switch o.Ident() {
-		case identKey{}, identKeySet{}, identVerifyAuto{}, identKeyProvider{}:
+		case identKey{}:
+			// keys for key encryption algorithms are used to decrypt
+			// JWE envelopes. Everything else is handed over to jws.Verify
+			if _, ok := o.Value().(*withKey).alg.(jwa.KeyEncryptionAlgorithm); ok {
+				decryptOpts = append(decryptOpts, o)
+			} else {
+				verifyOpts = append(verifyOpts, o)
+			}
+		case identKeySet{}, identVerifyAuto{}, identKeyProvider{}, identX5CKeyProvider{}:
 			verifyOpts = append(verifyOpts, o)
+		case identDecrypt{}, identKeyDecryptionProvider{}:
+			decryptOpts = append(decryptOpts, o)
+		case identPolicy{}:
+			policy = o.Value().(*jwx.Policy)
+			hasPolicy = true
+		case identCriticalHeaders{}:
+			critical = append(critical, o.Value().([]string)...)
 		case identToken{}:
 			token, ok := o.Value().(Token)
 			if !ok {
@@ -219,9 +247,26 @@ func parseBytes(data []byte, options ...ParseOption) (Token, error) {
 		if err != nil {
 			return nil, fmt.Errorf(`jwt.Parse: failed to convert options into jws.VerifyOption: %w`, err)
 		}
+		if hasPolicy {
+			converted = append(converted, jws.WithPolicy(policy))
+		}
+		if len(critical) > 0 {
+			converted = append(converted, jws.WithCriticalHeaders(critical...))
+		}
 		ctx.verifyOpts = converted
 	}
 
+	if len(decryptOpts) > 0 {
+		converted, err := toDecryptOptions(decryptOpts...)
+		if err != nil {
+			return nil, fmt.Errorf(`jwt.Parse: failed to convert options into jwe.DecryptOption: %w`, err)
+		}
+		if hasPolicy {
+			converted = append(converted, jwe.WithPolicy(policy))
+		}
+		ctx.decryptOpts = converted
+	}
+
 	data = bytes.TrimSpace(data)
 	return parse(&ctx, data)
 }
@@ -253,6 +298,11 @@ func parse(ctx *parseCtx, data []byte) (Token, error) {
 	// If cty = `JWT`, we expect this to be a nested structure
 	var expectNested bool
 
+	// verified is set to true once a JWS envelope has been verified.
+	// When we reach the raw JWT without having verified anything, we
+	// still need to attempt verification (which will fail, as intended)
+	var verified bool
+
 OUTER:
 	for i := 0; i < maxDecodeLevels; i++ {
 		switch kind := jwx.GuessFormat(payload); kind {
@@ -263,8 +313,8 @@ OUTER:
 				}
 			}
 
-			if i == 0 {
-				// We were NOT enveloped in other formats
+			if !verified {
+				// We were NOT enveloped in a JWS message
 				if !ctx.skipVerification {
 					if _, _, err := verifyJWS(ctx, payload); err != nil {
 						return nil, err
@@ -282,8 +332,8 @@ OUTER:
 				return nil, fmt.Errorf(`unknown JWT format (pedantic)`)
 			}
 
-			if i == 0 {
-				// We were NOT enveloped in other formats
+			if !verified {
+				// We were NOT enveloped in a JWS message
 				if !ctx.skipVerification {
 					if _, _, err := verifyJWS(ctx, payload); err != nil {
 						return nil, err
@@ -309,6 +359,7 @@ OUTER:
 
 				if state != _JwsVerifySkipped {
 					payload = v
+					verified = true
 
 					// We only check for cty and typ if the pedantic flag is enabled
 					if !ctx.pedantic {
@@ -326,11 +377,31 @@ OUTER:
 			}
 
 			// No verification.
-			m, err := jws.Parse(data)
+			m, err := jws.Parse(payload)
 			if err != nil {
 				return nil, fmt.Errorf(`invalid jws message: %w`, err)
 			}
 			payload = m.Payload()
+		case jwx.JWE:
+			if len(ctx.decryptOpts) == 0 {
+				return nil, fmt.Errorf(`jwt.Parse: token is encrypted, but no decryption keys have been provided (see jwt.WithKey(), jwt.WithDecrypt(), and jwt.WithKeyDecryptionProvider())`)
+			}
+
+			var m jwe.Message
+			decryptOpts := append(append([]jwe.DecryptOption(nil), ctx.decryptOpts...), jwe.WithMessage(&m))
+			decrypted, err := jwe.Decrypt(payload, decryptOpts...)
+			if err != nil {
+				return nil, fmt.Errorf(`jwt.Parse: failed to decrypt token (layer: #%d): %w`, i+1, err)
+			}
+			payload = decrypted
+
+			// If cty = `JWT`, the decrypted payload is expected to be
+			// a nested JWS (or JWE) message.
+			// https://datatracker.ietf.org/doc/html/rfc7519#section-5.2
+			if cty := m.ProtectedHeaders().ContentType(); cty == `JWT` {
+				expectNested = true
+				continue OUTER
+			}
 		default:
 			return nil, fmt.Errorf(`unsupported format (layer: #%d)`, i+1)
 		}
@@ -1530,6 +1530,20 @@ func TestSerializer(t *testing.T) {
 			return
 		}
 	})
+	t.Run(`WithEncryptOption`, func(t *testing.T) {
+		key := []byte("abracadabra-abracadabra-abracada")
+		serialized, err := jwt.NewSerializer().
+			Encrypt(
+				jwt.WithKey(jwa.A256KW, key),
+				jwt.WithEncryptOption(jwe.WithContentEncryption(jwa.A128CBC_HS256)),
+			).
+			Serialize(jwt.New())
+		require.NoError(t, err, `Serialize() should succeed`)
+
+		msg, err := jwe.Parse(serialized)
+		require.NoError(t, err, `jwe.Parse should succeed`)
+		require.Equal(t, jwa.A128CBC_HS256, msg.ProtectedHeaders().ContentEncryption(), `"enc" should match`)
+	})
 }
 
 func TestFractional(t *testing.T) {
@@ -1693,3 +1707,116 @@ func TestGH888(t *testing.T) {
 		require.Error(t, err, `jwt.Parse with alg=none should fail`)
 	})
 }
+
+func TestParseEncrypted(t *testing.T) {
+	tok, err := jwt.NewBuilder().
+		Issuer(`github.com/lestrrat-go/jwx`).
+		Subject(`encrypted`).
+		Build()
+	require.NoError(t, err, `jwt.NewBuilder should succeed`)
+
+	encKey, err := jwxtest.GenerateRsaKey()
+	require.NoError(t, err, `jwxtest.GenerateRsaKey should succeed`)
+
+	signKey, err := jwxtest.GenerateRsaKey()
+	require.NoError(t, err, `jwxtest.GenerateRsaKey should succeed`)
+
+	t.Run(`Encrypt only`, func(t *testing.T) {
+		serialized, err := jwt.NewSerializer().
+			Encrypt(jwt.WithKey(jwa.RSA_OAEP, encKey.PublicKey)).
+			Serialize(tok)
+		require.NoError(t, err, `Serialize should succeed`)
+
+		_, err = jwt.Parse(serialized, jwt.WithVerify(false))
+		require.Error(t, err, `jwt.Parse without decryption keys should fail`)
+
+		parsed, err := jwt.Parse(serialized, jwt.WithVerify(false), jwt.WithKey(jwa.RSA_OAEP, encKey))
+		require.NoError(t, err, `jwt.Parse should succeed`)
+		require.Equal(t, `encrypted`, parsed.Subject(), `subject should match`)
+
+		parsed, err = jwt.Parse(serialized, jwt.WithVerify(false), jwt.WithDecrypt(jwe.WithKey(jwa.RSA_OAEP, encKey)))
+		require.NoError(t, err, `jwt.Parse should succeed`)
+		require.Equal(t, `encrypted`, parsed.Subject(), `subject should match`)
+
+		_, err = jwt.Parse(serialized, jwt.WithKey(jwa.RS256, signKey.PublicKey), jwt.WithKey(jwa.RSA_OAEP, encKey))
+		require.Error(t, err, `jwt.Parse should fail when a signature is expected, but was not found`)
+	})
+	t.Run(`Sign, then encrypt`, func(t *testing.T) {
+		serialized, err := jwt.NewSerializer().
+			Sign(jwt.WithKey(jwa.RS256, signKey)).
+			Encrypt(jwt.WithKey(jwa.RSA_OAEP, encKey.PublicKey)).
+			Serialize(tok)
+		require.NoError(t, err, `Serialize should succeed`)
+
+		parsed, err := jwt.Parse(serialized,
+			jwt.WithKey(jwa.RS256, signKey.PublicKey),
+			jwt.WithKey(jwa.RSA_OAEP, encKey),
+			jwt.WithPedantic(true),
+		)
+		require.NoError(t, err, `jwt.Parse should succeed`)
+		require.Equal(t, `encrypted`, parsed.Subject(), `subject should match`)
+
+		kp := jwe.KeyProviderFunc(func(_ context.Context, sink jwe.KeySink, _ jwe.Recipient, _ *jwe.Message) error {
+			sink.Key(jwa.RSA_OAEP, encKey)
+			return nil
+		})
+		parsed, err = jwt.Parse(serialized,
+			jwt.WithKey(jwa.RS256, signKey.PublicKey),
+			jwt.WithKeyDecryptionProvider(kp),
+		)
+		require.NoError(t, err, `jwt.Parse should succeed`)
+		require.Equal(t, `encrypted`, parsed.Subject(), `subject should match`)
+
+		wrongKey, err := jwxtest.GenerateRsaKey()
+		require.NoError(t, err, `jwxtest.GenerateRsaKey should succeed`)
+		_, err = jwt.Parse(serialized,
+			jwt.WithKey(jwa.RS256, wrongKey.PublicKey),
+			jwt.WithKey(jwa.RSA_OAEP, encKey),
+		)
+		require.Error(t, err, `jwt.Parse should fail with the wrong verification key`)
+
+		_, err = jwt.Parse(serialized,
+			jwt.WithKey(jwa.RS256, signKey.PublicKey),
+			jwt.WithKey(jwa.RSA_OAEP, wrongKey),
+		)
+		require.Error(t, err, `jwt.Parse should fail with the wrong decryption key`)
+	})
+	t.Run(`Encrypt, then sign`, func(t *testing.T) {
+		serialized, err := jwt.NewSerializer().
+			Encrypt(jwt.WithKey(jwa.RSA_OAEP, encKey.PublicKey)).
+			Sign(jwt.WithKey(jwa.RS256, signKey)).
+			Serialize(tok)
+		require.NoError(t, err, `Serialize should succeed`)
+
+		parsed, err := jwt.Parse(serialized,
+			jwt.WithKey(jwa.RS256, signKey.PublicKey),
+			jwt.WithKey(jwa.RSA_OAEP, encKey),
+		)
+		require.NoError(t, err, `jwt.Parse should succeed`)
+		require.Equal(t, `encrypted`, parsed.Subject(), `subject should match`)
+	})
+}
+
+func TestParseCriticalHeaders(t *testing.T) {
+	key, err := jwxtest.GenerateRsaKey()
+	require.NoError(t, err, `jwxtest.GenerateRsaKey should succeed`)
+
+	tok := jwt.New()
+	require.NoError(t, tok.Set(jwt.SubjectKey, `critical`), `tok.Set should succeed`)
+
+	hdrs := jws.NewHeaders()
+	require.NoError(t, hdrs.Set(`x-jwt-test`, `value`), `hdrs.Set should succeed`)
+	require.NoError(t, hdrs.Set(jws.CriticalKey, []string{`x-jwt-test`}), `hdrs.Set should succeed`)
+	signed, err := jwt.Sign(tok, jwt.WithKey(jwa.RS256, key, jws.WithProtectedHeaders(hdrs)))
+	require.NoError(t, err, `jwt.Sign should succeed`)
+
+	_, err = jwt.Parse(signed, jwt.WithKey(jwa.RS256, key.PublicKey))
+	require.Error(t, err, `jwt.Parse should fail when the critical header is not understood`)
+
+	_, err = jwt.Parse(signed, jwt.WithCriticalHeaders(`x-jwt-test`))
+	require.Error(t, err, `jwt.WithCriticalHeaders should not count as a verification key`)
+
+	parsed, err := jwt.Parse(signed, jwt.WithKey(jwa.RS256, key.PublicKey), jwt.WithCriticalHeaders(`x-jwt-test`))
+	require.NoError(t, err, `jwt.Parse should succeed`)
+	require.Equal(t, `critical`, parsed.Subject(), `subject should match`)
+}
@@ -0,0 +1,51 @@
+//go:build go1.27
+// +build go1.27
+
+package jwt_test
+
+import (
+	"crypto/mldsa"
+	"testing"
+
+	"github.com/lestrrat-go/jwx/v2/jwa"
+	"github.com/lestrrat-go/jwx/v2/jwk"
+	"github.com/lestrrat-go/jwx/v2/jwt"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMLDSA(t *testing.T) {
+	t.Parallel()
+
+	raw, err := mldsa.GenerateKey(mldsa.MLDSA65())
+	require.NoError(t, err, `mldsa.GenerateKey should succeed`)
+
+	key, err := jwk.FromRaw(raw)
+	require.NoError(t, err, `jwk.FromRaw should succeed`)
+
+	pubkey, err := jwk.PublicKeyOf(key)
+	require.NoError(t, err, `jwk.PublicKeyOf should succeed`)
+
+	t1 := jwt.New()
+	require.NoError(t, t1.Set(jwt.SubjectKey, `jwx`), `t1.Set should succeed`)
+
+	signed, err := jwt.Sign(t1, jwt.WithKey(jwa.MLDSA65, key))
+	require.NoError(t, err, `jwt.Sign should succeed`)
+
+	t.Run("jwk.Key", func(t *testing.T) {
+		t.Parallel()
+		t2, err := jwt.Parse(signed, jwt.WithKey(jwa.MLDSA65, pubkey))
+		require.NoError(t, err, `jwt.Parse should succeed`)
+		require.Equal(t, `jwx`, t2.Subject(), `subject should match`)
+	})
+	t.Run("raw key", func(t *testing.T) {
+		t.Parallel()
+		t2, err := jwt.Parse(signed, jwt.WithKey(jwa.MLDSA65, raw.PublicKey()))
+		require.NoError(t, err, `jwt.Parse should succeed`)
+		require.Equal(t, `jwx`, t2.Subject(), `subject should match`)
+	})
+	t.Run("wrong algorithm", func(t *testing.T) {
+		t.Parallel()
+		_, err := jwt.Parse(signed, jwt.WithKey(jwa.MLDSA44, pubkey))
+		require.Error(t, err, `jwt.Parse should fail`)
+	})
+}
@@ -1,9 +1,11 @@
 package jwt
 
 import (
+	"crypto/x509"
 	"fmt"
 	"time"
 
+	"github.com/lestrrat-go/jwx/v2"
 	"github.com/lestrrat-go/jwx/v2/jwa"
 	"github.com/lestrrat-go/jwx/v2/jwe"
 	"github.com/lestrrat-go/jwx/v2/jwk"
@@ -11,11 +13,14 @@ import (
 	"github.com/lestrrat-go/option"
 )
 
+type identCriticalHeaders struct{}
+type identDecrypt struct{}
 type identInsecureNoSignature struct{}
 type identKey struct{}
 type identKeySet struct{}
 type identTypedClaim struct{}
 type identVerifyAuto struct{}
+type identX5CKeyProvider struct{}
 
 func toSignOptions(options ...Option) ([]jws.SignOption, error) {
 	var soptions []jws.SignOption
@@ -36,6 +41,10 @@ func toSignOptions(options ...Option) ([]jws.SignOption, error) {
 			}
 
 			soptions = append(soptions, jws.WithKey(wk.alg, wk.key, wksoptions...))
+		case identSignOption{}:
+			soptions = append(soptions, option.Value().(jws.SignOption))
+		case identPolicy{}:
+			soptions = append(soptions, jws.WithPolicy(option.Value().(*jwx.Policy)))
 		}
 	}
 	return soptions, nil
@@ -58,6 +67,10 @@ func toEncryptOptions(options ...Option) ([]jwe.EncryptOption, error) {
 			}
 
 			soptions = append(soptions, jwe.WithKey(wk.alg, wk.key, wksoptions...))
+		case identEncryptOption{}:
+			soptions = append(soptions, option.Value().(jwe.EncryptOption))
+		case identPolicy{}:
+			soptions = append(soptions, jwe.WithPolicy(option.Value().(*jwx.Policy)))
 		}
 	}
 	return soptions, nil
@@ -92,8 +105,8 @@ func toVerifyOptions(options ...Option) ([]jws.VerifyOption, error) {
 			}
 
 			voptions = append(voptions, jws.WithKeySet(wks.set, wkssoptions...))
-		case identVerifyAuto{}:
-			// this one doesn't need conversion. just get the stored option
+		case identVerifyAuto{}, identX5CKeyProvider{}:
+			// these don't need conversion. just get the stored option
 			voptions = append(voptions, option.Value().(jws.VerifyOption))
 		case identKeyProvider{}:
 			kp, ok := option.Value().(jws.KeyProvider)
@@ -106,6 +119,37 @@ func toVerifyOptions(options ...Option) ([]jws.VerifyOption, error) {
 	return voptions, nil
 }
 
+func toDecryptOptions(options ...Option) ([]jwe.DecryptOption, error) {
+	var doptions []jwe.DecryptOption
+	for _, option := range options {
+		//nolint:forcetypeassert
+		switch option.Ident() {
+		case identKey{}:
+			wk := option.Value().(*withKey) // this always succeeds
+			var wksoptions []jwe.WithKeySuboption
+			for _, subopt := range wk.options {
+				wksopt, ok := subopt.(jwe.WithKeySuboption)
+				if !ok {
+					return nil, fmt.Errorf(`expected optional arguments in jwt.WithKey to be jwe.WithKeySuboption, but got %T`, subopt)
+				}
+				wksoptions = append(wksoptions, wksopt)
+			}
+
+			doptions = append(doptions, jwe.WithKey(wk.alg, wk.key, wksoptions...))
+		case identDecrypt{}:
+			// this one doesn't need conversion. just get the stored options
+			doptions = append(doptions, option.Value().([]jwe.DecryptOption)...)
+		case identKeyDecryptionProvider{}:
+			kp, ok := option.Value().(jwe.KeyProvider)
+			if !ok {
+				return nil, fmt.Errorf(`expected jwe.KeyProvider, got %T`, option.Value())
+			}
+			doptions = append(doptions, jwe.WithKeyProvider(kp))
+		}
+	}
+	return doptions, nil
+}
+
 type withKey struct {
 	alg     jwa.KeyAlgorithm
 	key     interface{}
@@ -123,6 +167,12 @@ type withKey struct {
 // In the above example, the creation of the option via `jwt.WithKey()` will work, but
 // when `jwt.Sign()` is called, the fact that you passed JWE suboptions will be
 // detected, and it will be an error.
+//
+// When used with `jwt.Parse()`, the type of `alg` determines how the key is used:
+// a `jwa.SignatureAlgorithm` is used to verify JWS envelopes, and a
+// `jwa.KeyEncryptionAlgorithm` is used to decrypt JWE envelopes.
+// You may specify both kinds of keys to parse nested (e.g. signed, then
+// encrypted) tokens.
 func WithKey(alg jwa.KeyAlgorithm, key interface{}, suboptions ...Option) SignEncryptParseOption {
 	return &signEncryptParseOption{option.New(identKey{}, &withKey{
 		alg:     alg,
@@ -298,6 +348,46 @@ func WithVerifyAuto(f jwk.Fetcher, options ...jwk.FetchOption) ParseOption {
 	return &parseOption{option.New(identVerifyAuto{}, jws.WithVerifyAuto(f, options...))}
 }
 
+// WithX5CKeyProvider specifies that the token should be verified using
+// the certificate chain in the `x5c` field of the protected header.
+// The chain is verified against `roots` using `opts` before the public
+// key of the leaf certificate is used for verification.
+//
+// See `jws.WithX5CKeyProvider()` for details.
+func WithX5CKeyProvider(roots *x509.CertPool, opts x509.VerifyOptions) ParseOption {
+	return &parseOption{option.New(identX5CKeyProvider{}, jws.WithX5CKeyProvider(roots, opts))}
+}
+
+// WithCriticalHeaders specifies the names of header parameters that are
+// understood by the caller when verifying the JWS envelope of a token.
+// The names are passed to `jws.Verify()` via `jws.WithCriticalHeaders()`.
+//
+// To declare names for the JWE envelope of an encrypted token, use
+// `jwt.WithDecrypt(jwe.WithCriticalHeaders(...))` instead.
+func WithCriticalHeaders(names ...string) ParseOption {
+	return &parseOption{option.New(identCriticalHeaders{}, names)}
+}
+
+// WithDecrypt specifies options that are passed to `jwe.Decrypt()` when
+// `jwt.Parse()` encounters a JWE enveloped token. By specifying this option,
+// tokens that have been serialized using `(jwt.Serializer).Encrypt()`
+// (including nested tokens that have been signed, then encrypted) can
+// be parsed directly using `jwt.Parse()`.
+//
+//	jwt.Parse(data,
+//	  jwt.WithDecrypt(jwe.WithKey(jwa.RSA_OAEP, privkey)),
+//	  jwt.WithKey(jwa.RS256, pubkey),
+//	)
+//
+// Note that decryption alone does not verify the authenticity of the sender.
+// If the token is only encrypted (i.e. there is no JWS envelope), you must
+// explicitly disable verification using `jwt.WithVerify(false)`.
+//
+// If the token is not enveloped in JWE, this option has no effect.
+func WithDecrypt(options ...jwe.DecryptOption) ParseOption {
+	return &parseOption{option.New(identDecrypt{}, options)}
+}
+
 func WithInsecureNoSignature() SignOption {
 	return &signEncryptParseOption{option.New(identInsecureNoSignature{}, nil)}
 }
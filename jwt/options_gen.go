@@ -7,6 +7,7 @@ import (
 	"io/fs"
 	"time"
 
+	"github.com/lestrrat-go/jwx/v2"
 	"github.com/lestrrat-go/jwx/v2/jwe"
 	"github.com/lestrrat-go/jwx/v2/jws"
 	"github.com/lestrrat-go/option"
@@ -130,11 +131,13 @@ type identFS struct{}
 type identFlattenAudience struct{}
 type identFormKey struct{}
 type identHeaderKey struct{}
+type identKeyDecryptionProvider struct{}
 type identKeyProvider struct{}
 type identNumericDateFormatPrecision struct{}
 type identNumericDateParsePedantic struct{}
 type identNumericDateParsePrecision struct{}
 type identPedantic struct{}
+type identPolicy struct{}
 type identSignOption struct{}
 type identToken struct{}
 type identTruncation struct{}
@@ -174,6 +177,10 @@ func (identHeaderKey) String() string {
 	return "WithHeaderKey"
 }
 
+func (identKeyDecryptionProvider) String() string {
+	return "WithKeyDecryptionProvider"
+}
+
 func (identKeyProvider) String() string {
 	return "WithKeyProvider"
 }
@@ -194,6 +201,10 @@ func (identPedantic) String() string {
 	return "WithPedantic"
 }
 
+func (identPolicy) String() string {
+	return "WithPolicy"
+}
+
 func (identSignOption) String() string {
 	return "WithSignOption"
 }
@@ -278,6 +289,16 @@ func WithHeaderKey(v string) ParseOption {
 	return &parseOption{option.New(identHeaderKey{}, v)}
 }
 
+// WithKeyDecryptionProvider allows users to specify an object to provide keys to
+// decrypt JWE enveloped tokens using arbitrary code. Please read the documentation
+// for `jwe.KeyProvider` in the `jwe` package for details on how this works.
+//
+// This option is the JWE counterpart of `jwt.WithKeyProvider()`. Passing it to
+// `jwt.Parse()` enables the decryption of JWE layers found in the token.
+func WithKeyDecryptionProvider(v jwe.KeyProvider) ParseOption {
+	return &parseOption{option.New(identKeyDecryptionProvider{}, v)}
+}
+
 // WithKeyProvider allows users to specify an object to provide keys to
 // sign/verify tokens using arbitrary code. Please read the documentation
 // for `jws.KeyProvider` in the `jws` package for details on how this works.
@@ -318,6 +339,14 @@ func WithPedantic(v bool) ParseOption {
 	return &parseOption{option.New(identPedantic{}, v)}
 }
 
+// WithPolicy specifies the `jwx.Policy` to enforce when signing,
+// encrypting, or parsing tokens. It is passed down to `jws.Sign()`,
+// `jws.Verify()`, `jwe.Encrypt()`, and `jwe.Decrypt()` via
+// `jws.WithPolicy()` and `jwe.WithPolicy()`, respectively.
+func WithPolicy(v *jwx.Policy) SignEncryptParseOption {
+	return &signEncryptParseOption{option.New(identPolicy{}, v)}
+}
+
 // WithSignOption provides an escape hatch for cases where extra options to
 // `jws.Sign()` must be specified when usng `jwt.Sign()`. Normally you do not
 // need to use this.
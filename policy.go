@@ -0,0 +1,380 @@
+package jwx
+
+import (
+	"crypto"
+	"crypto/ecdh"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"fmt"
+	"sync"
+
+	"github.com/cloudflare/circl/sign/ed448"
+	"github.com/lestrrat-go/jwx/v2/internal/ecutil"
+	"github.com/lestrrat-go/jwx/v2/jwa"
+	"github.com/lestrrat-go/jwx/v2/jwk"
+	"github.com/lestrrat-go/jwx/v2/x25519"
+	"github.com/lestrrat-go/jwx/v2/x448"
+)
+
+// Policy describes the algorithms and key strengths that are acceptable
+// when signing, verifying, encrypting, and decrypting messages.
+//
+// A policy can be installed globally using `jwx.SetDefaultPolicy()`, or
+// for a single call using `jws.WithPolicy()`, `jwe.WithPolicy()`, or
+// `jwt.WithPolicy()`. When a policy is violated, the error returned
+// by these functions can be inspected using `errors.Is()` against
+// `jwx.ErrPolicyViolation()` and the other error values in this package.
+//
+// Fields that are left as their zero values are not enforced, so the
+// zero value of Policy (as well as a nil *Policy) accepts everything.
+// If MinRSAKeySize or Curves is set, asymmetric keys whose size or curve
+// can not be determined (for example opaque signers that do not implement
+// `Public() crypto.PublicKey`) are rejected.
+// A Policy should not be modified once it has been put into use.
+type Policy struct {
+	// SignatureAlgorithms is the list of signature algorithms that
+	// may be used. If empty, all signature algorithms are allowed.
+	SignatureAlgorithms []jwa.SignatureAlgorithm
+
+	// KeyEncryptionAlgorithms is the list of key encryption algorithms
+	// that may be used. If empty, all key encryption algorithms are allowed.
+	KeyEncryptionAlgorithms []jwa.KeyEncryptionAlgorithm
+
+	// ContentEncryptionAlgorithms is the list of content encryption
+	// algorithms that may be used. If empty, all content encryption
+	// algorithms are allowed.
+	ContentEncryptionAlgorithms []jwa.ContentEncryptionAlgorithm
+
+	// Curves is the list of curves that may be used by EC and OKP keys.
+	// If empty, all curves are allowed.
+	Curves []jwa.EllipticCurveAlgorithm
+
+	// MinRSAKeySize is the minimum size of RSA keys, in bits.
+	MinRSAKeySize int
+
+	// MinHMACKeySize is the minimum size of keys used for HMAC
+	// signatures (HS256, HS384, and HS512), in bytes.
+	MinHMACKeySize int
+}
+
+var muDefaultPolicy sync.RWMutex
+var defaultPolicy *Policy
+
+// SetDefaultPolicy installs the policy that is used by the jws, jwe, and
+// jwt packages when no policy is explicitly specified via options.
+// Passing nil removes the default policy.
+func SetDefaultPolicy(p *Policy) {
+	muDefaultPolicy.Lock()
+	defer muDefaultPolicy.Unlock()
+	defaultPolicy = p
+}
+
+// DefaultPolicy returns the policy installed via `jwx.SetDefaultPolicy()`.
+// nil is returned if no policy has been installed.
+func DefaultPolicy() *Policy {
+	muDefaultPolicy.RLock()
+	defer muDefaultPolicy.RUnlock()
+	return defaultPolicy
+}
+
+// CheckSignature returns an error if the given signature algorithm or
+// key may not be used under this policy. key may be a raw key or a
+// jwk.Key, and may be nil if only the algorithm should be checked.
+func (p *Policy) CheckSignature(alg jwa.SignatureAlgorithm, key interface{}) error {
+	if p == nil {
+		return nil
+	}
+
+	if !p.allowsSignatureAlgorithm(alg) {
+		return newPolicyError(errAlgorithmNotAllowed, fmt.Errorf(`signature algorithm %q is not allowed`, alg))
+	}
+
+	switch alg {
+	case jwa.HS256, jwa.HS384, jwa.HS512:
+		if octets, ok := rawKeyOf(key).([]byte); ok && len(octets) < p.MinHMACKeySize {
+			return newPolicyError(errKeyTooSmall, fmt.Errorf(`HMAC key size %d is smaller than the required %d bytes`, len(octets), p.MinHMACKeySize))
+		}
+		return nil
+	}
+	return p.checkKey(key)
+}
+
+// CheckKeyEncryption returns an error if the given key encryption
+// algorithm or key may not be used under this policy. key may be a raw
+// key or a jwk.Key, and may be nil if only the algorithm should be checked.
+func (p *Policy) CheckKeyEncryption(alg jwa.KeyEncryptionAlgorithm, key interface{}) error {
+	if p == nil {
+		return nil
+	}
+
+	if !p.allowsKeyEncryptionAlgorithm(alg) {
+		return newPolicyError(errAlgorithmNotAllowed, fmt.Errorf(`key encryption algorithm %q is not allowed`, alg))
+	}
+	return p.checkKey(key)
+}
+
+// CheckContentEncryption returns an error if the given content encryption
+// algorithm may not be used under this policy.
+func (p *Policy) CheckContentEncryption(alg jwa.ContentEncryptionAlgorithm) error {
+	if p == nil {
+		return nil
+	}
+
+	if !p.allowsContentEncryptionAlgorithm(alg) {
+		return newPolicyError(errAlgorithmNotAllowed, fmt.Errorf(`content encryption algorithm %q is not allowed`, alg))
+	}
+	return nil
+}
+
+func (p *Policy) allowsSignatureAlgorithm(alg jwa.SignatureAlgorithm) bool {
+	if len(p.SignatureAlgorithms) == 0 {
+		return true
+	}
+	for _, v := range p.SignatureAlgorithms {
+		if v == alg {
+			return true
+		}
+	}
+	return false
+}
+
+func (p *Policy) allowsKeyEncryptionAlgorithm(alg jwa.KeyEncryptionAlgorithm) bool {
+	if len(p.KeyEncryptionAlgorithms) == 0 {
+		return true
+	}
+	for _, v := range p.KeyEncryptionAlgorithms {
+		if v == alg {
+			return true
+		}
+	}
+	return false
+}
+
+func (p *Policy) allowsContentEncryptionAlgorithm(alg jwa.ContentEncryptionAlgorithm) bool {
+	if len(p.ContentEncryptionAlgorithms) == 0 {
+		return true
+	}
+	for _, v := range p.ContentEncryptionAlgorithms {
+		if v == alg {
+			return true
+		}
+	}
+	return false
+}
+
+func (p *Policy) allowsCurve(crv jwa.EllipticCurveAlgorithm) bool {
+	if len(p.Curves) == 0 {
+		return true
+	}
+	for _, v := range p.Curves {
+		if v == crv {
+			return true
+		}
+	}
+	return false
+}
+
+// rawKeyOf returns the raw key for jwk.Key objects, and the key
+// itself for everything else
+func rawKeyOf(key interface{}) interface{} {
+	if jwkKey, ok := key.(jwk.Key); ok {
+		var raw interface{}
+		if err := jwkKey.Raw(&raw); err == nil {
+			return raw
+		}
+	}
+	return key
+}
+
+// publicKeyer is implemented by keys that can report their public key,
+// such as crypto.Signer and crypto.Decrypter implementations
+type publicKeyer interface {
+	Public() crypto.PublicKey
+}
+
+// checkKey checks the RSA key size and curves for asymmetric keys.
+// Keys whose strength can not be determined are rejected if the policy
+// restricts the RSA key size or the curves
+func (p *Policy) checkKey(key interface{}) error {
+	var crv jwa.EllipticCurveAlgorithm
+	switch key := rawKeyOf(key).(type) {
+	case rsa.PublicKey:
+		return p.checkRSAKeySize(&key)
+	case *rsa.PublicKey:
+		return p.checkRSAKeySize(key)
+	case rsa.PrivateKey:
+		return p.checkRSAKeySize(&key.PublicKey)
+	case *rsa.PrivateKey:
+		return p.checkRSAKeySize(&key.PublicKey)
+	case ecdsa.PublicKey:
+		crv = curveAlgorithmOf(&key)
+	case *ecdsa.PublicKey:
+		crv = curveAlgorithmOf(key)
+	case ecdsa.PrivateKey:
+		crv = curveAlgorithmOf(&key.PublicKey)
+	case *ecdsa.PrivateKey:
+		crv = curveAlgorithmOf(&key.PublicKey)
+	case ed25519.PublicKey, ed25519.PrivateKey:
+		crv = jwa.Ed25519
+	case ed448.PublicKey, ed448.PrivateKey:
+		crv = jwa.Ed448
+	case x25519.PublicKey, x25519.PrivateKey:
+		crv = jwa.X25519
+	case x448.PublicKey, x448.PrivateKey:
+		crv = jwa.X448
+	case *ecdh.PublicKey:
+		crv = ecdhCurveAlgorithmOf(key.Curve())
+	case *ecdh.PrivateKey:
+		crv = ecdhCurveAlgorithmOf(key.Curve())
+	case publicKeyer:
+		return p.checkKey(key.Public())
+	default:
+		return p.checkUnknownKey(key)
+	}
+
+	if !p.allowsCurve(crv) {
+		return newPolicyError(errCurveNotAllowed, fmt.Errorf(`curve %q is not allowed`, crv))
+	}
+	return nil
+}
+
+// checkUnknownKey handles keys that checkKey does not know about. Keys
+// that have no RSA key size or curve (symmetric keys and AKP keys) are
+// accepted. Everything else, for example a `jws.ContextSigner` that does
+// not expose its public key, is rejected if the policy restricts the RSA
+// key size or the curves, as the key can not be checked against them.
+func (p *Policy) checkUnknownKey(key interface{}) error {
+	if key == nil || (p.MinRSAKeySize <= 0 && len(p.Curves) == 0) {
+		return nil
+	}
+
+	if jwkKey, err := jwk.FromRaw(key); err == nil {
+		switch jwkKey.KeyType() {
+		case jwa.OctetSeq, jwa.AKP:
+			return nil
+		}
+	}
+	return newPolicyError(errPolicyViolation, fmt.Errorf(`strength of key of type %T can not be determined (keys must implement Public() crypto.PublicKey)`, key))
+}
+
+func (p *Policy) checkRSAKeySize(key *rsa.PublicKey) error {
+	if key.N == nil {
+		return nil
+	}
+	if size := key.N.BitLen(); size < p.MinRSAKeySize {
+		return newPolicyError(errKeyTooSmall, fmt.Errorf(`RSA key size %d is smaller than the required %d bits`, size, p.MinRSAKeySize))
+	}
+	return nil
+}
+
+func curveAlgorithmOf(key *ecdsa.PublicKey) jwa.EllipticCurveAlgorithm {
+	if key.Curve == nil {
+		return jwa.InvalidEllipticCurve
+	}
+	if alg, ok := ecutil.AlgorithmForCurve(key.Curve); ok {
+		return alg
+	}
+	return jwa.EllipticCurveAlgorithm(key.Curve.Params().Name)
+}
+
+func ecdhCurveAlgorithmOf(crv ecdh.Curve) jwa.EllipticCurveAlgorithm {
+	switch crv {
+	case ecdh.P256():
+		return jwa.P256
+	case ecdh.P384():
+		return jwa.P384
+	case ecdh.P521():
+		return jwa.P521
+	case ecdh.X25519():
+		return jwa.X25519
+	default:
+		return jwa.InvalidEllipticCurve
+	}
+}
+
+// PolicyError is the type of errors returned when a `jwx.Policy` is
+// violated.
+type PolicyError interface {
+	error
+	isPolicyError()
+	Unwrap() error
+}
+
+type policyErrorKind int
+
+const (
+	policyViolation policyErrorKind = iota
+	policyAlgorithmNotAllowed
+	policyKeyTooSmall
+	policyCurveNotAllowed
+)
+
+type policyError struct {
+	kind policyErrorKind
+	err  error
+}
+
+func newPolicyError(kind *policyError, err error) PolicyError {
+	return &policyError{kind: kind.kind, err: err}
+}
+
+func (*policyError) isPolicyError() {}
+
+func (e *policyError) Unwrap() error {
+	return e.err
+}
+
+func (e *policyError) Error() string {
+	if e.err == nil {
+		return `policy violation`
+	}
+	return `policy violation: ` + e.err.Error()
+}
+
+// Is returns true if target is the error returned by
+// `jwx.ErrPolicyViolation()`, or is of the same kind
+func (e *policyError) Is(target error) bool {
+	t, ok := target.(*policyError)
+	if !ok {
+		return false
+	}
+	return t.kind == policyViolation || t.kind == e.kind
+}
+
+var errPolicyViolation = &policyError{kind: policyViolation}
+var errAlgorithmNotAllowed = &policyError{kind: policyAlgorithmNotAllowed}
+var errKeyTooSmall = &policyError{kind: policyKeyTooSmall}
+var errCurveNotAllowed = &policyError{kind: policyCurveNotAllowed}
+
+// ErrPolicyViolation returns the immutable error that matches all
+// errors returned when a `jwx.Policy` is violated.
+//
+// The return value should only be used for comparison using `errors.Is()`
+func ErrPolicyViolation() PolicyError {
+	return errPolicyViolation
+}
+
+// ErrAlgorithmNotAllowed returns the immutable error used when an
+// algorithm is not allowed by a `jwx.Policy`.
+//
+// The return value should only be used for comparison using `errors.Is()`
+func ErrAlgorithmNotAllowed() PolicyError {
+	return errAlgorithmNotAllowed
+}
+
+// ErrKeyTooSmall returns the immutable error used when an RSA or HMAC
+// key is smaller than the size required by a `jwx.Policy`.
+//
+// The return value should only be used for comparison using `errors.Is()`
+func ErrKeyTooSmall() PolicyError {
+	return errKeyTooSmall
+}
+
+// ErrCurveNotAllowed returns the immutable error used when the curve
+// of an EC or OKP key is not allowed by a `jwx.Policy`.
+//
+// The return value should only be used for comparison using `errors.Is()`
+func ErrCurveNotAllowed() PolicyError {
+	return errCurveNotAllowed
+}
@@ -0,0 +1,157 @@
+package jwx_test
+
+import (
+	"crypto/ecdh"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"errors"
+	"testing"
+
+	"github.com/lestrrat-go/jwx/v2"
+	"github.com/lestrrat-go/jwx/v2/jwa"
+	"github.com/lestrrat-go/jwx/v2/jwe"
+	"github.com/lestrrat-go/jwx/v2/jwk"
+	"github.com/lestrrat-go/jwx/v2/jws"
+	"github.com/lestrrat-go/jwx/v2/jwt"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPolicy(t *testing.T) {
+	payload := []byte(`Lorem ipsum`)
+
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err, `rsa.GenerateKey should succeed`)
+	ecKey, err := ecdsa.GenerateKey(elliptic.P384(), rand.Reader)
+	require.NoError(t, err, `ecdsa.GenerateKey should succeed`)
+
+	t.Run("Zero value", func(t *testing.T) {
+		var policy *jwx.Policy
+		require.NoError(t, policy.CheckSignature(jwa.RS256, rsaKey), `nil policy should accept everything`)
+		require.NoError(t, (&jwx.Policy{}).CheckKeyEncryption(jwa.RSA1_5, rsaKey), `zero value should accept everything`)
+	})
+	t.Run("Checks", func(t *testing.T) {
+		policy := &jwx.Policy{
+			SignatureAlgorithms:         []jwa.SignatureAlgorithm{jwa.RS256, jwa.ES384, jwa.HS256},
+			KeyEncryptionAlgorithms:     []jwa.KeyEncryptionAlgorithm{jwa.RSA_OAEP_256},
+			ContentEncryptionAlgorithms: []jwa.ContentEncryptionAlgorithm{jwa.A256GCM},
+			Curves:                      []jwa.EllipticCurveAlgorithm{jwa.P256},
+			MinRSAKeySize:               3072,
+			MinHMACKeySize:              32,
+		}
+
+		err := policy.CheckSignature(jwa.PS256, nil)
+		require.True(t, errors.Is(err, jwx.ErrAlgorithmNotAllowed()), `error should be ErrAlgorithmNotAllowed`)
+		require.True(t, errors.Is(err, jwx.ErrPolicyViolation()), `error should be ErrPolicyViolation`)
+		require.False(t, errors.Is(err, jwx.ErrKeyTooSmall()), `error should not be ErrKeyTooSmall`)
+
+		var perr jwx.PolicyError
+		require.True(t, errors.As(err, &perr), `error should be a jwx.PolicyError`)
+
+		err = policy.CheckSignature(jwa.RS256, rsaKey)
+		require.True(t, errors.Is(err, jwx.ErrKeyTooSmall()), `error should be ErrKeyTooSmall`)
+
+		jwkKey, err := jwk.FromRaw(ecKey)
+		require.NoError(t, err, `jwk.FromRaw should succeed`)
+		err = policy.CheckSignature(jwa.ES384, jwkKey)
+		require.True(t, errors.Is(err, jwx.ErrCurveNotAllowed()), `error should be ErrCurveNotAllowed`)
+
+		err = policy.CheckSignature(jwa.HS256, []byte(`short`))
+		require.True(t, errors.Is(err, jwx.ErrKeyTooSmall()), `error should be ErrKeyTooSmall`)
+		require.NoError(t, policy.CheckSignature(jwa.HS256, make([]byte, 32)), `32 byte HMAC key should be accepted`)
+
+		err = policy.CheckKeyEncryption(jwa.RSA1_5, nil)
+		require.True(t, errors.Is(err, jwx.ErrAlgorithmNotAllowed()), `error should be ErrAlgorithmNotAllowed`)
+
+		err = policy.CheckContentEncryption(jwa.A128CBC_HS256)
+		require.True(t, errors.Is(err, jwx.ErrAlgorithmNotAllowed()), `error should be ErrAlgorithmNotAllowed`)
+		require.NoError(t, policy.CheckContentEncryption(jwa.A256GCM), `A256GCM should be accepted`)
+	})
+	t.Run("Key types", func(t *testing.T) {
+		policy := &jwx.Policy{Curves: []jwa.EllipticCurveAlgorithm{jwa.P256}}
+
+		ecdhKey, err := ecdh.P384().GenerateKey(rand.Reader)
+		require.NoError(t, err, `ecdh.GenerateKey should succeed`)
+		err = policy.CheckKeyEncryption(jwa.ECDH_ES, ecdhKey.PublicKey())
+		require.True(t, errors.Is(err, jwx.ErrCurveNotAllowed()), `error should be ErrCurveNotAllowed`)
+
+		require.NoError(t, policy.CheckKeyEncryption(jwa.A128KW, make([]byte, 16)), `symmetric keys should be accepted`)
+
+		// keys whose strength can not be determined are rejected, unless
+		// the policy does not care about the key strength
+		err = policy.CheckSignature(jwa.ES256, struct{}{})
+		require.True(t, errors.Is(err, jwx.ErrPolicyViolation()), `error should be ErrPolicyViolation`)
+		require.NoError(t, (&jwx.Policy{MinHMACKeySize: 32}).CheckSignature(jwa.ES256, struct{}{}), `unknown keys should be accepted`)
+	})
+	t.Run("jws", func(t *testing.T) {
+		policy := &jwx.Policy{MinRSAKeySize: 3072}
+
+		_, err := jws.Sign(payload, jws.WithKey(jwa.RS256, rsaKey), jws.WithPolicy(policy))
+		require.True(t, errors.Is(err, jwx.ErrKeyTooSmall()), `jws.Sign should fail with ErrKeyTooSmall`)
+
+		signed, err := jws.Sign(payload, jws.WithKey(jwa.RS256, rsaKey))
+		require.NoError(t, err, `jws.Sign should succeed`)
+
+		_, err = jws.Verify(signed, jws.WithKey(jwa.RS256, &rsaKey.PublicKey), jws.WithPolicy(policy))
+		require.True(t, errors.Is(err, jwx.ErrKeyTooSmall()), `jws.Verify should fail with ErrKeyTooSmall`)
+
+		_, err = jws.Sign(payload, jws.WithInsecureNoSignature(), jws.WithPolicy(&jwx.Policy{SignatureAlgorithms: []jwa.SignatureAlgorithm{jwa.RS256}}))
+		require.True(t, errors.Is(err, jwx.ErrAlgorithmNotAllowed()), `jws.Sign should fail with ErrAlgorithmNotAllowed`)
+	})
+	t.Run("jwe", func(t *testing.T) {
+		policy := &jwx.Policy{
+			KeyEncryptionAlgorithms:     []jwa.KeyEncryptionAlgorithm{jwa.RSA_OAEP_256},
+			ContentEncryptionAlgorithms: []jwa.ContentEncryptionAlgorithm{jwa.A256GCM},
+		}
+
+		_, err := jwe.Encrypt(payload, jwe.WithKey(jwa.RSA1_5, &rsaKey.PublicKey), jwe.WithPolicy(policy))
+		require.True(t, errors.Is(err, jwx.ErrAlgorithmNotAllowed()), `jwe.Encrypt should fail with ErrAlgorithmNotAllowed`)
+
+		_, err = jwe.Encrypt(payload, jwe.WithKey(jwa.RSA_OAEP_256, &rsaKey.PublicKey), jwe.WithContentEncryption(jwa.A128GCM), jwe.WithPolicy(policy))
+		require.True(t, errors.Is(err, jwx.ErrAlgorithmNotAllowed()), `jwe.Encrypt should fail with ErrAlgorithmNotAllowed`)
+
+		encrypted, err := jwe.Encrypt(payload, jwe.WithKey(jwa.RSA1_5, &rsaKey.PublicKey))
+		require.NoError(t, err, `jwe.Encrypt should succeed`)
+
+		_, err = jwe.Decrypt(encrypted, jwe.WithKey(jwa.RSA1_5, rsaKey), jwe.WithPolicy(policy))
+		require.True(t, errors.Is(err, jwx.ErrAlgorithmNotAllowed()), `jwe.Decrypt should fail with ErrAlgorithmNotAllowed`)
+
+		encrypted, err = jwe.Encrypt(payload, jwe.WithKey(jwa.RSA_OAEP_256, &rsaKey.PublicKey))
+		require.NoError(t, err, `jwe.Encrypt should succeed`)
+
+		decrypted, err := jwe.Decrypt(encrypted, jwe.WithKey(jwa.RSA_OAEP_256, rsaKey), jwe.WithPolicy(policy))
+		require.NoError(t, err, `jwe.Decrypt should succeed`)
+		require.Equal(t, payload, decrypted, `payload should match`)
+	})
+	t.Run("jwt", func(t *testing.T) {
+		policy := &jwx.Policy{Curves: []jwa.EllipticCurveAlgorithm{jwa.P256}}
+		tok := jwt.New()
+
+		_, err := jwt.Sign(tok, jwt.WithKey(jwa.ES384, ecKey), jwt.WithPolicy(policy))
+		require.True(t, errors.Is(err, jwx.ErrCurveNotAllowed()), `jwt.Sign should fail with ErrCurveNotAllowed`)
+
+		signed, err := jwt.Sign(tok, jwt.WithKey(jwa.ES384, ecKey))
+		require.NoError(t, err, `jwt.Sign should succeed`)
+
+		_, err = jwt.Parse(signed, jwt.WithKey(jwa.ES384, &ecKey.PublicKey), jwt.WithPolicy(policy))
+		require.True(t, errors.Is(err, jwx.ErrCurveNotAllowed()), `jwt.Parse should fail with ErrCurveNotAllowed`)
+
+		_, err = jwt.Parse(signed, jwt.WithKey(jwa.ES384, &ecKey.PublicKey))
+		require.NoError(t, err, `jwt.Parse should succeed`)
+	})
+	t.Run("Default policy", func(t *testing.T) {
+		jwx.SetDefaultPolicy(&jwx.Policy{SignatureAlgorithms: []jwa.SignatureAlgorithm{jwa.ES384}})
+		defer jwx.SetDefaultPolicy(nil)
+
+		_, err := jws.Sign(payload, jws.WithKey(jwa.RS256, rsaKey))
+		require.True(t, errors.Is(err, jwx.ErrAlgorithmNotAllowed()), `jws.Sign should fail with ErrAlgorithmNotAllowed`)
+
+		_, err = jws.Sign(payload, jws.WithKey(jwa.ES384, ecKey))
+		require.NoError(t, err, `jws.Sign should succeed`)
+
+		// explicitly specified policies take precedence
+		_, err = jws.Sign(payload, jws.WithKey(jwa.RS256, rsaKey), jws.WithPolicy(nil))
+		require.NoError(t, err, `jws.Sign should succeed`)
+	})
+}
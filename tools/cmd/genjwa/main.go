@@ -73,6 +73,16 @@ func _main() error {
 					value:   `A256GCM`,
 					comment: `AES-GCM (256)`,
 				},
+				{
+					name:    `C20P`,
+					value:   `C20P`,
+					comment: `ChaCha20-Poly1305`,
+				},
+				{
+					name:    `XC20P`,
+					value:   `XC20P`,
+					comment: `XChaCha20-Poly1305`,
+				},
 			},
 		},
 		{
@@ -106,6 +116,11 @@ func _main() error {
 					value:   `OKP`,
 					comment: `Octet string key pairs`,
 				},
+				{
+					name:    `AKP`,
+					value:   `AKP`,
+					comment: `Algorithm key pairs`,
+				},
 			},
 		},
 		{
@@ -130,6 +145,18 @@ func _main() error {
 					name:  `P521`,
 					value: `P-521`,
 				},
+				{
+					name:  `BrainpoolP256r1`,
+					value: `brainpoolP256r1`,
+				},
+				{
+					name:  `BrainpoolP384r1`,
+					value: `brainpoolP384r1`,
+				},
+				{
+					name:  `BrainpoolP512r1`,
+					value: `brainpoolP512r1`,
+				},
 				{
 					name:  `Ed25519`,
 					value: `Ed25519`,
@@ -207,6 +234,21 @@ func _main() error {
 					value:   "ES256K",
 					comment: `ECDSA using secp256k1 and SHA-256`,
 				},
+				{
+					name:    `ESB256`,
+					value:   "ESB256",
+					comment: `ECDSA using brainpoolP256r1 and SHA-256`,
+				},
+				{
+					name:    `ESB384`,
+					value:   "ESB384",
+					comment: `ECDSA using brainpoolP384r1 and SHA-384`,
+				},
+				{
+					name:    `ESB512`,
+					value:   "ESB512",
+					comment: `ECDSA using brainpoolP512r1 and SHA-512`,
+				},
 				{
 					name:    `EdDSA`,
 					value:   `EdDSA`,
@@ -227,6 +269,21 @@ func _main() error {
 					value:   `PS512`,
 					comment: `RSASSA-PSS using SHA512 and MGF1-SHA512`,
 				},
+				{
+					name:    `MLDSA44`,
+					value:   `ML-DSA-44`,
+					comment: `ML-DSA using parameter set ML-DSA-44`,
+				},
+				{
+					name:    `MLDSA65`,
+					value:   `ML-DSA-65`,
+					comment: `ML-DSA using parameter set ML-DSA-65`,
+				},
+				{
+					name:    `MLDSA87`,
+					value:   `ML-DSA-87`,
+					comment: `ML-DSA using parameter set ML-DSA-87`,
+				},
 			},
 		},
 		{
@@ -249,6 +306,16 @@ func _main() error {
 					value:   "RSA-OAEP-256",
 					comment: `RSA-OAEP-SHA256`,
 				},
+				{
+					name:    `RSA_OAEP_384`,
+					value:   "RSA-OAEP-384",
+					comment: `RSA-OAEP-SHA384`,
+				},
+				{
+					name:    `RSA_OAEP_512`,
+					value:   "RSA-OAEP-512",
+					comment: `RSA-OAEP-SHA512`,
+				},
 				{
 					name:    `A128KW`,
 					value:   "A128KW",
@@ -289,6 +356,26 @@ func _main() error {
 					value:   "ECDH-ES+A256KW",
 					comment: `ECDH-ES + AES key wrap (256)`,
 				},
+				{
+					name:    `ECDH_1PU`,
+					value:   "ECDH-1PU",
+					comment: `ECDH-1PU`,
+				},
+				{
+					name:    `ECDH_1PU_A128KW`,
+					value:   "ECDH-1PU+A128KW",
+					comment: `ECDH-1PU + AES key wrap (128)`,
+				},
+				{
+					name:    `ECDH_1PU_A192KW`,
+					value:   "ECDH-1PU+A192KW",
+					comment: `ECDH-1PU + AES key wrap (192)`,
+				},
+				{
+					name:    `ECDH_1PU_A256KW`,
+					value:   "ECDH-1PU+A256KW",
+					comment: `ECDH-1PU + AES key wrap (256)`,
+				},
 				{
 					name:    `A128GCMKW`,
 					value:   "A128GCMKW",
@@ -304,6 +391,16 @@ func _main() error {
 					value:   "A256GCMKW",
 					comment: `AES-GCM key wrap (256)`,
 				},
+				{
+					name:    `C20PKW`,
+					value:   "C20PKW",
+					comment: `ChaCha20-Poly1305 key wrap`,
+				},
+				{
+					name:    `XC20PKW`,
+					value:   "XC20PKW",
+					comment: `XChaCha20-Poly1305 key wrap`,
+				},
 				{
 					name:    `PBES2_HS256_A128KW`,
 					value:   "PBES2-HS256+A128KW",
@@ -319,6 +416,56 @@ func _main() error {
 					value:   "PBES2-HS512+A256KW",
 					comment: `PBES2 + HMAC-SHA512 + AES key wrap (256)`,
 				},
+				{
+					name:    `MLKEM768`,
+					value:   "MLKEM768",
+					comment: `ML-KEM-768 (direct key agreement, experimental)`,
+				},
+				{
+					name:    `MLKEM1024`,
+					value:   "MLKEM1024",
+					comment: `ML-KEM-1024 (direct key agreement, experimental)`,
+				},
+				{
+					name:    `MLKEM768_A192KW`,
+					value:   "MLKEM768+A192KW",
+					comment: `ML-KEM-768 + AES key wrap (192, experimental)`,
+				},
+				{
+					name:    `MLKEM1024_A256KW`,
+					value:   "MLKEM1024+A256KW",
+					comment: `ML-KEM-1024 + AES key wrap (256, experimental)`,
+				},
+				{
+					name:    `HPKE_0`,
+					value:   "HPKE-0",
+					comment: `HPKE using DHKEM(P-256, HKDF-SHA256), HKDF-SHA256 and AES-128-GCM (integrated encryption)`,
+				},
+				{
+					name:    `HPKE_3`,
+					value:   "HPKE-3",
+					comment: `HPKE using DHKEM(X25519, HKDF-SHA256), HKDF-SHA256 and AES-128-GCM (integrated encryption)`,
+				},
+				{
+					name:    `HPKE_4`,
+					value:   "HPKE-4",
+					comment: `HPKE using DHKEM(X25519, HKDF-SHA256), HKDF-SHA256 and ChaCha20Poly1305 (integrated encryption)`,
+				},
+				{
+					name:    `HPKE_0_KE`,
+					value:   "HPKE-0-KE",
+					comment: `HPKE using DHKEM(P-256, HKDF-SHA256), HKDF-SHA256 and AES-128-GCM (key encryption)`,
+				},
+				{
+					name:    `HPKE_3_KE`,
+					value:   "HPKE-3-KE",
+					comment: `HPKE using DHKEM(X25519, HKDF-SHA256), HKDF-SHA256 and AES-128-GCM (key encryption)`,
+				},
+				{
+					name:    `HPKE_4_KE`,
+					value:   "HPKE-4-KE",
+					comment: `HPKE using DHKEM(X25519, HKDF-SHA256), HKDF-SHA256 and ChaCha20Poly1305 (key encryption)`,
+				},
 			},
 		},
 	}
@@ -364,6 +511,8 @@ var isSymmetricKeyEncryption = map[string]struct{}{
 	`A128GCMKW`: {},
 	`A192GCMKW`: {},
 	`A256GCMKW`: {},
+	`C20PKW`:    {},
+	`XC20PKW`:   {},
 
 	`PBES2_HS256_A128KW`: {},
 	`PBES2_HS384_A192KW`: {},